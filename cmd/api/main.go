@@ -58,6 +58,35 @@ func main() {
 			log.Error("Storage cleanup job failed", zap.Error(err))
 		}
 	})
+	_, _ = scheduler.Every(1).Hour().Do(func() {
+		if err := serviceContainer.ContributionService.SendDueReminders(); err != nil {
+			log.Error("Contribution reminder job failed", zap.Error(err))
+		}
+	})
+	_, _ = scheduler.Every(1).Day().At("03:00").Do(func() {
+		if err := serviceContainer.TrashService.PurgeExpired(); err != nil {
+			log.Error("Trash purge job failed", zap.Error(err))
+		}
+	})
+	_, _ = scheduler.Every(1).Hour().Do(func() {
+		// Roll up yesterday again so views recorded just before midnight are included
+		now := time.Now().UTC()
+		for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+			if err := serviceContainer.AnalyticsService.RollupDailyStats(day); err != nil {
+				log.Error("Board analytics rollup job failed", zap.Error(err))
+			}
+		}
+	})
+	_, _ = scheduler.Every(1).Day().At("03:30").Do(func() {
+		if err := serviceContainer.AnalyticsService.PruneViews(); err != nil {
+			log.Error("Board view pruning job failed", zap.Error(err))
+		}
+	})
+	_, _ = scheduler.Every(1).Day().At("04:00").Do(func() {
+		if err := serviceContainer.PostService.RebalancePostRanks(); err != nil {
+			log.Error("Post rank rebalance job failed", zap.Error(err))
+		}
+	})
 	scheduler.StartAsync()
 
 	// Create Gin router
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// AnalyticsHandler handles board analytics requests
+type AnalyticsHandler struct {
+	analyticsService *services.AnalyticsService
+	cfg              *config.Config
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler
+func NewAnalyticsHandler(analyticsService *services.AnalyticsService, cfg *config.Config) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+		cfg:              cfg,
+	}
+}
+
+// GetBoardAnalytics returns a board's views and activity per day
+func (h *AnalyticsHandler) GetBoardAnalytics(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.BoardAnalyticsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Default to the last 30 days
+	to := time.Now().UTC()
+	if query.To != "" {
+		to, _ = time.Parse("2006-01-02", query.To)
+	}
+	from := to.AddDate(0, 0, -29)
+	if query.From != "" {
+		from, _ = time.Parse("2006-01-02", query.From)
+	}
+
+	// Get analytics using service
+	analytics, err := h.analyticsService.GetBoardAnalytics(uint(boardID), userID, from, to)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := responses.BoardAnalyticsResponse{
+		BoardID:           uint(boardID),
+		From:              analytics.From.Format("2006-01-02"),
+		To:                analytics.To.Format("2006-01-02"),
+		Views:             analytics.Views,
+		UniqueVisitors:    analytics.UniqueVisitors,
+		NewPosts:          analytics.NewPosts,
+		NewLikes:          analytics.NewLikes,
+		NewContributors:   analytics.NewContributors,
+		TotalPosts:        analytics.TotalPosts,
+		TotalLikes:        analytics.TotalLikes,
+		TotalContributors: analytics.TotalContributors,
+		Daily:             make([]responses.DailyBoardStatsResponse, len(analytics.Daily)),
+	}
+	for i, day := range analytics.Daily {
+		response.Daily[i] = responses.DailyBoardStatsResponse{
+			Day:               day.Day.Format("2006-01-02"),
+			Views:             day.Views,
+			UniqueVisitors:    day.UniqueVisitors,
+			NewPosts:          day.NewPosts,
+			NewLikes:          day.NewLikes,
+			NewContributors:   day.NewContributors,
+			TotalPosts:        day.TotalPosts,
+			TotalLikes:        day.TotalLikes,
+			TotalContributors: day.TotalContributors,
+		}
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(response))
+}
+
+// GetBoardViewers lists the signed-in users who opened a private board
+func (h *AnalyticsHandler) GetBoardViewers(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Get viewers using service
+	viewers, err := h.analyticsService.GetBoardViewers(uint(boardID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	viewerResponses := make([]responses.BoardViewerResponse, len(viewers))
+	for i, viewer := range viewers {
+		viewerResponses[i] = responses.BoardViewerResponse{
+			User:          responses.NewUserResponse(&viewer.User),
+			FirstViewedAt: viewer.FirstViewedAt,
+			LastViewedAt:  viewer.LastViewedAt,
+			VisitDays:     viewer.VisitDays,
+			ViewCount:     viewer.ViewCount,
+		}
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(viewerResponses))
+}
@@ -194,3 +194,39 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 		"message": "Password has been reset successfully",
 	}))
 }
+
+// VerifyEmail confirms a user's email address using the token from the verification email
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req requests.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	user, err := h.authService.VerifyEmail(req.Token)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(responses.NewUserResponse(user)))
+}
+
+// ResendVerification sends the current user a new verification email
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	if err := h.authService.ResendVerification(userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{
+		"message": "Verification email sent",
+	}))
+}
@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"io"
 	"kudoboard-api/internal/config"
 	"kudoboard-api/internal/dto/requests"
 	"kudoboard-api/internal/dto/responses"
@@ -10,25 +14,31 @@ import (
 	"kudoboard-api/internal/utils"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // BoardHandler handles board-related requests
 type BoardHandler struct {
-	boardService *services.BoardService
-	postService  *services.PostService
-	themeService *services.ThemeService
-	authService  *services.AuthService
-	cfg          *config.Config
+	boardService     *services.BoardService
+	postService      *services.PostService
+	themeService     *services.ThemeService
+	authService      *services.AuthService
+	analyticsService *services.AnalyticsService
+	sectionService   *services.SectionService
+	cfg              *config.Config
 }
 
 // NewBoardHandler creates a new BoardHandler
-func NewBoardHandler(boardService *services.BoardService, postService *services.PostService, themeService *services.ThemeService, authService *services.AuthService, cfg *config.Config) *BoardHandler {
+func NewBoardHandler(boardService *services.BoardService, postService *services.PostService, themeService *services.ThemeService, authService *services.AuthService, analyticsService *services.AnalyticsService, sectionService *services.SectionService, cfg *config.Config) *BoardHandler {
 	return &BoardHandler{
-		boardService: boardService,
-		postService:  postService,
-		themeService: themeService,
-		authService:  authService,
-		cfg:          cfg,
+		boardService:     boardService,
+		postService:      postService,
+		themeService:     themeService,
+		authService:      authService,
+		analyticsService: analyticsService,
+		sectionService:   sectionService,
+		cfg:              cfg,
 	}
 }
 
@@ -88,7 +98,7 @@ func (h *BoardHandler) ListUserBoards(c *gin.Context) {
 	}
 
 	// Get boards using service
-	boardsWithInfo, total, err := h.boardService.ListUserBoards(userID, query.Page, query.PerPage, query.Search, query.SortBy, query.Order)
+	boardsWithInfo, total, err := h.boardService.ListUserBoards(userID, query)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -97,16 +107,15 @@ func (h *BoardHandler) ListUserBoards(c *gin.Context) {
 	// Build response
 	boardResponses := make([]responses.BoardResponseWithRelation, len(boardsWithInfo))
 	for i, boardInfo := range boardsWithInfo {
-		postCount := h.postService.CountPostsInBoard(boardInfo.ID)
-
 		// Create response
 		boardResponses[i] = responses.NewBoardResponseWithRelation(
 			&boardInfo.Board,
 			&boardInfo.Creator,
-			postCount,
+			boardInfo.PostCount,
 			boardInfo.IsOwner,
 			boardInfo.IsFavorite,
 			boardInfo.IsArchived,
+			boardInfo.FolderID,
 		)
 	}
 
@@ -132,8 +141,16 @@ func (h *BoardHandler) GetBoardBySlug(c *gin.Context) {
 		userID = user.(*models.User).ID
 	}
 
+	// Parse query parameters. With a limit, only the first page of posts is returned.
+	var query requests.BoardPostsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+	paginated := query.Limit > 0
+
 	// Get board by slug using service
-	board, creator, posts, err := h.boardService.GetBoardBySlug(slug)
+	board, creator, posts, err := h.boardService.GetBoardBySlug(slug, userID, !paginated)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -149,8 +166,29 @@ func (h *BoardHandler) GetBoardBySlug(c *gin.Context) {
 		}
 	}
 
+	// Record the view, except for the board's own creator
+	if userID == 0 || userID != board.CreatorID {
+		h.analyticsService.RecordView(board.ID, userID, c.ClientIP(), c.Request.UserAgent())
+	}
+
+	// Count approved posts; moderators and authors may also see pending ones
+	var postCount int64
+	if paginated {
+		postCount = h.postService.CountPostsInBoard(board.ID)
+	}
+	for _, post := range posts {
+		if post.Status == models.PostStatusApproved {
+			postCount++
+		}
+	}
+
 	// Create board response
-	boardResponse := responses.NewBoardResponse(board, creator, int64(len(posts)))
+	boardResponse := responses.NewBoardResponse(board, creator, postCount)
+
+	// Show moderators how many posts await review
+	if h.boardService.CanModerateBoard(board, userID) {
+		boardResponse.PendingCount = h.postService.CountPendingPosts(board.ID)
+	}
 
 	// If board has a theme, include it
 	if board.ThemeID != nil {
@@ -161,26 +199,52 @@ func (h *BoardHandler) GetBoardBySlug(c *gin.Context) {
 		}
 	}
 
-	// Create post responses
-	postResponses := make([]responses.PostResponse, len(posts))
-	for i, post := range posts {
-		// Get post author if not anonymous
-		var author *models.User
-		if post.AuthorID != nil {
-			author, _ = h.authService.GetUserByID(*post.AuthorID)
+	// Get the sections posts are grouped in
+	sections, err := h.sectionService.ListBoardSections(board.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Return only the first page of posts if requested; pages can be limited to a section
+	if paginated {
+		page, err := h.postService.ListBoardPosts(board, userID, query)
+		if err != nil {
+			_ = c.Error(err)
+			return
 		}
 
-		// Count likes
-		likesCount, _ := h.postService.CountPostLikes(post.ID)
+		pageResponse := newPostPageResponse(page)
+		c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{
+			"board":       boardResponse,
+			"sections":    newSectionResponses(sections),
+			"posts":       pageResponse.Posts,
+			"next_cursor": pageResponse.NextCursor,
+			"has_more":    pageResponse.HasMore,
+		}))
+		return
+	}
 
-		// Create post response
-		postResponses[i] = responses.NewPostResponse(&post, author, likesCount)
+	// Load authors and likes of all posts at once
+	boardPosts, err := h.postService.LoadPostDetails(posts, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
-	// Add posts to response
+	// Add posts to response, both grouped by section and as a flat list for older clients
+	groups := services.GroupPostsBySection(sections, boardPosts)
+	sectionResponses := make([]responses.SectionPostsResponse, len(groups))
+	for i, group := range groups {
+		sectionResponses[i] = responses.SectionPostsResponse{
+			SectionResponse: responses.NewSectionResponse(&group.BoardSection, group.PostCount),
+			Posts:           newBoardPostResponses(group.Posts),
+		}
+	}
 	response := gin.H{
-		"board": boardResponse,
-		"posts": postResponses,
+		"board":    boardResponse,
+		"sections": sectionResponses,
+		"posts":    newBoardPostResponses(boardPosts),
 	}
 
 	c.JSON(http.StatusOK, responses.SuccessResponse(response))
@@ -227,6 +291,79 @@ func (h *BoardHandler) UpdateBoard(c *gin.Context) {
 	))
 }
 
+// GetBoardHistory lists the versions of a board's settings
+func (h *BoardHandler) GetBoardHistory(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Get history using service
+	versions, err := h.boardService.GetBoardHistory(uint(boardID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Build response
+	versionResponses := make([]responses.BoardVersionResponse, len(versions))
+	for i, version := range versions {
+		versionResponses[i] = responses.NewBoardVersionResponse(version.Version, version.Actor, version.ChangedAt, version.Changes)
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(versionResponses))
+}
+
+// RevertBoard restores a board's settings to a previous version
+func (h *BoardHandler) RevertBoard(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Get version from URL
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid version"))
+		return
+	}
+
+	// Revert board using service
+	board, err := h.boardService.RevertBoard(uint(boardID), userID, version)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Get user for response
+	user, _ := c.Get("user")
+
+	// Count posts
+	postCount := h.postService.CountPostsInBoard(board.ID)
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(
+		responses.NewBoardResponse(board, user.(*models.User), postCount),
+	))
+}
+
 // DeleteBoard deletes a board
 func (h *BoardHandler) DeleteBoard(c *gin.Context) {
 	// Get user ID from context
@@ -253,6 +390,47 @@ func (h *BoardHandler) DeleteBoard(c *gin.Context) {
 	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Board deleted successfully"}))
 }
 
+// DuplicateBoard creates a copy of a board
+func (h *BoardHandler) DuplicateBoard(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.DuplicateBoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Duplicate board using service
+	board, err := h.boardService.DuplicateBoard(uint(boardID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Get user for response
+	user, _ := c.Get("user")
+
+	// Count copied posts
+	postCount := h.postService.CountPostsInBoard(board.ID)
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(
+		responses.NewBoardResponse(board, user.(*models.User), postCount),
+	))
+}
+
 // ToggleBoardLock handles locking or unlocking a board
 func (h *BoardHandler) ToggleBoardLock(c *gin.Context) {
 	// Get user ID from context
@@ -335,6 +513,12 @@ func (h *BoardHandler) ListBoardContributors(c *gin.Context) {
 		}
 	}
 
+	// Export as CSV if requested
+	if c.Query("format") == "csv" {
+		writeContributorsCSV(c, uint(boardID), contributorResponses)
+		return
+	}
+
 	c.JSON(http.StatusOK, responses.SuccessResponse(contributorResponses))
 }
 
@@ -362,7 +546,7 @@ func (h *BoardHandler) UpdateBoardPreferences(c *gin.Context) {
 	}
 
 	// Update preferences using service
-	err = h.boardService.UpdateBoardPreferences(uint(boardID), userID, req.IsFavorite, req.IsArchived)
+	err = h.boardService.UpdateBoardPreferences(uint(boardID), userID, req)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -371,6 +555,32 @@ func (h *BoardHandler) UpdateBoardPreferences(c *gin.Context) {
 	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Board preferences updated successfully"}))
 }
 
+// BulkUpdateBoardPreferences moves, favorites or archives several boards at once
+func (h *BoardHandler) BulkUpdateBoardPreferences(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Parse request
+	var req requests.BulkUpdateBoardPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Update preferences using service
+	updated, err := h.boardService.BulkUpdateBoardPreferences(req.BoardIDs, userID, req.UpdateBoardPreferencesRequest)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"updated": updated}))
+}
+
 // AddContributor adds a new contributor to a board
 func (h *BoardHandler) AddContributor(c *gin.Context) {
 	// Get user ID from context
@@ -474,3 +684,155 @@ func (h *BoardHandler) RemoveContributor(c *gin.Context) {
 
 	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Contributor removed successfully"}))
 }
+
+// BulkAddContributors adds many contributors to a board from a CSV file or a JSON list
+func (h *BoardHandler) BulkAddContributors(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	// Parse request rows from CSV or JSON
+	entries, err := parseBulkContributorEntries(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Import contributors using service
+	results, err := h.boardService.BulkAddContributors(uint(boardID), userID, entries, dryRun)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(responses.NewBulkContributorsResponse(dryRun, results)))
+}
+
+// BulkUpdateContributors updates roles of or removes many contributors at once
+func (h *BoardHandler) BulkUpdateContributors(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	// Parse request
+	var req requests.BulkUpdateContributorsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Update contributors using service
+	results, err := h.boardService.BulkUpdateContributors(uint(boardID), userID, req.Changes, dryRun)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(responses.NewBulkContributorsResponse(dryRun, results)))
+}
+
+// parseBulkContributorEntries reads bulk contributor rows from a CSV upload, a CSV body or a JSON body
+func parseBulkContributorEntries(c *gin.Context) ([]requests.BulkContributorEntry, error) {
+	switch c.ContentType() {
+	case "multipart/form-data":
+		file, err := c.FormFile("file")
+		if err != nil {
+			return nil, utils.NewBadRequestError("Failed to read file")
+		}
+		src, err := file.Open()
+		if err != nil {
+			return nil, utils.NewInternalError("Failed to open uploaded file", err)
+		}
+		defer src.Close()
+		return parseContributorsCSV(src)
+	case "text/csv":
+		return parseContributorsCSV(c.Request.Body)
+	default:
+		var req requests.BulkAddContributorsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, utils.NewValidationError(err.Error())
+		}
+		return req.Contributors, nil
+	}
+}
+
+// parseContributorsCSV parses "email,role" rows, skipping an optional header row
+func parseContributorsCSV(reader io.Reader) ([]requests.BulkContributorEntry, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	var entries []requests.BulkContributorEntry
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, utils.NewBadRequestError(fmt.Sprintf("Invalid CSV: %s", err.Error()))
+		}
+
+		// Skip header row
+		if len(entries) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "email") {
+			continue
+		}
+
+		entry := requests.BulkContributorEntry{Email: strings.TrimSpace(record[0])}
+		if len(record) > 1 {
+			entry.Role = models.Role(strings.ToLower(strings.TrimSpace(record[1])))
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, utils.NewBadRequestError("CSV file contains no contributors")
+	}
+
+	return entries, nil
+}
+
+// writeContributorsCSV writes the contributor list as a CSV attachment
+func writeContributorsCSV(c *gin.Context, boardID uint, contributors []responses.BoardContributorResponse) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=board-%d-contributors.csv", boardID))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write([]string{"name", "email", "role", "added_at"})
+	for _, contributor := range contributors {
+		if contributor.User.ID == 0 {
+			continue
+		}
+		_ = writer.Write([]string{
+			contributor.User.Name,
+			contributor.User.Email,
+			contributor.Role,
+			contributor.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	writer.Flush()
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// CommentHandler handles comments on posts
+type CommentHandler struct {
+	postService *services.PostService
+	cfg         *config.Config
+}
+
+// NewCommentHandler creates a new CommentHandler
+func NewCommentHandler(postService *services.PostService, cfg *config.Config) *CommentHandler {
+	return &CommentHandler{
+		postService: postService,
+		cfg:         cfg,
+	}
+}
+
+// ListComments lists the comments on a post with their replies
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID format"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.CommentsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Set defaults if not provided
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PerPage < 1 {
+		query.PerPage = 20
+	}
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	threads, total, err := h.postService.ListComments(uint(postID), userID, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	commentResponses := make([]responses.CommentResponse, len(threads))
+	for i, thread := range threads {
+		commentResponses[i] = responses.NewCommentResponse(&thread.PostComment, thread.Author)
+		commentResponses[i].Replies = make([]responses.CommentResponse, len(thread.Replies))
+		for j, reply := range thread.Replies {
+			commentResponses[i].Replies[j] = responses.NewCommentResponse(&reply.PostComment, reply.Author)
+		}
+	}
+
+	pagination := &responses.Pagination{
+		Total:      total,
+		Page:       query.Page,
+		PerPage:    query.PerPage,
+		TotalPages: int((total + int64(query.PerPage) - 1) / int64(query.PerPage)),
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponseWithPagination(commentResponses, pagination))
+}
+
+// CreateComment comments on a post or replies to a comment
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID format"))
+		return
+	}
+
+	// Parse request
+	var req requests.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	comment, err := h.postService.CreateComment(uint(postID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(responses.NewCommentResponse(&comment.PostComment, comment.Author)))
+}
+
+// UpdateComment edits a comment
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get comment ID from URL
+	commentID, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid comment ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	comment, err := h.postService.UpdateComment(uint(commentID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(responses.NewCommentResponse(&comment.PostComment, comment.Author)))
+}
+
+// DeleteComment deletes a comment and its replies
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get comment ID from URL
+	commentID, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid comment ID"))
+		return
+	}
+
+	if err := h.postService.DeleteComment(uint(commentID), userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Comment deleted successfully"}))
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// ContentFilterHandler handles content filter word list requests.
+// Routes with a board ID manage that board's lists; the others manage site-wide lists.
+type ContentFilterHandler struct {
+	contentFilterService *services.ContentFilterService
+	boardService         *services.BoardService
+	cfg                  *config.Config
+}
+
+// NewContentFilterHandler creates a new ContentFilterHandler
+func NewContentFilterHandler(contentFilterService *services.ContentFilterService, boardService *services.BoardService, cfg *config.Config) *ContentFilterHandler {
+	return &ContentFilterHandler{
+		contentFilterService: contentFilterService,
+		boardService:         boardService,
+		cfg:                  cfg,
+	}
+}
+
+// ListFilterLists lists site-wide or board content filter lists
+func (h *ContentFilterHandler) ListFilterLists(c *gin.Context) {
+	// Resolve whose lists are managed
+	boardID, ok := h.filterScope(c)
+	if !ok {
+		return
+	}
+
+	// Get lists using service
+	lists, err := h.contentFilterService.ListFilterLists(boardID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Build response
+	listResponses := make([]responses.ContentFilterListResponse, len(lists))
+	for i := range lists {
+		listResponses[i] = responses.NewContentFilterListResponse(&lists[i])
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(listResponses))
+}
+
+// CreateFilterList creates a site-wide or board content filter list
+func (h *ContentFilterHandler) CreateFilterList(c *gin.Context) {
+	// Resolve whose lists are managed
+	boardID, ok := h.filterScope(c)
+	if !ok {
+		return
+	}
+
+	// Parse request
+	var req requests.CreateContentFilterListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Create list using service
+	list, err := h.contentFilterService.CreateFilterList(boardID, c.GetUint("userID"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(responses.NewContentFilterListResponse(list)))
+}
+
+// UpdateFilterList updates a site-wide or board content filter list
+func (h *ContentFilterHandler) UpdateFilterList(c *gin.Context) {
+	// Resolve whose lists are managed
+	boardID, ok := h.filterScope(c)
+	if !ok {
+		return
+	}
+
+	// Get list ID from URL
+	listID, err := strconv.ParseUint(c.Param("listId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid content filter ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.UpdateContentFilterListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Update list using service
+	list, err := h.contentFilterService.UpdateFilterList(uint(listID), boardID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(responses.NewContentFilterListResponse(list)))
+}
+
+// DeleteFilterList deletes a site-wide or board content filter list
+func (h *ContentFilterHandler) DeleteFilterList(c *gin.Context) {
+	// Resolve whose lists are managed
+	boardID, ok := h.filterScope(c)
+	if !ok {
+		return
+	}
+
+	// Get list ID from URL
+	listID, err := strconv.ParseUint(c.Param("listId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid content filter ID"))
+		return
+	}
+
+	// Delete list using service
+	if err := h.contentFilterService.DeleteFilterList(uint(listID), boardID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Content filter deleted successfully"}))
+}
+
+// ListFilterEvents lists recorded content filter matches, site-wide or for a board
+func (h *ContentFilterHandler) ListFilterEvents(c *gin.Context) {
+	// Resolve whose events are listed
+	boardID, ok := h.filterScope(c)
+	if !ok {
+		return
+	}
+
+	// Parse query parameters
+	var query requests.ContentFilterEventQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Set defaults if not provided
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PerPage < 1 {
+		query.PerPage = 20
+	}
+	if boardID != nil {
+		query.BoardID = *boardID
+	}
+
+	// Get events using service
+	events, total, err := h.contentFilterService.ListFilterEvents(query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Build response
+	eventResponses := make([]responses.ContentFilterEventResponse, len(events))
+	for i := range events {
+		eventResponses[i] = responses.NewContentFilterEventResponse(&events[i])
+	}
+
+	// Create pagination info
+	pagination := &responses.Pagination{
+		Total:      total,
+		Page:       query.Page,
+		PerPage:    query.PerPage,
+		TotalPages: int((total + int64(query.PerPage) - 1) / int64(query.PerPage)),
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponseWithPagination(eventResponses, pagination))
+}
+
+// filterScope returns the board whose lists a request manages, or nil for site-wide lists,
+// which are only routed to site admins. Board lists can only be managed by board admins.
+func (h *ContentFilterHandler) filterScope(c *gin.Context) (*uint, bool) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return nil, false
+	}
+
+	if c.Param("boardId") == "" {
+		return nil, true
+	}
+
+	// Get board ID from URL
+	parsedID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return nil, false
+	}
+	boardID := uint(parsedID)
+
+	// Check if user can manage the board
+	board, err := h.boardService.GetBoardByID(boardID)
+	if err != nil {
+		_ = c.Error(err)
+		return nil, false
+	}
+	if !h.boardService.IsBoardAdmin(board, userID) {
+		_ = c.Error(utils.NewForbiddenError("You don't have permission to manage this board's content filters").
+			WithField("board_id", boardID).
+			WithField("user_id", userID))
+		return nil, false
+	}
+
+	return &boardID, true
+}
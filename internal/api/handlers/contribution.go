@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// ContributionHandler handles contribution tracking and reminder requests
+type ContributionHandler struct {
+	contributionService *services.ContributionService
+	cfg                 *config.Config
+}
+
+// NewContributionHandler creates a new ContributionHandler
+func NewContributionHandler(contributionService *services.ContributionService, cfg *config.Config) *ContributionHandler {
+	return &ContributionHandler{
+		contributionService: contributionService,
+		cfg:                 cfg,
+	}
+}
+
+// ListExpectedContributors lists the people expected to sign a board
+func (h *ContributionHandler) ListExpectedContributors(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Get expected contributors using service
+	statuses, err := h.contributionService.ListExpectedContributors(uint(boardID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(newExpectedContributorResponses(statuses)))
+}
+
+// AddExpectedContributors adds people expected to sign a board
+func (h *ContributionHandler) AddExpectedContributors(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.AddExpectedContributorsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Add expected contributors using service
+	statuses, err := h.contributionService.AddExpectedContributors(uint(boardID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(newExpectedContributorResponses(statuses)))
+}
+
+// RemoveExpectedContributor removes a person from a board's expected contributor list
+func (h *ContributionHandler) RemoveExpectedContributor(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID and expected contributor ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	expectedID, err := strconv.ParseUint(c.Param("expectedId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid expected contributor ID"))
+		return
+	}
+
+	// Remove expected contributor using service
+	err = h.contributionService.RemoveExpectedContributor(uint(boardID), userID, uint(expectedID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Expected contributor removed successfully"}))
+}
+
+// GetCompletion returns a summary of who has and hasn't signed a board
+func (h *ContributionHandler) GetCompletion(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Get completion summary using service
+	summary, pending, err := h.contributionService.GetCompletionSummary(uint(boardID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := responses.CompletionResponse{
+		BoardID:             summary.Board.ID,
+		DeliveryAt:          summary.Board.DeliveryAt,
+		Total:               summary.Total,
+		Completed:           summary.Completed,
+		Pending:             summary.Pending,
+		OptedOut:            summary.OptedOut,
+		RemindersSent:       summary.RemindersSent,
+		PendingContributors: newExpectedContributorResponses(pending),
+	}
+	if summary.Total > 0 {
+		response.CompletionRate = float64(summary.Completed) / float64(summary.Total)
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(response))
+}
+
+// OptOut stops contribution reminders for the person owning the opt-out token
+func (h *ContributionHandler) OptOut(c *gin.Context) {
+	// Parse request
+	var req requests.ReminderOptOutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Opt out using service
+	if err := h.contributionService.OptOut(req.Token, req.AllBoards); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "You will no longer receive reminders"}))
+}
+
+// newExpectedContributorResponses converts expected contributor statuses to responses
+func newExpectedContributorResponses(statuses []services.ExpectedContributorStatus) []responses.ExpectedContributorResponse {
+	expectedResponses := make([]responses.ExpectedContributorResponse, len(statuses))
+	for i, status := range statuses {
+		expectedResponses[i] = responses.NewExpectedContributorResponse(
+			&status.ExpectedContributor,
+			status.User,
+			status.PostCount,
+			status.FirstPostedAt,
+		)
+	}
+	return expectedResponses
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/models"
+	"net/http"
+)
+
+// EffectHandler handles board effect requests
+type EffectHandler struct {
+	cfg *config.Config
+}
+
+// NewEffectHandler creates a new EffectHandler
+func NewEffectHandler(cfg *config.Config) *EffectHandler {
+	return &EffectHandler{
+		cfg: cfg,
+	}
+}
+
+// ListEffects lists the effects boards can show and the parameters each one accepts
+func (h *EffectHandler) ListEffects(c *gin.Context) {
+	c.JSON(http.StatusOK, responses.SuccessResponse(responses.NewEffectCatalogResponse(models.EffectDefinitions)))
+}
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"html/template"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// widgetPostLimit is how many posts the embed widget shows
+const widgetPostLimit = 50
+
+// oEmbedCacheAge is how long oEmbed consumers may cache a board's description, in seconds
+const oEmbedCacheAge = 3600
+
+// EmbedHandler handles embedding boards in other sites
+type EmbedHandler struct {
+	embedService *services.EmbedService
+	postService  *services.PostService
+	themeService *services.ThemeService
+	cfg          *config.Config
+}
+
+// NewEmbedHandler creates a new EmbedHandler
+func NewEmbedHandler(embedService *services.EmbedService, postService *services.PostService, themeService *services.ThemeService, cfg *config.Config) *EmbedHandler {
+	return &EmbedHandler{
+		embedService: embedService,
+		postService:  postService,
+		themeService: themeService,
+		cfg:          cfg,
+	}
+}
+
+// OEmbed describes a board URL as an embeddable iframe, following the oEmbed spec
+func (h *EmbedHandler) OEmbed(c *gin.Context) {
+	// Parse query parameters
+	var query requests.OEmbedQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Only JSON responses are supported
+	if query.Format != "" && query.Format != "json" {
+		c.JSON(http.StatusNotImplemented, responses.ErrorResponse("NOT_IMPLEMENTED", "Only the json format is supported"))
+		return
+	}
+
+	// Describe the board using service
+	embed, err := h.embedService.GetOEmbed(query.URL, query.MaxWidth, query.MaxHeight)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := responses.OEmbedResponse{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        embed.Board.Title,
+		ProviderName: "Kudoboard",
+		ProviderURL:  h.cfg.ClientURL,
+		CacheAge:     oEmbedCacheAge,
+		HTML:         embed.HTML,
+		Width:        embed.Width,
+		Height:       embed.Height,
+	}
+	if embed.Creator != nil {
+		response.AuthorName = embed.Creator.Name
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetEmbedBoard gets the read-only view of a board and a page of its approved posts
+func (h *EmbedHandler) GetEmbedBoard(c *gin.Context) {
+	slug := c.Param("slug")
+
+	// Get current user if authenticated
+	var userID uint
+	user, exists := c.Get("user")
+	if exists && user != nil {
+		userID = user.(*models.User).ID
+	}
+
+	// Parse query parameters
+	var query requests.BoardPostsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Get board using service
+	board, _, err := h.embedService.GetEmbeddableBoard(slug, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	page, err := h.buildEmbedPage(board, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(page))
+}
+
+// RenderWidget renders the read-only widget of a board as a page other sites can frame.
+// The widget is always loaded without credentials, so private boards are never shown.
+func (h *EmbedHandler) RenderWidget(c *gin.Context) {
+	slug := c.Param("slug")
+
+	// Get board using service
+	board, _, err := h.embedService.GetEmbeddableBoard(slug, 0)
+	if err != nil {
+		appError := utils.AsAppError(err)
+		h.renderWidgetPage(c, nil, widgetStatus(appError), widgetPage{Message: appError.Message})
+		return
+	}
+
+	page, err := h.buildEmbedPage(board, requests.BoardPostsQuery{Limit: widgetPostLimit})
+	if err != nil {
+		h.renderWidgetPage(c, nil, http.StatusInternalServerError, widgetPage{Message: "Unable to load board content"})
+		return
+	}
+
+	h.renderWidgetPage(c, board, http.StatusOK, widgetPage{
+		Board:     &page.Board,
+		Posts:     page.Posts,
+		MorePosts: page.Board.PostCount - int64(len(page.Posts)),
+	})
+}
+
+// buildEmbedPage loads the read-only view of a board and a page of its approved posts
+func (h *EmbedHandler) buildEmbedPage(board *models.Board, query requests.BoardPostsQuery) (*responses.EmbedBoardPageResponse, error) {
+	// Embeds show what anonymous visitors see, even to moderators
+	page, err := h.postService.ListBoardPosts(board, 0, query)
+	if err != nil {
+		return nil, err
+	}
+
+	boardResponse := responses.NewEmbedBoardResponse(board, h.embedService.BoardURL(board), h.postService.CountPostsInBoard(board.ID))
+
+	// If board has a theme, include it
+	if board.ThemeID != nil {
+		theme, err := h.themeService.GetThemeByID(*board.ThemeID)
+		if err == nil {
+			themeResponse := responses.NewThemeResponse(theme)
+			boardResponse.Theme = &themeResponse
+		}
+	}
+
+	posts := make([]responses.EmbedPostResponse, len(page.Posts))
+	for i, post := range page.Posts {
+		posts[i] = responses.NewEmbedPostResponse(&post.Post, post.LikesCount, post.Reactions)
+	}
+
+	return &responses.EmbedBoardPageResponse{
+		Board:      boardResponse,
+		Posts:      posts,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}, nil
+}
+
+// widgetPage holds what the widget template shows
+type widgetPage struct {
+	Board     *responses.EmbedBoardResponse
+	Posts     []responses.EmbedPostResponse
+	MorePosts int64
+	Message   string
+}
+
+// renderWidgetPage writes a widget page with the headers that limit who can frame it
+func (h *EmbedHandler) renderWidgetPage(c *gin.Context, board *models.Board, status int, page widgetPage) {
+	c.Header("Content-Security-Policy", "default-src 'none'; img-src * data:; style-src 'unsafe-inline'; "+
+		"base-uri 'none'; form-action 'none'; frame-ancestors "+h.embedService.FrameAncestors(board))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Referrer-Policy", "strict-origin-when-cross-origin")
+
+	var sb strings.Builder
+	if err := widgetTemplate.Execute(&sb, page); err != nil {
+		log.Error("Failed to render embed widget", zap.Error(err))
+		c.Data(http.StatusInternalServerError, "text/plain; charset=utf-8", []byte("Unable to show this board"))
+		return
+	}
+	c.Data(status, "text/html; charset=utf-8", []byte(sb.String()))
+}
+
+// widgetStatus maps an error to the status code of the widget page
+func widgetStatus(appError *utils.AppError) int {
+	switch appError.Code {
+	case "NOT_FOUND":
+		return http.StatusNotFound
+	case "FORBIDDEN":
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// widgetTemplate is the read-only board page shown in iframes
+var widgetTemplate = template.Must(template.New("widget").Funcs(template.FuncMap{
+	"isImage": func(mediaType string) bool {
+		return mediaType == "image" || mediaType == "gif"
+	},
+	// Post HTML is sanitized when the post is saved
+	"postHTML": func(contentHTML string) template.HTML {
+		return template.HTML(contentHTML)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{if .Board}}{{.Board.Title}}{{else}}Kudoboard{{end}}</title>
+<style>
+body { margin: 0; font-family: system-ui, sans-serif; background: #f5f5f5; color: #222; }
+header { padding: 16px 20px; }
+h1 { margin: 0; font-size: 1.4em; }
+.receiver { margin: 4px 0 0; opacity: 0.75; }
+.posts { display: grid; grid-template-columns: repeat(auto-fill, minmax(220px, 1fr)); gap: 12px; padding: 0 20px 20px; }
+.post { border-radius: 8px; padding: 14px; box-shadow: 0 1px 3px rgba(0, 0, 0, 0.15); overflow-wrap: anywhere; }
+.post img { display: block; max-width: 100%; border-radius: 4px; margin-bottom: 8px; }
+.post p { margin: 0 0 8px; white-space: pre-line; }
+.post ul, .post ol { margin: 0 0 8px; padding-left: 20px; }
+.author { font-size: 0.85em; font-weight: 600; }
+footer { padding: 0 20px 20px; }
+a { color: inherit; }
+.message { padding: 40px 20px; text-align: center; }
+</style>
+</head>
+<body>
+{{with .Board}}
+<header{{if .ShowHeaderColor}} style="background-color: {{.HeaderColor}}"{{end}}>
+<h1>{{.Title}}</h1>
+<p class="receiver">For {{.ReceiverName}}</p>
+</header>
+{{end}}
+{{if .Board}}
+<main class="posts">
+{{range .Posts}}
+<article class="post" style="background-color: {{.BackgroundColor}}; color: {{.TextColor}}">
+{{if isImage .MediaType}}<img src="{{.MediaPath}}" alt="" loading="lazy">{{else if .MediaPath}}<p><a href="{{.MediaPath}}" target="_blank" rel="noopener noreferrer">View {{.MediaType}}</a></p>{{end}}
+{{postHTML .ContentHTML}}
+<div class="author">{{.AuthorName}}</div>
+</article>
+{{end}}
+</main>
+<footer>
+<a href="{{.Board.URL}}" target="_blank" rel="noopener noreferrer">{{if gt .MorePosts 0}}See {{.MorePosts}} more on Kudoboard{{else}}View on Kudoboard{{end}}</a>
+</footer>
+{{else}}
+<p class="message">{{.Message}}</p>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// FolderHandler handles board folder requests
+type FolderHandler struct {
+	folderService *services.FolderService
+	cfg           *config.Config
+}
+
+// NewFolderHandler creates a new FolderHandler
+func NewFolderHandler(folderService *services.FolderService, cfg *config.Config) *FolderHandler {
+	return &FolderHandler{
+		folderService: folderService,
+		cfg:           cfg,
+	}
+}
+
+// ListFolders lists the current user's folders
+func (h *FolderHandler) ListFolders(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get folders using service
+	folders, err := h.folderService.ListFolders(userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Build response
+	folderResponses := make([]responses.FolderResponse, len(folders))
+	for i, folder := range folders {
+		folderResponses[i] = responses.NewFolderResponse(&folder.BoardFolder, folder.BoardCount)
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(folderResponses))
+}
+
+// CreateFolder creates a new folder
+func (h *FolderHandler) CreateFolder(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Parse request
+	var req requests.CreateFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Create folder using service
+	folder, err := h.folderService.CreateFolder(userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(responses.NewFolderResponse(folder, 0)))
+}
+
+// UpdateFolder renames, recolors or moves a folder
+func (h *FolderHandler) UpdateFolder(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get folder ID from URL
+	folderID, err := strconv.ParseUint(c.Param("folderId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid folder ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.UpdateFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Update folder using service
+	folder, err := h.folderService.UpdateFolder(uint(folderID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Count boards in folder
+	boardCount := h.folderService.CountBoardsInFolder(folder.ID, userID)
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(responses.NewFolderResponse(folder, boardCount)))
+}
+
+// DeleteFolder deletes a folder
+func (h *FolderHandler) DeleteFolder(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get folder ID from URL
+	folderID, err := strconv.ParseUint(c.Param("folderId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid folder ID"))
+		return
+	}
+
+	// Delete folder using service
+	if err := h.folderService.DeleteFolder(uint(folderID), userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Folder deleted successfully"}))
+}
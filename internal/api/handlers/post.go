@@ -90,6 +90,73 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 	))
 }
 
+// ListBoardPosts returns a page of a board's posts
+func (h *PostHandler) ListBoardPosts(c *gin.Context) {
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.BoardPostsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	// Check if user can open the board
+	board, err := h.boardService.GetViewableBoard(uint(boardID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Get posts using service
+	page, err := h.postService.ListBoardPosts(board, userID, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(newPostPageResponse(page)))
+}
+
+// newPostPageResponse converts a page of posts to a response
+func newPostPageResponse(page *services.PostPage) responses.PostPageResponse {
+	return responses.PostPageResponse{
+		Posts:      newBoardPostResponses(page.Posts),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+}
+
+// loadPostResponse loads the author and likes of a single post for a response
+func loadPostResponse(postService *services.PostService, post *models.Post, viewerID uint) (responses.PostResponse, error) {
+	boardPosts, err := postService.LoadPostDetails([]models.Post{*post}, viewerID)
+	if err != nil {
+		return responses.PostResponse{}, err
+	}
+	return newBoardPostResponses(boardPosts)[0], nil
+}
+
+// newBoardPostResponses converts posts loaded with their details to responses
+func newBoardPostResponses(posts []services.BoardPost) []responses.PostResponse {
+	postResponses := make([]responses.PostResponse, len(posts))
+	for i, post := range posts {
+		postResponses[i] = responses.NewPostResponse(&post.Post, post.Author, post.LikesCount)
+		postResponses[i].LikedByMe = post.LikedByMe
+		postResponses[i].Reactions = responses.NewReactionCountResponses(post.Reactions)
+		postResponses[i].MyReactions = post.MyReactions
+		postResponses[i].CommentsCount = post.CommentsCount
+	}
+	return postResponses
+}
+
 // UpdatePost updates an existing post
 func (h *PostHandler) UpdatePost(c *gin.Context) {
 	// Get user ID from context
@@ -120,19 +187,15 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
-	// Get author if not anonymous
-	var author *models.User
-	if post.AuthorID != nil {
-		author, _ = h.authService.GetUserByID(*post.AuthorID)
+	// Load author and likes
+	postResponse, err := loadPostResponse(h.postService, post, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
-	// Count likes
-	likesCount, _ := h.postService.CountPostLikes(post.ID)
-
 	// Return response
-	c.JSON(http.StatusOK, responses.SuccessResponse(
-		responses.NewPostResponse(post, author, likesCount),
-	))
+	c.JSON(http.StatusOK, responses.SuccessResponse(postResponse))
 }
 
 // DeletePost deletes a post
@@ -219,6 +282,75 @@ func (h *PostHandler) UnlikePost(c *gin.Context) {
 	}))
 }
 
+// ListPostRevisions lists the earlier versions of a post
+func (h *PostHandler) ListPostRevisions(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID"))
+		return
+	}
+
+	revisions, err := h.postService.ListPostRevisions(uint(postID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	revisionResponses := make([]responses.PostRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		revisionResponses[i] = responses.NewPostRevisionResponse(&revision.PostRevision, revision.Editor)
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(revisionResponses))
+}
+
+// RestorePostRevision brings back an earlier version of a post
+func (h *PostHandler) RestorePostRevision(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID"))
+		return
+	}
+
+	// Get revision ID from URL
+	revisionID, err := strconv.ParseUint(c.Param("revisionId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid revision ID"))
+		return
+	}
+
+	post, err := h.postService.RestorePostRevision(uint(postID), uint(revisionID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Load author and likes
+	postResponse, err := loadPostResponse(h.postService, post, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(postResponse))
+}
+
 // ReorderPosts updates the order of posts on a board
 func (h *PostHandler) ReorderPosts(c *gin.Context) {
 	// Get user ID from context
@@ -243,7 +375,7 @@ func (h *PostHandler) ReorderPosts(c *gin.Context) {
 	}
 
 	// Reorder posts using service
-	err = h.postService.ReorderPosts(uint(boardID), userID, req.PostPositions)
+	err = h.postService.ReorderPosts(uint(boardID), userID, req.SectionID, req.PostPositions)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -251,3 +383,219 @@ func (h *PostHandler) ReorderPosts(c *gin.Context) {
 
 	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Posts reordered successfully"}))
 }
+
+// MovePost moves a post next to other posts of its board
+func (h *PostHandler) MovePost(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID format"))
+		return
+	}
+
+	// Parse request
+	var req requests.MovePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Move post using service
+	post, err := h.postService.MovePost(uint(postID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	postResponse, err := loadPostResponse(h.postService, post, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(postResponse))
+}
+
+// UpdatePostLayout moves, resizes or rotates a post on a canvas board
+func (h *PostHandler) UpdatePostLayout(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID format"))
+		return
+	}
+
+	// Parse request
+	var req requests.UpdatePostLayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Update layout using service
+	result, err := h.postService.UpdatePostLayout(uint(postID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(newPostLayoutResultResponse(*result)))
+}
+
+// BatchUpdatePostLayouts applies the layout changes of several posts moved together
+func (h *PostHandler) BatchUpdatePostLayouts(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.BatchUpdatePostLayoutsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Update layouts using service
+	results, err := h.postService.BatchUpdatePostLayouts(uint(boardID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	layouts := make([]responses.PostLayoutResultResponse, len(results))
+	for i, result := range results {
+		layouts[i] = newPostLayoutResultResponse(result)
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"layouts": layouts}))
+}
+
+// newPostLayoutResultResponse converts the outcome of a layout change to its response
+func newPostLayoutResultResponse(result services.PostLayoutResult) responses.PostLayoutResultResponse {
+	return responses.PostLayoutResultResponse{
+		PostID:  result.PostID,
+		Applied: result.Applied,
+		Layout:  responses.NewPostLayoutResponse(result.Layout),
+	}
+}
+
+// ListModerationQueue lists a board's posts awaiting moderation
+func (h *PostHandler) ListModerationQueue(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.ModerationQueueQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	status := models.PostStatusPending
+	if query.Status != "" {
+		status = models.PostStatus(query.Status)
+	}
+
+	// Get queue using service
+	posts, err := h.postService.ListModerationQueue(uint(boardID), userID, status)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Load authors and likes of all posts at once
+	boardPosts, err := h.postService.LoadPostDetails(posts, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(newBoardPostResponses(boardPosts)))
+}
+
+// ApprovePost approves a pending post
+func (h *PostHandler) ApprovePost(c *gin.Context) {
+	h.moderatePost(c, models.PostStatusApproved)
+}
+
+// RejectPost rejects a pending post
+func (h *PostHandler) RejectPost(c *gin.Context) {
+	h.moderatePost(c, models.PostStatusRejected)
+}
+
+// moderatePost sets a post's moderation status with an optional reason
+func (h *PostHandler) moderatePost(c *gin.Context, status models.PostStatus) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID"))
+		return
+	}
+
+	// Parse optional request body
+	var req requests.ModeratePostRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			_ = c.Error(utils.NewValidationError(err.Error()))
+			return
+		}
+	}
+
+	// Moderate post using service
+	post, err := h.postService.ModeratePost(uint(postID), userID, status, req.Reason)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Load author and likes
+	postResponse, err := loadPostResponse(h.postService, post, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(postResponse))
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"net/http"
+)
+
+// PreviewHandler handles social preview requests for boards
+type PreviewHandler struct {
+	previewService *services.PreviewService
+	cfg            *config.Config
+}
+
+// NewPreviewHandler creates a new PreviewHandler
+func NewPreviewHandler(previewService *services.PreviewService, cfg *config.Config) *PreviewHandler {
+	return &PreviewHandler{
+		previewService: previewService,
+		cfg:            cfg,
+	}
+}
+
+// GetBoardOpenGraph gets the social metadata of a board for server-side rendering of its page
+func (h *PreviewHandler) GetBoardOpenGraph(c *gin.Context) {
+	// Get metadata using service
+	openGraph, err := h.previewService.GetOpenGraph(c.Param("slug"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Build response; boards without an image get the smaller card
+	response := responses.OpenGraphResponse{
+		Title:       openGraph.Title,
+		Description: openGraph.Description,
+		URL:         openGraph.URL,
+		SiteName:    openGraph.SiteName,
+		Type:        "website",
+		TwitterCard: "summary",
+	}
+	if openGraph.ImageURL != "" {
+		response.TwitterCard = "summary_large_image"
+		response.Image = &responses.OpenGraphImageField{
+			URL:    openGraph.ImageURL,
+			Type:   "image/png",
+			Width:  services.PreviewImageWidth,
+			Height: services.PreviewImageHeight,
+			Alt:    openGraph.ImageAlt,
+		}
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(response))
+}
+
+// GetBoardPreviewImage gets the PNG preview card of a public board
+func (h *PreviewHandler) GetBoardPreviewImage(c *gin.Context) {
+	// Get image using service
+	image, err := h.previewService.GetPreviewImage(c.Param("slug"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer image.Close()
+
+	// The image URL in the metadata changes with the board, so caches can keep it for a while
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.DataFromReader(http.StatusOK, -1, "image/png", image, nil)
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// ReactionHandler handles emoji reactions to posts
+type ReactionHandler struct {
+	postService *services.PostService
+	cfg         *config.Config
+}
+
+// NewReactionHandler creates a new ReactionHandler
+func NewReactionHandler(postService *services.PostService, cfg *config.Config) *ReactionHandler {
+	return &ReactionHandler{
+		postService: postService,
+		cfg:         cfg,
+	}
+}
+
+// ListEmojis lists the emoji posts can be reacted with
+func (h *ReactionHandler) ListEmojis(c *gin.Context) {
+	emojis, err := h.postService.ListReactionEmojis()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	emojiResponses := make([]responses.ReactionEmojiResponse, len(emojis))
+	for i, emoji := range emojis {
+		emojiResponses[i] = responses.ReactionEmojiResponse{
+			ID:       emoji.ID,
+			Name:     emoji.Name,
+			Emoji:    emoji.Emoji,
+			ImageURL: emoji.ImageURL,
+			Custom:   emoji.ImageURL != "",
+		}
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(emojiResponses))
+}
+
+// AddReaction reacts to a post with an emoji
+func (h *ReactionHandler) AddReaction(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID format"))
+		return
+	}
+
+	// Parse request
+	var req requests.AddReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	reactions, err := h.postService.AddReaction(uint(postID), userID, req.Emoji)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(newPostReactionsResponse(reactions)))
+}
+
+// RemoveReaction removes the user's reaction with an emoji from a post
+func (h *ReactionHandler) RemoveReaction(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID format"))
+		return
+	}
+
+	reactions, err := h.postService.RemoveReaction(uint(postID), userID, c.Param("emoji"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(newPostReactionsResponse(reactions)))
+}
+
+// ListReactions lists the users who reacted to a post
+func (h *ReactionHandler) ListReactions(c *gin.Context) {
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID format"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.ReactionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Set defaults if not provided
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PerPage < 1 {
+		query.PerPage = 20
+	}
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	reactors, total, err := h.postService.ListReactors(uint(postID), userID, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	reactorResponses := make([]responses.ReactorResponse, len(reactors))
+	for i, reactor := range reactors {
+		reactorResponses[i] = responses.ReactorResponse{
+			Emoji:     reactor.Emoji,
+			CreatedAt: reactor.CreatedAt,
+		}
+		if reactor.User != nil {
+			user := responses.NewUserResponse(reactor.User)
+			reactorResponses[i].User = &user
+		}
+	}
+
+	pagination := &responses.Pagination{
+		Total:      total,
+		Page:       query.Page,
+		PerPage:    query.PerPage,
+		TotalPages: int((total + int64(query.PerPage) - 1) / int64(query.PerPage)),
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponseWithPagination(reactorResponses, pagination))
+}
+
+// CreateCustomEmoji adds a custom emoji posts can be reacted with
+func (h *ReactionHandler) CreateCustomEmoji(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Parse request
+	var req requests.CreateCustomEmojiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	emoji, err := h.postService.CreateCustomEmoji(userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(responses.NewCustomEmojiResponse(emoji)))
+}
+
+// DeleteCustomEmoji removes a custom emoji and the reactions made with it
+func (h *ReactionHandler) DeleteCustomEmoji(c *gin.Context) {
+	// Get emoji ID from URL
+	emojiID, err := strconv.ParseUint(c.Param("emojiId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid emoji ID"))
+		return
+	}
+
+	if err := h.postService.DeleteCustomEmoji(uint(emojiID)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Emoji deleted successfully"}))
+}
+
+// newPostReactionsResponse converts the reactions to a post to a response
+func newPostReactionsResponse(reactions *services.PostReactions) responses.PostReactionsResponse {
+	return responses.PostReactionsResponse{
+		PostID:      reactions.PostID,
+		Reactions:   responses.NewReactionCountResponses(reactions.Counts),
+		MyReactions: reactions.MyReactions,
+		LikesCount:  reactions.Total,
+	}
+}
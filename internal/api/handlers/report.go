@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// ReportHandler handles abuse report requests
+type ReportHandler struct {
+	reportService *services.ReportService
+	cfg           *config.Config
+}
+
+// NewReportHandler creates a new ReportHandler
+func NewReportHandler(reportService *services.ReportService, cfg *config.Config) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+		cfg:           cfg,
+	}
+}
+
+// ReportPost reports a post for abuse
+func (h *ReportHandler) ReportPost(c *gin.Context) {
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.CreateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	// Report post using service
+	if err := h.reportService.ReportPost(uint(postID), userID, c.ClientIP(), req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(gin.H{"message": "Thank you, the post has been reported"}))
+}
+
+// ReportBoard reports a board for abuse
+func (h *ReportHandler) ReportBoard(c *gin.Context) {
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.CreateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	// Report board using service
+	if err := h.reportService.ReportBoard(uint(boardID), userID, c.ClientIP(), req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(gin.H{"message": "Thank you, the board has been reported"}))
+}
+
+// ListReports lists abuse reports for site admins
+func (h *ReportHandler) ListReports(c *gin.Context) {
+	// Parse query parameters
+	var query requests.ReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Set defaults if not provided
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PerPage < 1 {
+		query.PerPage = 20
+	}
+
+	// Get reports using service
+	reports, total, err := h.reportService.ListReports(query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Build response
+	reportResponses := make([]responses.ReportResponse, len(reports))
+	for i, report := range reports {
+		reportResponses[i] = responses.NewReportResponse(&report.Report, report.Board, report.Post, report.OpenReportCount)
+	}
+
+	// Create pagination info
+	pagination := &responses.Pagination{
+		Total:      total,
+		Page:       query.Page,
+		PerPage:    query.PerPage,
+		TotalPages: int((total + int64(query.PerPage) - 1) / int64(query.PerPage)),
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponseWithPagination(reportResponses, pagination))
+}
+
+// ResolveReport applies a site admin's decision to a report
+func (h *ReportHandler) ResolveReport(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get report ID from URL
+	reportID, err := strconv.ParseUint(c.Param("reportId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid report ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Resolve report using service
+	report, err := h.reportService.ResolveReport(uint(reportID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(
+		responses.NewReportResponse(&report.Report, report.Board, report.Post, report.OpenReportCount),
+	))
+}
+
+// LiftSuspension lets a suspended user sign in again
+func (h *ReportHandler) LiftSuspension(c *gin.Context) {
+	// Get user ID from URL
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid user ID"))
+		return
+	}
+
+	// Lift suspension using service
+	if err := h.reportService.LiftSuspension(uint(userID)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Suspension lifted successfully"}))
+}
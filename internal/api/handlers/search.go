@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// SearchHandler handles full-text search requests
+type SearchHandler struct {
+	searchService *services.SearchService
+	cfg           *config.Config
+}
+
+// NewSearchHandler creates a new SearchHandler
+func NewSearchHandler(searchService *services.SearchService, cfg *config.Config) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		cfg:           cfg,
+	}
+}
+
+// SearchBoards searches the boards the current user created or contributes to
+func (h *SearchHandler) SearchBoards(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+	setSearchDefaults(&query)
+
+	// Search boards using service
+	results, total, err := h.searchService.SearchBoards(userID, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Build response
+	resultResponses := make([]responses.BoardSearchResultResponse, len(results))
+	for i, result := range results {
+		resultResponses[i] = responses.BoardSearchResultResponse{
+			ID:                    result.ID,
+			Title:                 result.Title,
+			Slug:                  result.Slug,
+			ReceiverName:          result.ReceiverName,
+			IsPrivate:             result.IsPrivate,
+			CreatorID:             result.CreatorID,
+			CreatedAt:             result.CreatedAt,
+			Rank:                  result.Rank,
+			TitleHighlight:        result.TitleHighlight,
+			ReceiverNameHighlight: result.ReceiverNameHighlight,
+		}
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponseWithPagination(resultResponses, searchPagination(query, total)))
+}
+
+// SearchPosts searches posts on the boards the current user created or contributes to
+func (h *SearchHandler) SearchPosts(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.PostSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+	setSearchDefaults(&query.SearchQuery)
+
+	// Search posts using service
+	results, total, err := h.searchService.SearchPosts(userID, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponseWithPagination(newPostSearchResultResponses(results), searchPagination(query.SearchQuery, total)))
+}
+
+// SearchBoardPosts searches the posts of one board
+func (h *SearchHandler) SearchBoardPosts(c *gin.Context) {
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.PostSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+	setSearchDefaults(&query.SearchQuery)
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	// Search posts using service
+	results, total, err := h.searchService.SearchBoardPosts(uint(boardID), userID, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponseWithPagination(newPostSearchResultResponses(results), searchPagination(query.SearchQuery, total)))
+}
+
+// setSearchDefaults sets the default page and page size of a search
+func setSearchDefaults(query *requests.SearchQuery) {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PerPage < 1 {
+		query.PerPage = 20
+	}
+}
+
+// searchPagination creates pagination info for a page of search results
+func searchPagination(query requests.SearchQuery, total int64) *responses.Pagination {
+	return &responses.Pagination{
+		Total:      total,
+		Page:       query.Page,
+		PerPage:    query.PerPage,
+		TotalPages: int((total + int64(query.PerPage) - 1) / int64(query.PerPage)),
+	}
+}
+
+// newPostSearchResultResponses converts post search results to responses
+func newPostSearchResultResponses(results []services.PostSearchResult) []responses.PostSearchResultResponse {
+	resultResponses := make([]responses.PostSearchResultResponse, len(results))
+	for i, result := range results {
+		postResponse := responses.NewPostResponse(&result.Post, result.Author, result.LikesCount)
+		postResponse.LikedByMe = result.LikedByMe
+		postResponse.Reactions = responses.NewReactionCountResponses(result.Reactions)
+		postResponse.MyReactions = result.MyReactions
+		postResponse.CommentsCount = result.CommentsCount
+		resultResponses[i] = responses.PostSearchResultResponse{
+			PostResponse: postResponse,
+			Board: responses.SearchResultBoardResponse{
+				ID:    result.BoardID,
+				Title: result.BoardTitle,
+				Slug:  result.BoardSlug,
+			},
+			Rank:    result.Rank,
+			Snippet: result.Snippet,
+		}
+	}
+	return resultResponses
+}
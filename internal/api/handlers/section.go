@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// SectionHandler handles the sections posts are grouped in on a board
+type SectionHandler struct {
+	sectionService *services.SectionService
+	postService    *services.PostService
+	cfg            *config.Config
+}
+
+// NewSectionHandler creates a new SectionHandler
+func NewSectionHandler(sectionService *services.SectionService, postService *services.PostService, cfg *config.Config) *SectionHandler {
+	return &SectionHandler{
+		sectionService: sectionService,
+		postService:    postService,
+		cfg:            cfg,
+	}
+}
+
+// ListSections lists the sections of a board
+func (h *SectionHandler) ListSections(c *gin.Context) {
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	// Get sections using service
+	sections, err := h.sectionService.ListSections(uint(boardID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(newSectionResponses(sections)))
+}
+
+// CreateSection adds a section to a board
+func (h *SectionHandler) CreateSection(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.CreateSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Create section using service
+	section, err := h.sectionService.CreateSection(uint(boardID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(responses.NewSectionResponse(section, 0)))
+}
+
+// UpdateSection renames or recolors a section
+func (h *SectionHandler) UpdateSection(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board and section IDs from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+	sectionID, err := strconv.ParseUint(c.Param("sectionId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid section ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.UpdateSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Update section using service
+	section, err := h.sectionService.UpdateSection(uint(boardID), uint(sectionID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(
+		responses.NewSectionResponse(section, h.sectionService.CountSectionPosts(section)),
+	))
+}
+
+// DeleteSection deletes a section, moving its posts to the default section
+func (h *SectionHandler) DeleteSection(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board and section IDs from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+	sectionID, err := strconv.ParseUint(c.Param("sectionId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid section ID"))
+		return
+	}
+
+	// Delete section using service
+	if err := h.sectionService.DeleteSection(uint(boardID), uint(sectionID), userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Section deleted successfully"}))
+}
+
+// ReorderSections updates the order of the sections of a board
+func (h *SectionHandler) ReorderSections(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.ReorderSectionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Reorder sections using service
+	if err := h.sectionService.ReorderSections(uint(boardID), userID, req.SectionPositions); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Sections reordered successfully"}))
+}
+
+// MovePost moves a post to another section of its board
+func (h *SectionHandler) MovePost(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.MovePostToSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Move post using service
+	post, err := h.sectionService.MovePost(uint(postID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	postResponse, err := loadPostResponse(h.postService, post, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(postResponse))
+}
+
+// newSectionResponses converts sections with their post counts to responses
+func newSectionResponses(sections []services.SectionWithCount) []responses.SectionResponse {
+	sectionResponses := make([]responses.SectionResponse, len(sections))
+	for i, section := range sections {
+		sectionResponses[i] = responses.NewSectionResponse(&section.BoardSection, section.PostCount)
+	}
+	return sectionResponses
+}
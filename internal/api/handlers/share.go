@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// ShareHandler handles QR codes and printable cards for sharing boards
+type ShareHandler struct {
+	shareService *services.ShareService
+	cfg          *config.Config
+}
+
+// NewShareHandler creates a new ShareHandler
+func NewShareHandler(shareService *services.ShareService, cfg *config.Config) *ShareHandler {
+	return &ShareHandler{
+		shareService: shareService,
+		cfg:          cfg,
+	}
+}
+
+// GetBoardQR gets a QR code linking to a board as a PNG or SVG image
+func (h *ShareHandler) GetBoardQR(c *gin.Context) {
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.BoardQRQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	// Generate QR code using service
+	file, err := h.shareService.GetBoardQR(uint(boardID), userID, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	writeShareFile(c, file)
+}
+
+// GetShareCard gets a printable PDF card with a board's title and QR code
+func (h *ShareHandler) GetShareCard(c *gin.Context) {
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Parse query parameters
+	var query requests.ShareCardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Get user ID from context (0 if anonymous)
+	userID := c.GetUint("userID")
+
+	// Generate card using service
+	file, err := h.shareService.GetShareCard(uint(boardID), userID, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	writeShareFile(c, file)
+}
+
+// writeShareFile sends a generated file to be shown inline, with a name for saving it
+func writeShareFile(c *gin.Context, file *services.ShareFile) {
+	// Private boards can be shared too, so only the viewer's browser may cache the file
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": file.Filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
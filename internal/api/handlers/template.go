@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// TemplateHandler handles board template requests
+type TemplateHandler struct {
+	templateService *services.TemplateService
+	postService     *services.PostService
+	cfg             *config.Config
+}
+
+// NewTemplateHandler creates a new TemplateHandler
+func NewTemplateHandler(templateService *services.TemplateService, postService *services.PostService, cfg *config.Config) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+		postService:     postService,
+		cfg:             cfg,
+	}
+}
+
+// ListTemplates lists the templates available to the current user
+func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get templates using service
+	templates, err := h.templateService.ListTemplates(userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Build response
+	templateResponses := make([]responses.TemplateResponse, len(templates))
+	for i, template := range templates {
+		templateResponses[i] = responses.NewTemplateResponse(&template)
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(templateResponses))
+}
+
+// GetTemplate gets a template by ID
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get template ID from URL
+	templateID, err := strconv.ParseUint(c.Param("templateId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid template ID"))
+		return
+	}
+
+	// Get template using service
+	template, err := h.templateService.GetTemplate(uint(templateID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(responses.NewTemplateResponse(template)))
+}
+
+// CreateTemplate saves a board's settings as a template
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Parse request
+	var req requests.CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Create template using service
+	template, err := h.templateService.CreateTemplate(userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(responses.NewTemplateResponse(template)))
+}
+
+// DeleteTemplate deletes a template
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get template ID from URL
+	templateID, err := strconv.ParseUint(c.Param("templateId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid template ID"))
+		return
+	}
+
+	// Delete template using service
+	err = h.templateService.DeleteTemplate(uint(templateID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Template deleted successfully"}))
+}
+
+// CreateBoardFromTemplate creates a new board from a template
+func (h *TemplateHandler) CreateBoardFromTemplate(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get template ID from URL
+	templateID, err := strconv.ParseUint(c.Param("templateId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid template ID"))
+		return
+	}
+
+	// Parse request
+	var req requests.CreateBoardFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(utils.NewValidationError(err.Error()))
+		return
+	}
+
+	// Create board using service
+	board, err := h.templateService.CreateBoardFromTemplate(uint(templateID), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Get user for response
+	user, _ := c.Get("user")
+
+	// Count seeded posts
+	postCount := h.postService.CountPostsInBoard(board.ID)
+
+	c.JSON(http.StatusCreated, responses.SuccessResponse(
+		responses.NewBoardResponse(board, user.(*models.User), postCount),
+	))
+}
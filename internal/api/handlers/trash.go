@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/responses"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/services"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"strconv"
+)
+
+// TrashHandler handles requests for deleted boards and posts
+type TrashHandler struct {
+	trashService *services.TrashService
+	postService  *services.PostService
+	authService  *services.AuthService
+	cfg          *config.Config
+}
+
+// NewTrashHandler creates a new TrashHandler
+func NewTrashHandler(trashService *services.TrashService, postService *services.PostService, authService *services.AuthService, cfg *config.Config) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+		postService:  postService,
+		authService:  authService,
+		cfg:          cfg,
+	}
+}
+
+// ListTrash lists the deleted boards and posts the current user can restore
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get trash using service
+	boards, posts, err := h.trashService.ListTrash(userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Build response
+	response := responses.TrashResponse{
+		Boards: make([]responses.TrashedBoardResponse, len(boards)),
+		Posts:  make([]responses.TrashedPostResponse, len(posts)),
+	}
+	for i, board := range boards {
+		response.Boards[i] = responses.NewTrashedBoardResponse(&board.Board, board.PostCount,
+			h.trashService.PurgeAt(board.DeletedAt.Time))
+	}
+	for i, post := range posts {
+		response.Posts[i] = responses.NewTrashedPostResponse(&post.Post, post.BoardTitle,
+			h.trashService.PurgeAt(post.DeletedAt.Time))
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(response))
+}
+
+// RestoreBoard restores a deleted board
+func (h *TrashHandler) RestoreBoard(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Restore board using service
+	board, err := h.trashService.RestoreBoard(uint(boardID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Get user for response
+	user, _ := c.Get("user")
+
+	// Count restored posts
+	postCount := h.postService.CountPostsInBoard(board.ID)
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(
+		responses.NewBoardResponse(board, user.(*models.User), postCount),
+	))
+}
+
+// DeleteBoardPermanently permanently deletes a board from the trash
+func (h *TrashHandler) DeleteBoardPermanently(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get board ID from URL
+	boardID, err := strconv.ParseUint(c.Param("boardId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid board ID"))
+		return
+	}
+
+	// Delete board using service
+	if err := h.trashService.DeleteBoardPermanently(uint(boardID), userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Board permanently deleted"}))
+}
+
+// RestorePost restores a deleted post
+func (h *TrashHandler) RestorePost(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID"))
+		return
+	}
+
+	// Restore post using service
+	post, err := h.trashService.RestorePost(uint(postID), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Load author and likes
+	postResponse, err := loadPostResponse(h.postService, post, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(postResponse))
+}
+
+// DeletePostPermanently permanently deletes a post from the trash
+func (h *TrashHandler) DeletePostPermanently(c *gin.Context) {
+	// Get user ID from context
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		_ = c.Error(utils.NewUnauthorizedError("User not authenticated"))
+		return
+	}
+
+	// Get post ID from URL
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		_ = c.Error(utils.NewBadRequestError("Invalid post ID"))
+		return
+	}
+
+	// Delete post using service
+	if err := h.trashService.DeletePostPermanently(uint(postID), userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SuccessResponse(gin.H{"message": "Post permanently deleted"}))
+}
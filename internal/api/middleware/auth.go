@@ -82,6 +82,19 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Suspended users keep their tokens but can't use them
+		if user.SuspendedAt != nil {
+			log.Info("Authentication failed: user suspended",
+				zap.Uint("user_id", userID),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("request_id", requestIDStr),
+			)
+
+			c.JSON(http.StatusForbidden, responses.ErrorResponse("ACCOUNT_SUSPENDED", "This account has been suspended"))
+			c.Abort()
+			return
+		}
+
 		// Set the user and userID in the context
 		c.Set("user", user)
 		c.Set("userID", userID)
@@ -147,6 +160,12 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Suspended users are treated as anonymous
+		if user.SuspendedAt != nil {
+			c.Next()
+			return
+		}
+
 		// Set the user and userID in the context
 		c.Set("user", user)
 		c.Set("userID", userID)
@@ -123,6 +123,14 @@ func (m *ErrorMiddleware) processError(err error, c *gin.Context) (int, response
 		appError.Message,
 	)
 
+	// Tell the client which request fields are invalid
+	for _, fieldError := range appError.FieldErrors {
+		response.Error.Fields = append(response.Error.Fields, responses.FieldErrorResponse{
+			Field:   fieldError.Field,
+			Message: fieldError.Message,
+		})
+	}
+
 	// Add details if in debug mode
 	if m.Debug {
 		details := m.buildErrorDetails(appError)
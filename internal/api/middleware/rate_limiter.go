@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -70,6 +71,26 @@ func (r *RateLimiterMiddleware) getClientLimiter(key string, isAuth bool) *rate.
 	return client.limiter
 }
 
+// getReportLimiter gets or creates the abuse report limiter for a client.
+// Reports are limited separately from other requests, at a much lower rate.
+func (r *RateLimiterMiddleware) getReportLimiter(clientIP string) *rate.Limiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := "report:" + clientIP
+	client, exists := r.clients[key]
+	if !exists {
+		limiter := rate.NewLimiter(rate.Limit(float64(r.cfg.ReportRateLimitPerHour)/3600), r.cfg.ReportRateLimitBurst)
+		client = &Client{limiter: limiter, lastSeen: time.Now()}
+		r.clients[key] = client
+		return limiter
+	}
+
+	// Update last seen time
+	client.lastSeen = time.Now()
+	return client.limiter
+}
+
 // cleanupClients removes clients that haven't been seen for a while
 func (r *RateLimiterMiddleware) cleanupClients() {
 	for {
@@ -110,6 +131,9 @@ func (r *RateLimiterMiddleware) RateLimit() gin.HandlerFunc {
 
 		// Get the appropriate limiter
 		limiter := r.getClientLimiter(clientIP, isAuth)
+		if c.Request.Method == http.MethodPost && strings.HasSuffix(c.Request.URL.Path, "/report") {
+			limiter = r.getReportLimiter(clientIP)
+		}
 
 		// Check if allowed
 		if !limiter.Allow() {
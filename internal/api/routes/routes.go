@@ -27,13 +27,28 @@ func Setup(
 
 	// Create handler instances with services from container
 	authHandler := handlers.NewAuthHandler(container.AuthService, cfg)
-	boardHandler := handlers.NewBoardHandler(container.BoardService, container.PostService, container.ThemeService, container.AuthService, cfg)
+	boardHandler := handlers.NewBoardHandler(container.BoardService, container.PostService, container.ThemeService, container.AuthService, container.AnalyticsService, container.SectionService, cfg)
 	postHandler := handlers.NewPostHandler(container.PostService, container.BoardService, container.AuthService, cfg)
 	themeHandler := handlers.NewThemeHandler(container.ThemeService, cfg)
 	fileHandler := handlers.NewFileHandler(container.FileService, container.StorageCleanupService, cfg)
 	giphyHandler := handlers.NewGiphyHandler(container.GiphyService, cfg)
 	unsplashHandler := handlers.NewUnsplashHandler(container.UnsplashService, cfg)
 	healthHandler := handlers.NewHealthHandler(container.DB, cfg)
+	contributionHandler := handlers.NewContributionHandler(container.ContributionService, cfg)
+	templateHandler := handlers.NewTemplateHandler(container.TemplateService, container.PostService, cfg)
+	folderHandler := handlers.NewFolderHandler(container.FolderService, cfg)
+	trashHandler := handlers.NewTrashHandler(container.TrashService, container.PostService, container.AuthService, cfg)
+	contentFilterHandler := handlers.NewContentFilterHandler(container.ContentFilterService, container.BoardService, cfg)
+	reportHandler := handlers.NewReportHandler(container.ReportService, cfg)
+	analyticsHandler := handlers.NewAnalyticsHandler(container.AnalyticsService, cfg)
+	searchHandler := handlers.NewSearchHandler(container.SearchService, cfg)
+	effectHandler := handlers.NewEffectHandler(cfg)
+	embedHandler := handlers.NewEmbedHandler(container.EmbedService, container.PostService, container.ThemeService, cfg)
+	previewHandler := handlers.NewPreviewHandler(container.PreviewService, cfg)
+	shareHandler := handlers.NewShareHandler(container.ShareService, cfg)
+	sectionHandler := handlers.NewSectionHandler(container.SectionService, container.PostService, cfg)
+	reactionHandler := handlers.NewReactionHandler(container.PostService, cfg)
+	commentHandler := handlers.NewCommentHandler(container.PostService, cfg)
 
 	authMiddleware := middleware.NewAuthMiddleware(container.AuthService, cfg)
 
@@ -65,6 +80,7 @@ func Setup(
 		auth.POST("/facebook", authHandler.FacebookLogin)
 		auth.POST("/forgot-password", authHandler.ForgotPassword)
 		auth.POST("/reset-password", authHandler.ResetPassword)
+		auth.POST("/verify-email", authHandler.VerifyEmail)
 
 		// Auth routes requiring authentication
 		authProtected := auth.Group("")
@@ -72,6 +88,7 @@ func Setup(
 		{
 			authProtected.GET("/me", authHandler.GetMe)
 			authProtected.PUT("/me", authHandler.UpdateProfile)
+			authProtected.POST("/verify-email/resend", authHandler.ResendVerification)
 		}
 	}
 
@@ -80,6 +97,8 @@ func Setup(
 	{
 		// Public board endpoints
 		boards.GET("/slug/:slug", authMiddleware.OptionalAuth(), boardHandler.GetBoardBySlug)
+		boards.GET("/slug/:slug/og", previewHandler.GetBoardOpenGraph)
+		boards.GET("/slug/:slug/preview.png", previewHandler.GetBoardPreviewImage)
 
 		// Board endpoints requiring authentication
 		boardsAuth := boards.Group("")
@@ -91,38 +110,173 @@ func Setup(
 			boardsAuth.PUT("/:boardId", boardHandler.UpdateBoard)
 			boardsAuth.DELETE("/:boardId", boardHandler.DeleteBoard)
 			boardsAuth.PATCH("/:boardId/lock", boardHandler.ToggleBoardLock)
+			boardsAuth.POST("/:boardId/duplicate", boardHandler.DuplicateBoard)
+			boardsAuth.GET("/:boardId/history", boardHandler.GetBoardHistory)
+			boardsAuth.POST("/:boardId/history/:version/revert", boardHandler.RevertBoard)
 
 			// Board preferences
 			boardsAuth.PATCH("/:boardId/preferences", boardHandler.UpdateBoardPreferences)
+			boardsAuth.PATCH("/preferences/bulk", boardHandler.BulkUpdateBoardPreferences)
 
 			// Board contributors
 			boardsAuth.GET("/:boardId/contributors", boardHandler.ListBoardContributors)
 			boardsAuth.POST("/:boardId/contributors", boardHandler.AddContributor)
+			boardsAuth.POST("/:boardId/contributors/bulk", boardHandler.BulkAddContributors)
+			boardsAuth.PATCH("/:boardId/contributors/bulk", boardHandler.BulkUpdateContributors)
+
+			// Contribution tracking
+			boardsAuth.GET("/:boardId/expected-contributors", contributionHandler.ListExpectedContributors)
+			boardsAuth.POST("/:boardId/expected-contributors", contributionHandler.AddExpectedContributors)
+			boardsAuth.DELETE("/:boardId/expected-contributors/:expectedId", contributionHandler.RemoveExpectedContributor)
+			boardsAuth.GET("/:boardId/completion", contributionHandler.GetCompletion)
 			boardsAuth.PUT("/:boardId/contributors/:contributorId", boardHandler.UpdateContributor)
 			boardsAuth.DELETE("/:boardId/contributors/:contributorId", boardHandler.RemoveContributor)
 
+			// Analytics
+			boardsAuth.GET("/:boardId/analytics", analyticsHandler.GetBoardAnalytics)
+			boardsAuth.GET("/:boardId/analytics/viewers", analyticsHandler.GetBoardViewers)
+
 			// Posts within a board
 			boardsAuth.PUT("/:boardId/posts/reorder", postHandler.ReorderPosts)
+			boardsAuth.PATCH("/:boardId/posts/layout", postHandler.BatchUpdatePostLayouts)
+
+			// Sections
+			boardsAuth.POST("/:boardId/sections", sectionHandler.CreateSection)
+			boardsAuth.PUT("/:boardId/sections/reorder", sectionHandler.ReorderSections)
+			boardsAuth.PUT("/:boardId/sections/:sectionId", sectionHandler.UpdateSection)
+			boardsAuth.DELETE("/:boardId/sections/:sectionId", sectionHandler.DeleteSection)
+			boardsAuth.GET("/:boardId/moderation", postHandler.ListModerationQueue)
+
+			// Board content filters
+			boardsAuth.GET("/:boardId/content-filters", contentFilterHandler.ListFilterLists)
+			boardsAuth.POST("/:boardId/content-filters", contentFilterHandler.CreateFilterList)
+			boardsAuth.GET("/:boardId/content-filters/events", contentFilterHandler.ListFilterEvents)
+			boardsAuth.PUT("/:boardId/content-filters/:listId", contentFilterHandler.UpdateFilterList)
+			boardsAuth.DELETE("/:boardId/content-filters/:listId", contentFilterHandler.DeleteFilterList)
 		}
 
 		// Posts within a board
 		boards.POST("/:boardId/posts", authMiddleware.OptionalAuth(), postHandler.CreatePost)
+		boards.GET("/:boardId/posts", authMiddleware.OptionalAuth(), postHandler.ListBoardPosts)
+		boards.GET("/:boardId/search", authMiddleware.OptionalAuth(), searchHandler.SearchBoardPosts)
+		boards.GET("/:boardId/sections", authMiddleware.OptionalAuth(), sectionHandler.ListSections)
+
+		// Sharing (anonymous allowed for boards they can open)
+		boards.GET("/:boardId/qr", authMiddleware.OptionalAuth(), shareHandler.GetBoardQR)
+		boards.GET("/:boardId/share-card", authMiddleware.OptionalAuth(), shareHandler.GetShareCard)
+
+		// Abuse reports (anonymous allowed)
+		boards.POST("/:boardId/report", authMiddleware.OptionalAuth(), reportHandler.ReportBoard)
 	}
 
 	// Post operations
 	posts := v1.Group("/posts")
 	{
+		// Abuse reports (anonymous allowed)
+		posts.POST("/:postId/report", authMiddleware.OptionalAuth(), reportHandler.ReportPost)
+
+		// Reactions (anonymous allowed to see them on boards they can open)
+		posts.GET("/:postId/reactions", authMiddleware.OptionalAuth(), reactionHandler.ListReactions)
+
+		// Comments (anonymous allowed on boards that allow anonymous posts)
+		posts.GET("/:postId/comments", authMiddleware.OptionalAuth(), commentHandler.ListComments)
+		posts.POST("/:postId/comments", authMiddleware.OptionalAuth(), commentHandler.CreateComment)
+
 		// Posts require authentication
 		postsAuth := posts.Group("")
 		postsAuth.Use(authMiddleware.RequireAuth())
 		{
 			postsAuth.PUT("/:postId", postHandler.UpdatePost)
 			postsAuth.DELETE("/:postId", postHandler.DeletePost)
+			postsAuth.GET("/:postId/revisions", postHandler.ListPostRevisions)
+			postsAuth.POST("/:postId/revisions/:revisionId/restore", postHandler.RestorePostRevision)
 			postsAuth.POST("/:postId/like", postHandler.LikePost)
 			postsAuth.DELETE("/:postId/like", postHandler.UnlikePost)
+			postsAuth.POST("/:postId/reactions", reactionHandler.AddReaction)
+			postsAuth.DELETE("/:postId/reactions/:emoji", reactionHandler.RemoveReaction)
+			postsAuth.POST("/:postId/approve", postHandler.ApprovePost)
+			postsAuth.POST("/:postId/reject", postHandler.RejectPost)
+			postsAuth.PUT("/:postId/section", sectionHandler.MovePost)
+			postsAuth.PATCH("/:postId/layout", postHandler.UpdatePostLayout)
+			postsAuth.POST("/:postId/move", postHandler.MovePost)
 		}
 	}
 
+	// Comment routes
+	comments := v1.Group("/comments")
+	comments.Use(authMiddleware.RequireAuth())
+	{
+		comments.PUT("/:commentId", commentHandler.UpdateComment)
+		comments.DELETE("/:commentId", commentHandler.DeleteComment)
+	}
+
+	// Search routes
+	search := v1.Group("/search")
+	search.Use(authMiddleware.RequireAuth())
+	{
+		search.GET("/boards", searchHandler.SearchBoards)
+		search.GET("/posts", searchHandler.SearchPosts)
+	}
+
+	// Board template routes
+	templates := v1.Group("/templates")
+	templates.Use(authMiddleware.RequireAuth())
+	{
+		templates.GET("", templateHandler.ListTemplates)
+		templates.POST("", templateHandler.CreateTemplate)
+		templates.GET("/:templateId", templateHandler.GetTemplate)
+		templates.DELETE("/:templateId", templateHandler.DeleteTemplate)
+		templates.POST("/:templateId/boards", templateHandler.CreateBoardFromTemplate)
+	}
+
+	// Board folder routes
+	folders := v1.Group("/folders")
+	folders.Use(authMiddleware.RequireAuth())
+	{
+		folders.GET("", folderHandler.ListFolders)
+		folders.POST("", folderHandler.CreateFolder)
+		folders.PUT("/:folderId", folderHandler.UpdateFolder)
+		folders.DELETE("/:folderId", folderHandler.DeleteFolder)
+	}
+
+	// Trash routes
+	trash := v1.Group("/trash")
+	trash.Use(authMiddleware.RequireAuth())
+	{
+		trash.GET("", trashHandler.ListTrash)
+		trash.POST("/boards/:boardId/restore", trashHandler.RestoreBoard)
+		trash.DELETE("/boards/:boardId", trashHandler.DeleteBoardPermanently)
+		trash.POST("/posts/:postId/restore", trashHandler.RestorePost)
+		trash.DELETE("/posts/:postId", trashHandler.DeletePostPermanently)
+	}
+
+	// Site administration routes
+	admin := v1.Group("/admin")
+	admin.Use(authMiddleware.RequireAuth(), middleware.AdminOnly())
+	{
+		// Site-wide content filters
+		admin.GET("/content-filters", contentFilterHandler.ListFilterLists)
+		admin.POST("/content-filters", contentFilterHandler.CreateFilterList)
+		admin.GET("/content-filters/events", contentFilterHandler.ListFilterEvents)
+		admin.PUT("/content-filters/:listId", contentFilterHandler.UpdateFilterList)
+		admin.DELETE("/content-filters/:listId", contentFilterHandler.DeleteFilterList)
+
+		// Abuse reports
+		admin.GET("/reports", reportHandler.ListReports)
+		admin.POST("/reports/:reportId/resolve", reportHandler.ResolveReport)
+		admin.DELETE("/users/:userId/suspension", reportHandler.LiftSuspension)
+
+		// Custom reaction emoji
+		admin.POST("/emojis", reactionHandler.CreateCustomEmoji)
+		admin.DELETE("/emojis/:emojiId", reactionHandler.DeleteCustomEmoji)
+	}
+
+	// Contribution reminder routes
+	reminders := v1.Group("/reminders")
+	{
+		reminders.POST("/opt-out", contributionHandler.OptOut)
+	}
+
 	// Theme routes
 	themes := v1.Group("/themes")
 	{
@@ -139,6 +293,20 @@ func Setup(
 		}
 	}
 
+	// Effect routes
+	v1.GET("/effects", effectHandler.ListEffects)
+
+	// Reaction routes
+	v1.GET("/reactions/emojis", reactionHandler.ListEmojis)
+
+	// Embed routes
+	v1.GET("/oembed", embedHandler.OEmbed)
+	embed := v1.Group("/embed")
+	{
+		embed.GET("/boards/:slug", authMiddleware.OptionalAuth(), embedHandler.GetEmbedBoard)
+		embed.GET("/boards/:slug/widget", embedHandler.RenderWidget)
+	}
+
 	// File routes
 	files := v1.Group("/files")
 	{
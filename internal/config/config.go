@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,7 @@ type Config struct {
 	Environment string
 	Port        string
 	ClientURL   string
+	APIURL      string // Public URL of this API, used in links to pages it serves
 
 	// Server Timeouts
 	ReadTimeout       time.Duration
@@ -34,10 +36,12 @@ type Config struct {
 	JWTExpiresIn time.Duration
 
 	// Rate Limiting
-	RateLimitRequests     float64 // Requests per second for general endpoints
-	RateLimitBurst        int     // Maximum burst size for general endpoints
-	AuthRateLimitRequests float64 // Requests per second for auth endpoints
-	AuthRateLimitBurst    int     // Maximum burst size for auth endpoints
+	RateLimitRequests      float64 // Requests per second for general endpoints
+	RateLimitBurst         int     // Maximum burst size for general endpoints
+	AuthRateLimitRequests  float64 // Requests per second for auth endpoints
+	AuthRateLimitBurst     int     // Maximum burst size for auth endpoints
+	ReportRateLimitPerHour int     // Abuse reports per hour for each client
+	ReportRateLimitBurst   int     // Maximum burst size for abuse reports
 
 	// Storage
 	StorageType   string // "local" or "s3"
@@ -52,6 +56,33 @@ type Config struct {
 
 	// Unsplash
 	UnsplashAccessKey string
+
+	// Email
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	EmailFrom    string
+
+	// Contribution reminders
+	ReminderLeadTime          time.Duration // How long before delivery reminders start
+	ReminderInterval          time.Duration // Minimum time between reminders to the same person
+	ReminderMaxPerContributor int           // Maximum reminders sent to the same person per board
+
+	// Trash
+	TrashRetention time.Duration // How long deleted boards and posts can be restored before they are purged
+
+	// Abuse reports
+	ReportAutoHideThreshold int // Open reports from distinct reporters that hide a post or board pending review
+
+	// Analytics
+	AnalyticsRetention time.Duration // How long individual board views are kept before only daily rollups remain
+
+	// Embedding
+	EmbedFrameAncestors []string // Origins besides the client app that may frame embedded boards
+
+	// Reactions
+	ReactionEmojis []string // Shortcodes of the standard emoji posts can be reacted with, in display order
 }
 
 // Load returns application configuration from environment variables
@@ -79,12 +110,39 @@ func Load() *Config {
 	rateLimitBurst, _ := strconv.Atoi(getEnv("RATE_LIMIT_BURST", "20"))
 	authRateLimitRequests, _ := strconv.ParseFloat(getEnv("AUTH_RATE_LIMIT_REQUESTS", "5"), 64)
 	authRateLimitBurst, _ := strconv.Atoi(getEnv("AUTH_RATE_LIMIT_BURST", "10"))
+	reportRateLimitPerHour, _ := strconv.Atoi(getEnv("REPORT_RATE_LIMIT_PER_HOUR", "10"))
+	reportRateLimitBurst, _ := strconv.Atoi(getEnv("REPORT_RATE_LIMIT_BURST", "5"))
+
+	// Parse contribution reminder configuration
+	reminderLeadDays, _ := strconv.Atoi(getEnv("REMINDER_LEAD_DAYS", "3"))
+	reminderIntervalHours, _ := strconv.Atoi(getEnv("REMINDER_INTERVAL_HOURS", "24"))
+	reminderMaxPerContributor, _ := strconv.Atoi(getEnv("REMINDER_MAX_PER_CONTRIBUTOR", "3"))
+
+	// Parse trash retention
+	trashRetentionDays, _ := strconv.Atoi(getEnv("TRASH_RETENTION_DAYS", "30"))
+
+	// Parse abuse report configuration
+	reportAutoHideThreshold, _ := strconv.Atoi(getEnv("REPORT_AUTO_HIDE_THRESHOLD", "3"))
+
+	// Parse analytics retention
+	analyticsRetentionDays, _ := strconv.Atoi(getEnv("ANALYTICS_RETENTION_DAYS", "90"))
+
+	// Parse the origins allowed to frame embedded boards
+	embedFrameAncestors := strings.FieldsFunc(getEnv("EMBED_FRAME_ANCESTORS", ""), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	// Parse the standard reaction emoji
+	reactionEmojis := strings.FieldsFunc(getEnv("REACTION_EMOJIS", "heart,thumbsup,tada,joy,clap,fire,heart_eyes,pray"), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
 
 	return &Config{
 		// Application config
 		Environment: getEnv("APP_ENV", "development"),
 		Port:        getEnv("PORT", "8080"),
 		ClientURL:   getEnv("CLIENT_URL", "http://localhost:3000"),
+		APIURL:      getEnv("API_URL", "http://localhost:8080"),
 
 		// Server Timeouts
 		ReadTimeout:       time.Duration(readTimeout) * time.Second,
@@ -107,10 +165,12 @@ func Load() *Config {
 		JWTExpiresIn: time.Duration(jwtExpiration) * time.Hour,
 
 		// Rate Limiting
-		RateLimitRequests:     rateLimitRequests,
-		RateLimitBurst:        rateLimitBurst,
-		AuthRateLimitRequests: authRateLimitRequests,
-		AuthRateLimitBurst:    authRateLimitBurst,
+		RateLimitRequests:      rateLimitRequests,
+		RateLimitBurst:         rateLimitBurst,
+		AuthRateLimitRequests:  authRateLimitRequests,
+		AuthRateLimitBurst:     authRateLimitBurst,
+		ReportRateLimitPerHour: reportRateLimitPerHour,
+		ReportRateLimitBurst:   reportRateLimitBurst,
 
 		// Storage
 		StorageType:   getEnv("STORAGE_TYPE", "local"),
@@ -125,6 +185,33 @@ func Load() *Config {
 
 		// Unsplash
 		UnsplashAccessKey: getEnv("UNSPLASH_ACCESS_KEY", ""),
+
+		// Email
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		EmailFrom:    getEnv("EMAIL_FROM", "no-reply@kudoboard.local"),
+
+		// Contribution reminders
+		ReminderLeadTime:          time.Duration(reminderLeadDays) * 24 * time.Hour,
+		ReminderInterval:          time.Duration(reminderIntervalHours) * time.Hour,
+		ReminderMaxPerContributor: reminderMaxPerContributor,
+
+		// Trash
+		TrashRetention: time.Duration(trashRetentionDays) * 24 * time.Hour,
+
+		// Abuse reports
+		ReportAutoHideThreshold: reportAutoHideThreshold,
+
+		// Analytics
+		AnalyticsRetention: time.Duration(analyticsRetentionDays) * 24 * time.Hour,
+
+		// Embedding
+		EmbedFrameAncestors: embedFrameAncestors,
+
+		// Reactions
+		ReactionEmojis: reactionEmojis,
 	}
 }
 
@@ -15,13 +15,26 @@ type Container struct {
 	StorageCleanupService *storage.StorageCleanupService
 
 	// Services
-	AuthService     *services.AuthService
-	BoardService    *services.BoardService
-	PostService     *services.PostService
-	ThemeService    *services.ThemeService
-	FileService     *services.FileService
-	GiphyService    *services.GiphyService
-	UnsplashService *services.UnsplashService
+	EmailService         *services.EmailService
+	AuthService          *services.AuthService
+	BoardService         *services.BoardService
+	PostService          *services.PostService
+	ThemeService         *services.ThemeService
+	FileService          *services.FileService
+	GiphyService         *services.GiphyService
+	UnsplashService      *services.UnsplashService
+	ContributionService  *services.ContributionService
+	TemplateService      *services.TemplateService
+	TrashService         *services.TrashService
+	FolderService        *services.FolderService
+	ContentFilterService *services.ContentFilterService
+	ReportService        *services.ReportService
+	AnalyticsService     *services.AnalyticsService
+	SearchService        *services.SearchService
+	EmbedService         *services.EmbedService
+	PreviewService       *services.PreviewService
+	ShareService         *services.ShareService
+	SectionService       *services.SectionService
 }
 
 // NewContainer creates and initializes a new dependency container
@@ -40,12 +53,15 @@ func NewContainer(cfg *config.Config, db *gorm.DB) (*Container, error) {
 	container.StorageCleanupService = storage.NewStorageCleanupService(db, storageService, cfg)
 
 	// Initialize services in the correct order (respect dependencies)
-	container.AuthService = services.NewAuthService(db, storageService, cfg)
-	container.BoardService = services.NewBoardService(db, storageService, cfg)
+	container.EmailService = services.NewEmailService(cfg)
+	container.AuthService = services.NewAuthService(db, storageService, cfg, container.EmailService)
+	container.ContentFilterService = services.NewContentFilterService(db, cfg)
+	container.UnsplashService = services.NewUnsplashService(cfg)
+	container.BoardService = services.NewBoardService(db, storageService, cfg, container.EmailService, container.ContentFilterService, container.UnsplashService)
 	container.ThemeService = services.NewThemeService(db, storageService, cfg)
 	container.FileService = services.NewFileService(storageService, cfg)
 	container.GiphyService = services.NewGiphyService(cfg)
-	container.UnsplashService = services.NewUnsplashService(cfg)
+	container.FolderService = services.NewFolderService(db, cfg)
 
 	// Services with dependencies on other services
 	container.PostService = services.NewPostService(
@@ -53,6 +69,63 @@ func NewContainer(cfg *config.Config, db *gorm.DB) (*Container, error) {
 		storageService,
 		cfg,
 		container.BoardService,
+		container.EmailService,
+		container.ContentFilterService,
+	)
+	container.ContributionService = services.NewContributionService(
+		db,
+		cfg,
+		container.BoardService,
+		container.EmailService,
+	)
+	container.TemplateService = services.NewTemplateService(
+		db,
+		storageService,
+		cfg,
+		container.BoardService,
+	)
+	container.ReportService = services.NewReportService(
+		db,
+		cfg,
+		container.BoardService,
+	)
+	container.AnalyticsService = services.NewAnalyticsService(
+		db,
+		cfg,
+		container.BoardService,
+	)
+	container.SearchService = services.NewSearchService(
+		db,
+		cfg,
+		container.BoardService,
+	)
+	container.EmbedService = services.NewEmbedService(
+		db,
+		cfg,
+		container.BoardService,
+	)
+	container.PreviewService = services.NewPreviewService(
+		db,
+		storageService,
+		cfg,
+		container.BoardService,
+		container.ThemeService,
+	)
+	container.ShareService = services.NewShareService(
+		db,
+		cfg,
+		container.BoardService,
+	)
+	container.SectionService = services.NewSectionService(
+		db,
+		cfg,
+		container.BoardService,
+	)
+	container.TrashService = services.NewTrashService(
+		db,
+		storageService,
+		cfg,
+		container.BoardService,
 	)
 
 	return container, nil
@@ -10,6 +10,7 @@ import (
 	"kudoboard-api/internal/config"
 	"kudoboard-api/internal/log"
 	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
 	"time"
 
 	"go.uber.org/zap"
@@ -134,20 +135,215 @@ func Connect(cfg *config.Config) (*gorm.DB, error) {
 func MigrateSchema(db *gorm.DB) error {
 	log.Info("Running database migrations...")
 
+	// Slugs only need to be unique among boards that aren't in the trash
+	if db.Migrator().HasIndex(&models.Board{}, "idx_boards_slug") {
+		if err := db.Migrator().DropIndex(&models.Board{}, "idx_boards_slug"); err != nil {
+			return fmt.Errorf("failed to drop board slug index: %w", err)
+		}
+	}
+
 	// Auto-migrate all models
 	err := db.AutoMigrate(
 		&models.User{},
 		&models.Theme{},
 		&models.Board{},
 		&models.BoardContributor{},
+		&models.BoardFolder{},
+		&models.BoardSettingChange{},
+		&models.BoardInvitation{},
+		&models.ExpectedContributor{},
+		&models.BoardTemplate{},
+		&models.BoardTemplatePost{},
 		&models.Post{},
-		&models.PostLike{},
+		&models.PostReaction{},
+		&models.CustomEmoji{},
+		&models.PostComment{},
+		&models.PostRevision{},
+		&models.ContentFilterList{},
+		&models.ContentFilterEvent{},
+		&models.Report{},
+		&models.BoardView{},
+		&models.BoardDailyStat{},
+		&models.BoardPreview{},
+		&models.BoardSection{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// Full-text search columns are generated by Postgres from the searchable fields.
+	// Post content is indexed both as written and by word stem.
+	searchMigrations := []string{
+		`ALTER TABLE boards ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('simple', coalesce(receiver_name, '')), 'A')
+		) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_boards_search_vector ON boards USING GIN (search_vector)`,
+		`ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', coalesce(author_name, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(content, '')), 'B') ||
+			setweight(to_tsvector('simple', coalesce(content, '')), 'C')
+		) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_posts_search_vector ON posts USING GIN (search_vector)`,
+	}
+	for _, migration := range searchMigrations {
+		if err := db.Exec(migration).Error; err != nil {
+			return fmt.Errorf("failed to migrate search columns: %w", err)
+		}
+	}
+
+	// Effects saved before the effect schema existed are rewritten in the current schema
+	for _, table := range []string{"boards", "board_templates"} {
+		if err := migrateEffects(db, table); err != nil {
+			return fmt.Errorf("failed to migrate %s effects: %w", table, err)
+		}
+	}
+
+	// Posts created before rank keys existed are ranked in the order of their position
+	if err := migratePostRanks(db); err != nil {
+		return fmt.Errorf("failed to migrate post ranks: %w", err)
+	}
+
+	// Likes from before reactions existed become heart reactions
+	if err := migratePostLikes(db); err != nil {
+		return fmt.Errorf("failed to migrate post likes: %w", err)
+	}
+
+	// Posts written before content formats existed are plain text and need their HTML
+	if err := migratePostContentHTML(db); err != nil {
+		return fmt.Errorf("failed to migrate post content: %w", err)
+	}
+
 	log.Info("Database migrations completed")
 	return nil
 }
+
+// migrateEffects rewrites the effects of a table that aren't in the current effect schema.
+// Effects that can't be read as a known effect are removed.
+func migrateEffects(db *gorm.DB, table string) error {
+	var rows []struct {
+		ID     uint
+		Effect string
+	}
+	if err := db.Table(table).
+		Select("id, effect::text AS effect").
+		Where("effect IS NOT NULL").
+		Where("jsonb_typeof(effect) <> 'object' OR (effect->>'version') IS DISTINCT FROM ?", fmt.Sprint(models.EffectSchemaVersion)).
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		effect := models.UpgradeEffect([]byte(row.Effect))
+		if err := db.Table(table).Where("id = ?", row.ID).Update("effect", effect).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(rows) > 0 {
+		log.Info("Migrated effects", zap.String("table", table), zap.Int("count", len(rows)))
+	}
+	return nil
+}
+
+// migratePostRanks gives every post of boards with unranked posts a rank that follows the
+// order of their positions. Trashed posts are included so they keep their place if restored.
+func migratePostRanks(db *gorm.DB) error {
+	var boardIDs []uint
+	if err := db.Unscoped().Model(&models.Post{}).
+		Where("rank = ''").
+		Distinct().
+		Pluck("board_id", &boardIDs).Error; err != nil {
+		return err
+	}
+
+	for _, boardID := range boardIDs {
+		var postIDs []uint
+		if err := db.Unscoped().Model(&models.Post{}).
+			Where("board_id = ?", boardID).
+			Order("position asc, id asc").
+			Pluck("id", &postIDs).Error; err != nil {
+			return err
+		}
+
+		ranks := utils.RankKeys(len(postIDs))
+		for i, postID := range postIDs {
+			if err := db.Unscoped().Model(&models.Post{}).Where("id = ?", postID).
+				UpdateColumn("rank", ranks[i]).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(boardIDs) > 0 {
+		log.Info("Migrated post ranks", zap.Int("boards", len(boardIDs)))
+	}
+	return nil
+}
+
+// migratePostContentHTML renders the content of posts without HTML as plain text, which is
+// the format every post had before formats existed. Trashed posts are included.
+func migratePostContentHTML(db *gorm.DB) error {
+	const batchSize = 500
+
+	var lastID uint
+	var migrated int
+	for {
+		var posts []struct {
+			ID      uint
+			Content string
+		}
+		if err := db.Unscoped().Model(&models.Post{}).
+			Select("id, content").
+			Where("id > ? AND content_html = '' AND content <> ''", lastID).
+			Order("id asc").
+			Limit(batchSize).
+			Scan(&posts).Error; err != nil {
+			return err
+		}
+		if len(posts) == 0 {
+			break
+		}
+
+		for _, post := range posts {
+			if err := db.Unscoped().Model(&models.Post{}).Where("id = ?", post.ID).
+				UpdateColumns(map[string]interface{}{
+					"content_format": models.PostContentPlain,
+					"content_html":   utils.RenderPlainText(post.Content),
+				}).Error; err != nil {
+				return err
+			}
+		}
+		lastID = posts[len(posts)-1].ID
+		migrated += len(posts)
+	}
+
+	if migrated > 0 {
+		log.Info("Migrated post content", zap.Int("posts", migrated))
+	}
+	return nil
+}
+
+// migratePostLikes turns the likes of the old post_likes table into heart reactions and
+// drops the table
+func migratePostLikes(db *gorm.DB) error {
+	if !db.Migrator().HasTable("post_likes") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`INSERT INTO post_reactions (post_id, user_id, emoji, created_at)
+			SELECT post_id, user_id, ?, created_at FROM post_likes
+			ON CONFLICT DO NOTHING`, models.ReactionHeart)
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := tx.Migrator().DropTable("post_likes"); err != nil {
+			return err
+		}
+
+		log.Info("Migrated post likes to reactions", zap.Int64("count", result.RowsAffected))
+		return nil
+	})
+}
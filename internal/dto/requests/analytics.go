@@ -0,0 +1,7 @@
+package requests
+
+// BoardAnalyticsQuery represents query parameters for board analytics
+type BoardAnalyticsQuery struct {
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
@@ -34,3 +34,8 @@ type ResetPasswordRequest struct {
 	Token    string `json:"token" binding:"required"`
 	Password string `json:"password" binding:"required,min=6"`
 }
+
+// VerifyEmailRequest represents a request to confirm an email address with a token
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
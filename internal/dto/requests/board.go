@@ -1,36 +1,50 @@
 package requests
 
 import (
+	"encoding/json"
 	"kudoboard-api/internal/models"
+	"time"
 )
 
 // CreateBoardRequest represents the request to create a new board
 type CreateBoardRequest struct {
-	Title                string `json:"title" binding:"required"`
-	ReceiverName         string `json:"receiver_name" binding:"required"`
-	FontName             string `json:"font_name" binding:"required"`
-	FontSize             uint   `json:"font_size"`
-	HeaderColor          string `json:"header_color"`
-	ThemeID              *uint  `json:"theme_id"`
-	Effect               string `json:"effect"`
-	EnableIntroAnimation bool   `json:"enable_intro_animation"`
-	IsPrivate            bool   `json:"is_private"`
-	AllowAnonymous       bool   `json:"allow_anonymous"`
+	Title                string          `json:"title" binding:"required"`
+	ReceiverName         string          `json:"receiver_name" binding:"required"`
+	FontName             string          `json:"font_name" binding:"required"`
+	FontSize             uint            `json:"font_size"`
+	HeaderColor          string          `json:"header_color"`
+	ThemeID              *uint           `json:"theme_id"`
+	Effect               json.RawMessage `json:"effect"`
+	Style                json.RawMessage `json:"style"`
+	EnableIntroAnimation bool            `json:"enable_intro_animation"`
+	IsPrivate            bool            `json:"is_private"`
+	AllowAnonymous       bool            `json:"allow_anonymous"`
+	DeliveryAt           *time.Time      `json:"delivery_at"`
+	EnableReminders      *bool           `json:"enable_reminders"`
+	RequireApproval      bool            `json:"require_approval"`
+	AllowEmbed           *bool           `json:"allow_embed"`
+	LayoutMode           string          `json:"layout_mode" binding:"omitempty,oneof=grid canvas"`
 }
 
 // UpdateBoardRequest represents the request to update a board
 type UpdateBoardRequest struct {
-	Title                *string `json:"title"`
-	ReceiverName         *string `json:"receiver_name" `
-	FontName             *string `json:"font_name"`
-	FontSize             *uint   `json:"font_size"`
-	HeaderColor          *string `json:"header_color"`
-	ShowHeaderColor      *bool   `json:"show_header_color"`
-	ThemeID              *uint   `json:"theme_id"`
-	Effect               *string `json:"effect"`
-	EnableIntroAnimation *bool   `json:"enable_intro_animation"`
-	IsPrivate            *bool   `json:"is_private"`
-	AllowAnonymous       *bool   `json:"allow_anonymous"`
+	Title                *string          `json:"title"`
+	ReceiverName         *string          `json:"receiver_name" `
+	FontName             *string          `json:"font_name"`
+	FontSize             *uint            `json:"font_size"`
+	HeaderColor          *string          `json:"header_color"`
+	ShowHeaderColor      *bool            `json:"show_header_color"`
+	ThemeID              *uint            `json:"theme_id"`
+	Effect               *json.RawMessage `json:"effect"`
+	Style                *json.RawMessage `json:"style"`
+	EnableIntroAnimation *bool            `json:"enable_intro_animation"`
+	IsPrivate            *bool            `json:"is_private"`
+	AllowAnonymous       *bool            `json:"allow_anonymous"`
+	DeliveryAt           *time.Time       `json:"delivery_at"`
+	EnableReminders      *bool            `json:"enable_reminders"`
+	RequireApproval      *bool            `json:"require_approval"`
+	AllowEmbed           *bool            `json:"allow_embed"`
+	LayoutMode           *string          `json:"layout_mode" binding:"omitempty,oneof=grid canvas"`
 }
 
 // LockBoardRequest represents a request to lock or unlock a board
@@ -40,26 +54,88 @@ type LockBoardRequest struct {
 
 // UpdateBoardPreferencesRequest represents a request to update a user's board preferences
 type UpdateBoardPreferencesRequest struct {
-	IsFavorite *bool `json:"is_favorite,omitempty"`
-	IsArchived *bool `json:"is_archived,omitempty"`
+	IsFavorite       *bool `json:"is_favorite,omitempty"`
+	IsArchived       *bool `json:"is_archived,omitempty"`
+	FolderID         *uint `json:"folder_id,omitempty"`
+	RemoveFromFolder bool  `json:"remove_from_folder,omitempty"`
+}
+
+// BulkUpdateBoardPreferencesRequest represents a request to move, favorite or archive many boards at once
+type BulkUpdateBoardPreferencesRequest struct {
+	BoardIDs []uint `json:"board_ids" binding:"required,min=1,max=100"`
+	UpdateBoardPreferencesRequest
 }
 
 // BoardQuery represents query parameters for board listing
 type BoardQuery struct {
-	Page    int    `form:"page" binding:"min=1"`
-	PerPage int    `form:"per_page" binding:"min=1,max=100"`
-	Search  string `form:"search"`
-	SortBy  string `form:"sort_by" binding:"omitempty,oneof=created_at title"`
-	Order   string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Page              int    `form:"page" binding:"min=1"`
+	PerPage           int    `form:"per_page" binding:"min=1,max=100"`
+	Search            string `form:"search"`
+	SortBy            string `form:"sort_by" binding:"omitempty,oneof=created_at title"`
+	Order             string `form:"order" binding:"omitempty,oneof=asc desc"`
+	FolderID          string `form:"folder_id"`
+	IncludeSubfolders bool   `form:"include_subfolders"`
+	Ownership         string `form:"ownership" binding:"omitempty,oneof=owned shared received"`
+	IsFavorite        *bool  `form:"is_favorite"`
+	IsArchived        *bool  `form:"is_archived"`
 }
 
 // AddContributorRequest represents a request to add a contributor to a board
 type AddContributorRequest struct {
 	Email string      `json:"email" binding:"required,email"`
-	Role  models.Role `json:"role" binding:"required,oneof=viewer contributor admin"`
+	Role  models.Role `json:"role" binding:"required,oneof=viewer contributor moderator admin"`
 }
 
 // UpdateContributorRequest represents a request to update a contributor's role
 type UpdateContributorRequest struct {
-	Role models.Role `json:"role" binding:"required,oneof=viewer contributor admin"`
+	Role models.Role `json:"role" binding:"required,oneof=viewer contributor moderator admin"`
+}
+
+// BulkContributorEntry represents a single row of a bulk contributor import
+type BulkContributorEntry struct {
+	Email string      `json:"email"`
+	Role  models.Role `json:"role"`
+}
+
+// BulkAddContributorsRequest represents a JSON bulk contributor import
+type BulkAddContributorsRequest struct {
+	Contributors []BulkContributorEntry `json:"contributors" binding:"required,min=1"`
+}
+
+// BulkContributorChange represents a role update or removal for a single contributor
+type BulkContributorChange struct {
+	UserID uint        `json:"user_id"`
+	Role   models.Role `json:"role"`
+	Remove bool        `json:"remove"`
+}
+
+// BulkUpdateContributorsRequest represents a request to update or remove many contributors at once
+type BulkUpdateContributorsRequest struct {
+	Changes []BulkContributorChange `json:"changes" binding:"required,min=1"`
+}
+
+// ExpectedContributorEntry represents a person expected to sign a board
+type ExpectedContributorEntry struct {
+	Email string `json:"email" binding:"required,email"`
+	Name  string `json:"name"`
+}
+
+// AddExpectedContributorsRequest represents a request to add people expected to sign a board
+type AddExpectedContributorsRequest struct {
+	Contributors             []ExpectedContributorEntry `json:"contributors" binding:"dive"`
+	IncludeBoardContributors bool                       `json:"include_board_contributors"`
+}
+
+// ReminderOptOutRequest represents a request to stop receiving contribution reminders
+type ReminderOptOutRequest struct {
+	Token     string `json:"token" binding:"required"`
+	AllBoards bool   `json:"all_boards"`
+}
+
+// DuplicateBoardRequest represents a request to duplicate a board
+type DuplicateBoardRequest struct {
+	Title               *string `json:"title"`
+	ReceiverName        *string `json:"receiver_name"`
+	IncludeContributors *bool   `json:"include_contributors"`
+	IncludePosts        bool    `json:"include_posts"`
 }
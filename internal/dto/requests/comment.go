@@ -0,0 +1,19 @@
+package requests
+
+// CreateCommentRequest represents a request to comment on a post or reply to a comment
+type CreateCommentRequest struct {
+	Content    string `json:"content" binding:"required,max=2000"`
+	ParentID   *uint  `json:"parent_id"`
+	AuthorName string `json:"author_name" binding:"max=100"`
+}
+
+// UpdateCommentRequest represents a request to edit a comment
+type UpdateCommentRequest struct {
+	Content string `json:"content" binding:"required,max=2000"`
+}
+
+// CommentsQuery represents query parameters for the comments on a post
+type CommentsQuery struct {
+	Page    int `form:"page" binding:"omitempty,min=1"`
+	PerPage int `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
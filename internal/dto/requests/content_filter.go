@@ -0,0 +1,28 @@
+package requests
+
+import "kudoboard-api/internal/models"
+
+// CreateContentFilterListRequest represents a request to create a content filter word list
+type CreateContentFilterListRequest struct {
+	Name      string                     `json:"name" binding:"required,max=100"`
+	Action    models.ContentFilterAction `json:"action" binding:"required,oneof=reject mask moderate"`
+	Words     []string                   `json:"words" binding:"required,min=1,max=2000,dive,required,max=100"`
+	IsEnabled *bool                      `json:"is_enabled"`
+}
+
+// UpdateContentFilterListRequest represents a request to update a content filter word list
+type UpdateContentFilterListRequest struct {
+	Name      *string                     `json:"name" binding:"omitempty,min=1,max=100"`
+	Action    *models.ContentFilterAction `json:"action" binding:"omitempty,oneof=reject mask moderate"`
+	Words     []string                    `json:"words" binding:"omitempty,min=1,max=2000,dive,required,max=100"`
+	IsEnabled *bool                       `json:"is_enabled"`
+}
+
+// ContentFilterEventQuery represents query parameters for listing content filter events
+type ContentFilterEventQuery struct {
+	Page    int    `form:"page" binding:"omitempty,min=1"`
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	BoardID uint   `form:"board_id"`
+	ListID  uint   `form:"list_id"`
+	Action  string `form:"action" binding:"omitempty,oneof=reject mask moderate"`
+}
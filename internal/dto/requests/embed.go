@@ -0,0 +1,9 @@
+package requests
+
+// OEmbedQuery represents the query parameters of an oEmbed request
+type OEmbedQuery struct {
+	URL       string `form:"url" binding:"required"`
+	MaxWidth  int    `form:"maxwidth" binding:"omitempty,min=1"`
+	MaxHeight int    `form:"maxheight" binding:"omitempty,min=1"`
+	Format    string `form:"format"`
+}
@@ -0,0 +1,16 @@
+package requests
+
+// CreateFolderRequest represents a request to create a board folder
+type CreateFolderRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	Color    string `json:"color" binding:"omitempty,hexcolor"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// UpdateFolderRequest represents a request to rename, recolor or move a board folder
+type UpdateFolderRequest struct {
+	Name       *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Color      *string `json:"color" binding:"omitempty,hexcolor"`
+	ParentID   *uint   `json:"parent_id"`
+	MoveToRoot bool    `json:"move_to_root"`
+}
@@ -3,17 +3,20 @@ package requests
 // CreatePostRequest represents the request to create a new post
 type CreatePostRequest struct {
 	Content         string `json:"content" binding:"required"`
+	ContentFormat   string `json:"content_format" binding:"omitempty,oneof=plain markdown"`
 	AuthorName      string `json:"author_name"`
 	BackgroundColor string `json:"background_color"`
 	TextColor       string `json:"text_color"`
 	MediaPath       string `json:"media_path,omitempty"`
 	MediaType       string `json:"media_type,omitempty" binding:"required_with=MediaPath"`
 	MediaSource     string `json:"media_source,omitempty" binding:"required_with=MediaPath,omitempty,oneof=internal external"`
+	SectionID       *uint  `json:"section_id,omitempty"`
 }
 
 // UpdatePostRequest represents the request to update a post
 type UpdatePostRequest struct {
 	Content         *string `json:"content"`
+	ContentFormat   *string `json:"content_format" binding:"omitempty,oneof=plain markdown"`
 	AuthorName      *string `json:"author_name"`
 	BackgroundColor *string `json:"background_color"`
 	TextColor       *string `json:"text_color"`
@@ -22,9 +25,11 @@ type UpdatePostRequest struct {
 	MediaSource     *string `json:"media_source" binding:"required_with=MediaPath,omitempty,oneof=internal external"`
 }
 
-// ReorderPostsRequest represents the request to reorder posts on a board
+// ReorderPostsRequest represents the request to reorder posts on a board. With a section,
+// the posts are also moved into that section.
 type ReorderPostsRequest struct {
 	PostPositions []PostPosition `json:"post_positions" binding:"required"`
+	SectionID     *uint          `json:"section_id"`
 }
 
 // PostPosition represents the new order for a post
@@ -32,3 +37,57 @@ type PostPosition struct {
 	ID       uint `json:"id" binding:"required"`
 	Position int  `json:"position" binding:"required"`
 }
+
+// MovePostRequest represents a request to move a post next to other posts of its board.
+// AfterID is the post it should follow and BeforeID the post it should precede; one of
+// them is enough.
+type MovePostRequest struct {
+	BeforeID *uint `json:"before_id" binding:"required_without=AfterID"`
+	AfterID  *uint `json:"after_id" binding:"required_without=BeforeID"`
+}
+
+// ModeratePostRequest represents a request to approve or reject a pending post
+type ModeratePostRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// ModerationQueueQuery represents query parameters for a board's moderation queue
+type ModerationQueueQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending rejected"`
+}
+
+// BoardPostsQuery represents query parameters for a page of a board's posts
+type BoardPostsQuery struct {
+	Cursor    string `form:"cursor"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	SortBy    string `form:"sort_by" binding:"omitempty,oneof=position created_at likes"`
+	Order     string `form:"order" binding:"omitempty,oneof=asc desc"`
+	AuthorID  uint   `form:"author_id"`
+	MediaType string `form:"media_type" binding:"omitempty,max=50"`
+	SectionID uint   `form:"section_id"`
+}
+
+// UpdatePostLayoutRequest represents a change to where a post sits on a canvas board.
+// Fields left out keep their current value. Version is the stamp of the change, such as
+// the time the drag ended in milliseconds; changes older than the stored stamp are ignored.
+// Stamps more than a minute ahead of the server time are rejected.
+type UpdatePostLayoutRequest struct {
+	X        *float64 `json:"x"`
+	Y        *float64 `json:"y"`
+	Width    *float64 `json:"width"`
+	Height   *float64 `json:"height"`
+	Rotation *float64 `json:"rotation"`
+	ZIndex   *int     `json:"z_index"`
+	Version  *int64   `json:"version" binding:"omitempty,min=1"`
+}
+
+// BatchUpdatePostLayoutsRequest represents the layout changes of several posts moved together
+type BatchUpdatePostLayoutsRequest struct {
+	Layouts []PostLayoutUpdate `json:"layouts" binding:"required,min=1,max=200,dive"`
+}
+
+// PostLayoutUpdate represents the layout change of one post in a batch
+type PostLayoutUpdate struct {
+	PostID uint `json:"post_id" binding:"required"`
+	UpdatePostLayoutRequest
+}
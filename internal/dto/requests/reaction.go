@@ -0,0 +1,19 @@
+package requests
+
+// AddReactionRequest represents a request to react to a post with an emoji
+type AddReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required,max=64"`
+}
+
+// ReactionsQuery represents query parameters for the users who reacted to a post
+type ReactionsQuery struct {
+	Emoji   string `form:"emoji" binding:"omitempty,max=64"`
+	Page    int    `form:"page" binding:"omitempty,min=1"`
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
+
+// CreateCustomEmojiRequest represents a request to add a custom reaction emoji
+type CreateCustomEmojiRequest struct {
+	Name     string `json:"name" binding:"required,max=32"`
+	ImageURL string `json:"image_url" binding:"required"`
+}
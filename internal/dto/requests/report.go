@@ -0,0 +1,25 @@
+package requests
+
+import "kudoboard-api/internal/models"
+
+// CreateReportRequest represents a request to report a post or board for abuse
+type CreateReportRequest struct {
+	Reason  models.ReportReason `json:"reason" binding:"required,oneof=spam harassment hate_speech sexual_content violence personal_info other"`
+	Details string              `json:"details" binding:"max=1000"`
+}
+
+// ReportQuery represents query parameters for the abuse report queue
+type ReportQuery struct {
+	Page       int    `form:"page" binding:"omitempty,min=1"`
+	PerPage    int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Status     string `form:"status" binding:"omitempty,oneof=open dismissed resolved"`
+	TargetType string `form:"target_type" binding:"omitempty,oneof=post board"`
+	Reason     string `form:"reason" binding:"omitempty,oneof=spam harassment hate_speech sexual_content violence personal_info other"`
+	BoardID    uint   `form:"board_id"`
+}
+
+// ResolveReportRequest represents a site admin's decision on an abuse report
+type ResolveReportRequest struct {
+	Action models.ReportAction `json:"action" binding:"required,oneof=dismiss hide_post lock_board suspend_user"`
+	Note   string              `json:"note" binding:"max=1000"`
+}
@@ -0,0 +1,18 @@
+package requests
+
+// SearchQuery represents query parameters for a full-text search
+type SearchQuery struct {
+	Query   string `form:"q" binding:"required,max=200"`
+	Page    int    `form:"page" binding:"omitempty,min=1"`
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
+
+// PostSearchQuery represents query parameters for a full-text search of posts
+type PostSearchQuery struct {
+	SearchQuery
+	BoardID   uint   `form:"board_id"`
+	AuthorID  uint   `form:"author_id"`
+	MediaType string `form:"media_type" binding:"omitempty,max=50"`
+	From      string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To        string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,30 @@
+package requests
+
+// CreateSectionRequest represents a request to add a section to a board
+type CreateSectionRequest struct {
+	Title string `json:"title" binding:"required,max=100"`
+	Color string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+// UpdateSectionRequest represents a request to rename or recolor a board section
+type UpdateSectionRequest struct {
+	Title *string `json:"title" binding:"omitempty,min=1,max=100"`
+	Color *string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+// ReorderSectionsRequest represents the request to reorder the sections of a board
+type ReorderSectionsRequest struct {
+	SectionPositions []SectionPosition `json:"section_positions" binding:"required"`
+}
+
+// SectionPosition represents the new order for a section
+type SectionPosition struct {
+	ID       uint `json:"id" binding:"required"`
+	Position int  `json:"position"`
+}
+
+// MovePostToSectionRequest represents a request to move a post to another section of its board
+type MovePostToSectionRequest struct {
+	SectionID uint `json:"section_id" binding:"required"`
+	Position  *int `json:"position"`
+}
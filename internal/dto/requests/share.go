@@ -0,0 +1,14 @@
+package requests
+
+// BoardQRQuery represents the query parameters of a board QR code request
+type BoardQRQuery struct {
+	Format          string `form:"format" binding:"omitempty,oneof=png svg"`
+	Size            int    `form:"size" binding:"omitempty,min=64,max=2048"`
+	ErrorCorrection string `form:"ecc" binding:"omitempty,oneof=L M Q H l m q h"`
+}
+
+// ShareCardQuery represents the query parameters of a printable share card request
+type ShareCardQuery struct {
+	Paper           string `form:"paper" binding:"omitempty,oneof=a4 letter"`
+	ErrorCorrection string `form:"ecc" binding:"omitempty,oneof=L M Q H l m q h"`
+}
@@ -0,0 +1,22 @@
+package requests
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// CreateTemplateRequest represents a request to save a board's settings as a template
+type CreateTemplateRequest struct {
+	BoardID      uint                 `json:"board_id" binding:"required"`
+	Name         string               `json:"name" binding:"required"`
+	Description  string               `json:"description"`
+	Scope        models.TemplateScope `json:"scope" binding:"omitempty,oneof=personal org"`
+	IncludePosts bool                 `json:"include_posts"`
+}
+
+// CreateBoardFromTemplateRequest represents a request to create a board from a template
+type CreateBoardFromTemplateRequest struct {
+	Title        *string    `json:"title"`
+	ReceiverName string     `json:"receiver_name" binding:"required"`
+	DeliveryAt   *time.Time `json:"delivery_at"`
+}
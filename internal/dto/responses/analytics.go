@@ -0,0 +1,41 @@
+package responses
+
+import "time"
+
+// BoardAnalyticsResponse represents a board's views and activity over a range of days
+type BoardAnalyticsResponse struct {
+	BoardID           uint                      `json:"board_id"`
+	From              string                    `json:"from"`
+	To                string                    `json:"to"`
+	Views             int64                     `json:"views"`
+	UniqueVisitors    int64                     `json:"unique_visitors"`
+	NewPosts          int64                     `json:"new_posts"`
+	NewLikes          int64                     `json:"new_likes"`
+	NewContributors   int64                     `json:"new_contributors"`
+	TotalPosts        int64                     `json:"total_posts"`
+	TotalLikes        int64                     `json:"total_likes"`
+	TotalContributors int64                     `json:"total_contributors"`
+	Daily             []DailyBoardStatsResponse `json:"daily"`
+}
+
+// DailyBoardStatsResponse represents a board's views and activity on one day
+type DailyBoardStatsResponse struct {
+	Day               string `json:"day"`
+	Views             int64  `json:"views"`
+	UniqueVisitors    int64  `json:"unique_visitors"`
+	NewPosts          int64  `json:"new_posts"`
+	NewLikes          int64  `json:"new_likes"`
+	NewContributors   int64  `json:"new_contributors"`
+	TotalPosts        int64  `json:"total_posts"`
+	TotalLikes        int64  `json:"total_likes"`
+	TotalContributors int64  `json:"total_contributors"`
+}
+
+// BoardViewerResponse represents a signed-in user who opened a private board
+type BoardViewerResponse struct {
+	User          UserResponse `json:"user"`
+	FirstViewedAt time.Time    `json:"first_viewed_at"`
+	LastViewedAt  time.Time    `json:"last_viewed_at"`
+	VisitDays     int64        `json:"visit_days"`
+	ViewCount     int64        `json:"view_count"`
+}
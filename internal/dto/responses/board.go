@@ -1,39 +1,49 @@
 package responses
 
 import (
+	"encoding/json"
 	"kudoboard-api/internal/models"
 	"time"
 )
 
 // BoardResponse represents a board in API responses
 type BoardResponse struct {
-	ID                   uint           `json:"id"`
-	Title                string         `json:"title"`
-	ReceiverName         string         `json:"receiver_name"`
-	Slug                 string         `json:"slug"`
-	MaxPost              uint           `json:"max_post"`
-	Creator              UserResponse   `json:"creator"`
-	FontName             string         `json:"font_name" `
-	FontSize             uint           `json:"font_size"`
-	HeaderColor          string         `json:"header_color"`
-	ShowHeaderColor      bool           `json:"show_header_color"`
-	Theme                *ThemeResponse `json:"theme,omitempty"`
-	Effect               string         `json:"effect"`
-	EnableIntroAnimation bool           `json:"enable_intro_animation"`
-	IsPrivate            bool           `json:"is_private"`
-	IsLocked             bool           `json:"is_locked"`
-	AllowAnonymous       bool           `json:"allow_anonymous"`
-	CreatedAt            time.Time      `json:"created_at"`
-	UpdatedAt            time.Time      `json:"updated_at"`
-	PostCount            int64          `json:"post_count"`
+	ID                   uint                 `json:"id"`
+	Title                string               `json:"title"`
+	ReceiverName         string               `json:"receiver_name"`
+	Slug                 string               `json:"slug"`
+	MaxPost              uint                 `json:"max_post"`
+	Creator              UserResponse         `json:"creator"`
+	FontName             string               `json:"font_name" `
+	FontSize             uint                 `json:"font_size"`
+	HeaderColor          string               `json:"header_color"`
+	ShowHeaderColor      bool                 `json:"show_header_color"`
+	Theme                *ThemeResponse       `json:"theme,omitempty"`
+	Effect               *BoardEffectResponse `json:"effect"`
+	Style                *BoardStyleResponse  `json:"style"`
+	EnableIntroAnimation bool                 `json:"enable_intro_animation"`
+	IsPrivate            bool                 `json:"is_private"`
+	IsLocked             bool                 `json:"is_locked"`
+	IsHidden             bool                 `json:"is_hidden"`
+	AllowAnonymous       bool                 `json:"allow_anonymous"`
+	DeliveryAt           *time.Time           `json:"delivery_at,omitempty"`
+	EnableReminders      bool                 `json:"enable_reminders"`
+	RequireApproval      bool                 `json:"require_approval"`
+	AllowEmbed           bool                 `json:"allow_embed"`
+	LayoutMode           string               `json:"layout_mode"`
+	CreatedAt            time.Time            `json:"created_at"`
+	UpdatedAt            time.Time            `json:"updated_at"`
+	PostCount            int64                `json:"post_count"`
+	PendingCount         int64                `json:"pending_count"`
 }
 
 // BoardResponseWithRelation extends BoardResponse with user relationship info
 type BoardResponseWithRelation struct {
 	BoardResponse
-	IsOwner    bool `json:"is_owner"`
-	IsFavorite bool `json:"is_favorite"`
-	IsArchived bool `json:"is_archived"`
+	IsOwner    bool  `json:"is_owner"`
+	IsFavorite bool  `json:"is_favorite"`
+	IsArchived bool  `json:"is_archived"`
+	FolderID   *uint `json:"folder_id"`
 }
 
 // ThemeResponse represents a theme in API responses
@@ -53,6 +63,23 @@ type BoardContributorResponse struct {
 	CreatedAt time.Time    `json:"created_at"`
 }
 
+// BulkContributorResult represents the outcome of a single row of a bulk contributor operation
+type BulkContributorResult struct {
+	Row     int    `json:"row"`
+	Email   string `json:"email,omitempty"`
+	UserID  uint   `json:"user_id,omitempty"`
+	Role    string `json:"role,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// BulkContributorsResponse represents the outcome of a bulk contributor operation
+type BulkContributorsResponse struct {
+	DryRun  bool                    `json:"dry_run"`
+	Summary map[string]int          `json:"summary"`
+	Results []BulkContributorResult `json:"results"`
+}
+
 // NewBoardResponse creates a new board response from a board model
 func NewBoardResponse(board *models.Board, creator *models.User, postCount int64) BoardResponse {
 	response := BoardResponse{
@@ -65,11 +92,18 @@ func NewBoardResponse(board *models.Board, creator *models.User, postCount int64
 		FontSize:             board.FontSize,
 		HeaderColor:          board.HeaderColor,
 		ShowHeaderColor:      board.ShowHeaderColor,
-		Effect:               board.Effect,
+		Effect:               NewBoardEffectResponse(board.Effect),
+		Style:                NewBoardStyleResponse(board.Style),
 		EnableIntroAnimation: board.EnableIntroAnimation,
 		IsPrivate:            board.IsPrivate,
 		IsLocked:             board.IsLocked,
+		IsHidden:             board.HiddenAt != nil,
 		AllowAnonymous:       board.AllowAnonymous,
+		DeliveryAt:           board.DeliveryAt,
+		EnableReminders:      board.EnableReminders,
+		RequireApproval:      board.RequireApproval,
+		AllowEmbed:           board.AllowEmbed,
+		LayoutMode:           string(board.LayoutMode),
 		CreatedAt:            board.CreatedAt,
 		UpdatedAt:            board.UpdatedAt,
 		PostCount:            postCount,
@@ -83,12 +117,13 @@ func NewBoardResponse(board *models.Board, creator *models.User, postCount int64
 }
 
 // NewBoardResponseWithRelation creates a new board response with relation info
-func NewBoardResponseWithRelation(board *models.Board, creator *models.User, postCount int64, isOwner, isFavorite, isArchived bool) BoardResponseWithRelation {
+func NewBoardResponseWithRelation(board *models.Board, creator *models.User, postCount int64, isOwner, isFavorite, isArchived bool, folderID *uint) BoardResponseWithRelation {
 	return BoardResponseWithRelation{
 		BoardResponse: NewBoardResponse(board, creator, postCount),
 		IsOwner:       isOwner,
 		IsFavorite:    isFavorite,
 		IsArchived:    isArchived,
+		FolderID:      folderID,
 	}
 }
 
@@ -112,3 +147,57 @@ func NewBoardContributorResponse(contributor *models.BoardContributor, user *mod
 		CreatedAt: contributor.CreatedAt,
 	}
 }
+
+// NewBulkContributorsResponse creates a bulk contributor response with a per-status summary
+func NewBulkContributorsResponse(dryRun bool, results []BulkContributorResult) BulkContributorsResponse {
+	summary := make(map[string]int)
+	for _, result := range results {
+		summary[result.Status]++
+	}
+
+	return BulkContributorsResponse{
+		DryRun:  dryRun,
+		Summary: summary,
+		Results: results,
+	}
+}
+
+// BoardVersionResponse represents a version of a board's settings in API responses
+type BoardVersionResponse struct {
+	Version   int                          `json:"version"`
+	Actor     *UserResponse                `json:"actor,omitempty"`
+	ChangedAt time.Time                    `json:"changed_at"`
+	Changes   []BoardSettingChangeResponse `json:"changes"`
+}
+
+// BoardSettingChangeResponse represents a single changed board setting in API responses
+type BoardSettingChangeResponse struct {
+	Field    string          `json:"field"`
+	OldValue json.RawMessage `json:"old_value"`
+	NewValue json.RawMessage `json:"new_value"`
+}
+
+// NewBoardVersionResponse creates a new board version response from the changes of a version
+func NewBoardVersionResponse(version int, actor *models.User, changedAt time.Time, changes []models.BoardSettingChange) BoardVersionResponse {
+	response := BoardVersionResponse{
+		Version:   version,
+		ChangedAt: changedAt,
+		Changes:   make([]BoardSettingChangeResponse, len(changes)),
+	}
+
+	if actor != nil {
+		var actorResponse UserResponse
+		actorResponse.FromUser(actor)
+		response.Actor = &actorResponse
+	}
+
+	for i, change := range changes {
+		response.Changes[i] = BoardSettingChangeResponse{
+			Field:    change.Field,
+			OldValue: json.RawMessage(change.OldValue),
+			NewValue: json.RawMessage(change.NewValue),
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,42 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// CommentResponse represents a comment on a post in API responses
+type CommentResponse struct {
+	ID         uint              `json:"id"`
+	PostID     uint              `json:"post_id"`
+	ParentID   *uint             `json:"parent_id"`
+	Author     *UserResponse     `json:"author,omitempty"`
+	AuthorName string            `json:"author_name"`
+	Content    string            `json:"content"`
+	Edited     bool              `json:"edited"`
+	EditedAt   *time.Time        `json:"edited_at,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Replies    []CommentResponse `json:"replies,omitempty"`
+}
+
+// NewCommentResponse creates a new comment response from a comment model
+func NewCommentResponse(comment *models.PostComment, author *models.User) CommentResponse {
+	response := CommentResponse{
+		ID:         comment.ID,
+		PostID:     comment.PostID,
+		ParentID:   comment.ParentID,
+		AuthorName: comment.AuthorName,
+		Content:    comment.Content,
+		Edited:     comment.EditedAt != nil,
+		EditedAt:   comment.EditedAt,
+		CreatedAt:  comment.CreatedAt,
+	}
+
+	// Include author details if not anonymous
+	if author != nil {
+		authorResponse := NewUserResponse(author)
+		response.Author = &authorResponse
+	}
+
+	return response
+}
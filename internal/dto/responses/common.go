@@ -10,9 +10,16 @@ type APIResponse struct {
 
 // APIError represents an error in the API response
 type APIError struct {
-	Code    string `json:"code"`
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Details string               `json:"details,omitempty"`
+	Fields  []FieldErrorResponse `json:"fields,omitempty"`
+}
+
+// FieldErrorResponse describes why the value of one request field is invalid
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
-	Details string `json:"details,omitempty"`
 }
 
 // Pagination represents pagination information in the API response
@@ -0,0 +1,69 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"strings"
+	"time"
+)
+
+// ContentFilterListResponse represents a content filter word list in API responses
+type ContentFilterListResponse struct {
+	ID        uint                       `json:"id"`
+	BoardID   *uint                      `json:"board_id"`
+	Name      string                     `json:"name"`
+	Action    models.ContentFilterAction `json:"action"`
+	Words     []string                   `json:"words"`
+	IsEnabled bool                       `json:"is_enabled"`
+	CreatedAt time.Time                  `json:"created_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+// ContentFilterEventResponse represents a recorded content filter match in API responses
+type ContentFilterEventResponse struct {
+	ID           uint                       `json:"id"`
+	ListID       uint                       `json:"list_id"`
+	BoardID      *uint                      `json:"board_id"`
+	UserID       *uint                      `json:"user_id"`
+	Field        string                     `json:"field"`
+	Action       models.ContentFilterAction `json:"action"`
+	MatchedWords []string                   `json:"matched_words"`
+	Excerpt      string                     `json:"excerpt"`
+	CreatedAt    time.Time                  `json:"created_at"`
+}
+
+// NewContentFilterListResponse creates a new content filter list response from a list model
+func NewContentFilterListResponse(list *models.ContentFilterList) ContentFilterListResponse {
+	return ContentFilterListResponse{
+		ID:        list.ID,
+		BoardID:   list.BoardID,
+		Name:      list.Name,
+		Action:    list.Action,
+		Words:     splitLines(list.Words),
+		IsEnabled: list.IsEnabled,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+}
+
+// NewContentFilterEventResponse creates a new content filter event response from an event model
+func NewContentFilterEventResponse(event *models.ContentFilterEvent) ContentFilterEventResponse {
+	return ContentFilterEventResponse{
+		ID:           event.ID,
+		ListID:       event.ListID,
+		BoardID:      event.BoardID,
+		UserID:       event.UserID,
+		Field:        event.Field,
+		Action:       event.Action,
+		MatchedWords: splitLines(event.MatchedWords),
+		Excerpt:      event.Excerpt,
+		CreatedAt:    event.CreatedAt,
+	}
+}
+
+// splitLines splits newline-separated values, returning an empty list for an empty string
+func splitLines(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, "\n")
+}
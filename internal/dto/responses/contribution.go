@@ -0,0 +1,58 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// ExpectedContributorResponse represents a person expected to sign a board in API responses
+type ExpectedContributorResponse struct {
+	ID             uint          `json:"id"`
+	Email          string        `json:"email"`
+	Name           string        `json:"name"`
+	User           *UserResponse `json:"user,omitempty"`
+	HasPosted      bool          `json:"has_posted"`
+	PostCount      int64         `json:"post_count"`
+	FirstPostedAt  *time.Time    `json:"first_posted_at,omitempty"`
+	ReminderCount  int           `json:"reminder_count"`
+	LastRemindedAt *time.Time    `json:"last_reminded_at,omitempty"`
+	OptedOut       bool          `json:"opted_out"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// CompletionResponse summarizes how many expected contributors have signed a board
+type CompletionResponse struct {
+	BoardID             uint                          `json:"board_id"`
+	DeliveryAt          *time.Time                    `json:"delivery_at,omitempty"`
+	Total               int                           `json:"total"`
+	Completed           int                           `json:"completed"`
+	Pending             int                           `json:"pending"`
+	OptedOut            int                           `json:"opted_out"`
+	CompletionRate      float64                       `json:"completion_rate"`
+	RemindersSent       int                           `json:"reminders_sent"`
+	PendingContributors []ExpectedContributorResponse `json:"pending_contributors"`
+}
+
+// NewExpectedContributorResponse creates a new expected contributor response
+func NewExpectedContributorResponse(expected *models.ExpectedContributor, user *models.User, postCount int64, firstPostedAt *time.Time) ExpectedContributorResponse {
+	response := ExpectedContributorResponse{
+		ID:             expected.ID,
+		Email:          expected.Email,
+		Name:           expected.Name,
+		HasPosted:      postCount > 0,
+		PostCount:      postCount,
+		FirstPostedAt:  firstPostedAt,
+		ReminderCount:  expected.ReminderCount,
+		LastRemindedAt: expected.LastRemindedAt,
+		OptedOut:       expected.OptedOut,
+		CreatedAt:      expected.CreatedAt,
+	}
+
+	// Include account details if the person has signed up
+	if user != nil {
+		userResponse := NewUserResponse(user)
+		response.User = &userResponse
+	}
+
+	return response
+}
@@ -0,0 +1,83 @@
+package responses
+
+import "kudoboard-api/internal/models"
+
+// BoardEffectResponse represents the animated effect of a board or template in API responses
+type BoardEffectResponse struct {
+	Version int                    `json:"version"`
+	Type    string                 `json:"type"`
+	Params  map[string]interface{} `json:"params"`
+}
+
+// EffectResponse represents an effect boards can show
+type EffectResponse struct {
+	Type        string                `json:"type"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Params      []EffectParamResponse `json:"params"`
+}
+
+// EffectParamResponse represents a parameter of an effect and the values it accepts
+type EffectParamResponse struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Kind        string      `json:"kind"`
+	Min         *float64    `json:"min,omitempty"`
+	Max         *float64    `json:"max,omitempty"`
+	MaxItems    int         `json:"max_items,omitempty"`
+	Options     []string    `json:"options,omitempty"`
+	Default     interface{} `json:"default"`
+}
+
+// EffectCatalogResponse represents the effects boards can show
+type EffectCatalogResponse struct {
+	Version int              `json:"version"`
+	Effects []EffectResponse `json:"effects"`
+}
+
+// NewBoardEffectResponse creates an effect response, or nil when there is no effect
+func NewBoardEffectResponse(effect models.BoardEffect) *BoardEffectResponse {
+	if effect.IsZero() {
+		return nil
+	}
+	return &BoardEffectResponse{
+		Version: effect.Version,
+		Type:    effect.Type,
+		Params:  effect.Params,
+	}
+}
+
+// NewEffectCatalogResponse creates the response listing every effect definition
+func NewEffectCatalogResponse(definitions []models.EffectDefinition) EffectCatalogResponse {
+	effects := make([]EffectResponse, len(definitions))
+	for i, definition := range definitions {
+		params := make([]EffectParamResponse, len(definition.Params))
+		for j, param := range definition.Params {
+			params[j] = EffectParamResponse{
+				Name:        param.Name,
+				Description: param.Description,
+				Kind:        string(param.Kind),
+				MaxItems:    param.MaxItems,
+				Options:     param.Options,
+				Default:     param.Default,
+			}
+			if param.Kind == models.EffectParamInteger || param.Kind == models.EffectParamNumber {
+				min, max := param.Min, param.Max
+				params[j].Min = &min
+				params[j].Max = &max
+			}
+		}
+
+		effects[i] = EffectResponse{
+			Type:        definition.Type,
+			Name:        definition.Name,
+			Description: definition.Description,
+			Params:      params,
+		}
+	}
+
+	return EffectCatalogResponse{
+		Version: models.EffectSchemaVersion,
+		Effects: effects,
+	}
+}
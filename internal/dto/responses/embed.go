@@ -0,0 +1,102 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// OEmbedResponse represents a board as an oEmbed rich type
+type OEmbedResponse struct {
+	Type         string `json:"type"`
+	Version      string `json:"version"`
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name,omitempty"`
+	ProviderName string `json:"provider_name"`
+	ProviderURL  string `json:"provider_url"`
+	CacheAge     int    `json:"cache_age"`
+	HTML         string `json:"html"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+// EmbedBoardResponse represents the read-only view of a board shown in embeds.
+// It leaves out everything only contributors need, such as emails and settings.
+type EmbedBoardResponse struct {
+	Slug                 string               `json:"slug"`
+	Title                string               `json:"title"`
+	ReceiverName         string               `json:"receiver_name"`
+	URL                  string               `json:"url"`
+	FontName             string               `json:"font_name"`
+	FontSize             uint                 `json:"font_size"`
+	HeaderColor          string               `json:"header_color"`
+	ShowHeaderColor      bool                 `json:"show_header_color"`
+	Theme                *ThemeResponse       `json:"theme,omitempty"`
+	Effect               *BoardEffectResponse `json:"effect"`
+	Style                *BoardStyleResponse  `json:"style"`
+	EnableIntroAnimation bool                 `json:"enable_intro_animation"`
+	PostCount            int64                `json:"post_count"`
+	CreatedAt            time.Time            `json:"created_at"`
+}
+
+// EmbedPostResponse represents a post in the read-only view of a board
+type EmbedPostResponse struct {
+	ID              uint                    `json:"id"`
+	AuthorName      string                  `json:"author_name"`
+	Content         string                  `json:"content"`
+	ContentFormat   string                  `json:"content_format"`
+	ContentHTML     string                  `json:"content_html"`
+	BackgroundColor string                  `json:"background_color"`
+	TextColor       string                  `json:"text_color"`
+	MediaPath       string                  `json:"media_path"`
+	MediaType       string                  `json:"media_type"`
+	MediaSource     string                  `json:"media_source"`
+	LikesCount      int64                   `json:"likes_count"`
+	Reactions       []ReactionCountResponse `json:"reactions"`
+	CreatedAt       time.Time               `json:"created_at"`
+}
+
+// EmbedBoardPageResponse represents the read-only view of a board with a page of its posts
+type EmbedBoardPageResponse struct {
+	Board      EmbedBoardResponse  `json:"board"`
+	Posts      []EmbedPostResponse `json:"posts"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+	HasMore    bool                `json:"has_more"`
+}
+
+// NewEmbedBoardResponse creates the read-only view of a board
+func NewEmbedBoardResponse(board *models.Board, boardURL string, postCount int64) EmbedBoardResponse {
+	return EmbedBoardResponse{
+		Slug:                 board.Slug,
+		Title:                board.Title,
+		ReceiverName:         board.ReceiverName,
+		URL:                  boardURL,
+		FontName:             board.FontName,
+		FontSize:             board.FontSize,
+		HeaderColor:          board.HeaderColor,
+		ShowHeaderColor:      board.ShowHeaderColor,
+		Effect:               NewBoardEffectResponse(board.Effect),
+		Style:                NewBoardStyleResponse(board.Style),
+		EnableIntroAnimation: board.EnableIntroAnimation,
+		PostCount:            postCount,
+		CreatedAt:            board.CreatedAt,
+	}
+}
+
+// NewEmbedPostResponse creates the read-only view of a post
+func NewEmbedPostResponse(post *models.Post, likesCount int64, reactions []models.ReactionCount) EmbedPostResponse {
+	return EmbedPostResponse{
+		ID:              post.ID,
+		AuthorName:      post.AuthorName,
+		Content:         post.Content,
+		ContentFormat:   string(post.ContentFormat),
+		ContentHTML:     post.ContentHTML,
+		BackgroundColor: post.BackgroundColor,
+		TextColor:       post.TextColor,
+		MediaPath:       post.MediaPath,
+		MediaType:       post.MediaType,
+		MediaSource:     post.MediaSource,
+		LikesCount:      likesCount,
+		Reactions:       NewReactionCountResponses(reactions),
+		CreatedAt:       post.CreatedAt,
+	}
+}
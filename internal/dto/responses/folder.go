@@ -0,0 +1,30 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// FolderResponse represents a board folder in API responses
+type FolderResponse struct {
+	ID         uint      `json:"id"`
+	Name       string    `json:"name"`
+	Color      string    `json:"color"`
+	ParentID   *uint     `json:"parent_id"`
+	BoardCount int64     `json:"board_count"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// NewFolderResponse creates a new folder response from a folder model
+func NewFolderResponse(folder *models.BoardFolder, boardCount int64) FolderResponse {
+	return FolderResponse{
+		ID:         folder.ID,
+		Name:       folder.Name,
+		Color:      folder.Color,
+		ParentID:   folder.ParentID,
+		BoardCount: boardCount,
+		CreatedAt:  folder.CreatedAt,
+		UpdatedAt:  folder.UpdatedAt,
+	}
+}
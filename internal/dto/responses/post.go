@@ -7,38 +7,67 @@ import (
 
 // PostResponse represents a post in API responses
 type PostResponse struct {
-	ID              uint          `json:"id"`
-	BoardID         uint          `json:"board_id"`
-	Author          *UserResponse `json:"author,omitempty"`
-	AuthorName      string        `json:"author_name"`
-	Content         string        `json:"content"`
-	BackgroundColor string        `json:"background_color"`
-	TextColor       string        `json:"text_color"`
-	Position        int           `json:"position"`
-	MediaPath       string        `json:"media_path"`
-	MediaType       string        `json:"media_type"`
-	MediaSource     string        `json:"media_source"`
-	LikesCount      int           `json:"likes_count"`
-	CreatedAt       time.Time     `json:"created_at"`
-	UpdatedAt       time.Time     `json:"updated_at"`
+	ID               uint                    `json:"id"`
+	BoardID          uint                    `json:"board_id"`
+	SectionID        *uint                   `json:"section_id"`
+	Author           *UserResponse           `json:"author,omitempty"`
+	AuthorName       string                  `json:"author_name"`
+	Content          string                  `json:"content"`
+	ContentFormat    string                  `json:"content_format"`
+	ContentHTML      string                  `json:"content_html"`
+	BackgroundColor  string                  `json:"background_color"`
+	TextColor        string                  `json:"text_color"`
+	Position         int                     `json:"position"`
+	Rank             string                  `json:"rank"`
+	Layout           *PostLayoutResponse     `json:"layout"`
+	MediaPath        string                  `json:"media_path"`
+	MediaType        string                  `json:"media_type"`
+	MediaSource      string                  `json:"media_source"`
+	LikesCount       int                     `json:"likes_count"` // Every reaction, as reactions replaced likes
+	LikedByMe        bool                    `json:"liked_by_me"` // Whether the viewer reacted with a heart
+	Reactions        []ReactionCountResponse `json:"reactions"`
+	MyReactions      []string                `json:"my_reactions"`
+	CommentsCount    int64                   `json:"comments_count"`
+	Status           string                  `json:"status"`
+	ModerationReason string                  `json:"moderation_reason,omitempty"`
+	Edited           bool                    `json:"edited"`
+	EditedAt         *time.Time              `json:"edited_at,omitempty"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
 }
 
 // NewPostResponse creates a new post response from a post model
 func NewPostResponse(post *models.Post, author *models.User, likesCount int64) PostResponse {
 	response := PostResponse{
-		ID:              post.ID,
-		BoardID:         post.BoardID,
-		AuthorName:      post.AuthorName,
-		Content:         post.Content,
-		BackgroundColor: post.BackgroundColor,
-		TextColor:       post.TextColor,
-		Position:        post.Position,
-		MediaPath:       post.MediaPath,
-		MediaType:       post.MediaType,
-		MediaSource:     post.MediaSource,
-		LikesCount:      int(likesCount),
-		CreatedAt:       post.CreatedAt,
-		UpdatedAt:       post.UpdatedAt,
+		ID:               post.ID,
+		BoardID:          post.BoardID,
+		SectionID:        post.SectionID,
+		AuthorName:       post.AuthorName,
+		Content:          post.Content,
+		ContentFormat:    string(post.ContentFormat),
+		ContentHTML:      post.ContentHTML,
+		BackgroundColor:  post.BackgroundColor,
+		TextColor:        post.TextColor,
+		Position:         post.Position,
+		Rank:             post.Rank,
+		MediaPath:        post.MediaPath,
+		MediaType:        post.MediaType,
+		MediaSource:      post.MediaSource,
+		LikesCount:       int(likesCount),
+		Reactions:        []ReactionCountResponse{},
+		MyReactions:      []string{},
+		Status:           string(post.Status),
+		ModerationReason: post.ModerationReason,
+		Edited:           post.EditedAt != nil,
+		EditedAt:         post.EditedAt,
+		CreatedAt:        post.CreatedAt,
+		UpdatedAt:        post.UpdatedAt,
+	}
+
+	// Include the canvas layout once the post has been placed
+	if post.Layout.Version > 0 {
+		layout := NewPostLayoutResponse(post.Layout)
+		response.Layout = &layout
 	}
 
 	// Include author details if not anonymous
@@ -49,3 +78,78 @@ func NewPostResponse(post *models.Post, author *models.User, likesCount int64) P
 
 	return response
 }
+
+// PostLayoutResponse represents where a post sits on a canvas board
+type PostLayoutResponse struct {
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Width    float64 `json:"width"`
+	Height   float64 `json:"height"`
+	Rotation float64 `json:"rotation"`
+	ZIndex   int     `json:"z_index"`
+	Version  int64   `json:"version"`
+}
+
+// NewPostLayoutResponse creates a new post layout response from a post layout
+func NewPostLayoutResponse(layout models.PostLayout) PostLayoutResponse {
+	return PostLayoutResponse{
+		X:        layout.X,
+		Y:        layout.Y,
+		Width:    layout.Width,
+		Height:   layout.Height,
+		Rotation: layout.Rotation,
+		ZIndex:   layout.ZIndex,
+		Version:  layout.Version,
+	}
+}
+
+// PostLayoutResultResponse represents the outcome of a layout change. When a newer change
+// has already been stored, Applied is false and Layout is the stored layout.
+type PostLayoutResultResponse struct {
+	PostID  uint               `json:"post_id"`
+	Applied bool               `json:"applied"`
+	Layout  PostLayoutResponse `json:"layout"`
+}
+
+// PostPageResponse represents one page of a board's posts
+type PostPageResponse struct {
+	Posts      []PostResponse `json:"posts"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	HasMore    bool           `json:"has_more"`
+}
+
+// PostRevisionResponse represents an earlier version of a post in API responses
+type PostRevisionResponse struct {
+	ID            uint          `json:"id"`
+	PostID        uint          `json:"post_id"`
+	Editor        *UserResponse `json:"editor"`
+	Content       string        `json:"content"`
+	ContentFormat string        `json:"content_format"`
+	ContentHTML   string        `json:"content_html"`
+	MediaPath     string        `json:"media_path"`
+	MediaType     string        `json:"media_type"`
+	MediaSource   string        `json:"media_source"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// NewPostRevisionResponse creates a new revision response from a revision model
+func NewPostRevisionResponse(revision *models.PostRevision, editor *models.User) PostRevisionResponse {
+	response := PostRevisionResponse{
+		ID:            revision.ID,
+		PostID:        revision.PostID,
+		Content:       revision.Content,
+		ContentFormat: string(revision.ContentFormat),
+		ContentHTML:   revision.ContentHTML,
+		MediaPath:     revision.MediaPath,
+		MediaType:     revision.MediaType,
+		MediaSource:   revision.MediaSource,
+		CreatedAt:     revision.CreatedAt,
+	}
+
+	if editor != nil {
+		editorResponse := NewUserResponse(editor)
+		response.Editor = &editorResponse
+	}
+
+	return response
+}
@@ -0,0 +1,22 @@
+package responses
+
+// OpenGraphResponse represents the social metadata of a board, for the og: and twitter:
+// meta tags of the board page
+type OpenGraphResponse struct {
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	URL         string               `json:"url"`
+	SiteName    string               `json:"site_name"`
+	Type        string               `json:"type"`
+	TwitterCard string               `json:"twitter_card"`
+	Image       *OpenGraphImageField `json:"image"`
+}
+
+// OpenGraphImageField represents the preview image in social metadata
+type OpenGraphImageField struct {
+	URL    string `json:"url"`
+	Type   string `json:"type"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Alt    string `json:"alt"`
+}
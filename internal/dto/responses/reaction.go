@@ -0,0 +1,59 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// ReactionCountResponse represents how many users reacted to a post with an emoji
+type ReactionCountResponse struct {
+	Emoji string `json:"emoji"`
+	Count int64  `json:"count"`
+}
+
+// PostReactionsResponse represents the reactions to a post after a reaction changed
+type PostReactionsResponse struct {
+	PostID      uint                    `json:"post_id"`
+	Reactions   []ReactionCountResponse `json:"reactions"`
+	MyReactions []string                `json:"my_reactions"`
+	LikesCount  int64                   `json:"likes_count"`
+}
+
+// ReactionEmojiResponse represents an emoji posts can be reacted with. Standard emoji have
+// the emoji character and custom emoji an image.
+type ReactionEmojiResponse struct {
+	ID       uint   `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Emoji    string `json:"emoji,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	Custom   bool   `json:"custom"`
+}
+
+// ReactorResponse represents a user who reacted to a post
+type ReactorResponse struct {
+	User      *UserResponse `json:"user"`
+	Emoji     string        `json:"emoji"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// NewReactionCountResponses creates the responses of a post's reaction counts
+func NewReactionCountResponses(counts []models.ReactionCount) []ReactionCountResponse {
+	responses := make([]ReactionCountResponse, len(counts))
+	for i, count := range counts {
+		responses[i] = ReactionCountResponse{
+			Emoji: count.Emoji,
+			Count: count.Count,
+		}
+	}
+	return responses
+}
+
+// NewCustomEmojiResponse creates a reaction emoji response from a custom emoji
+func NewCustomEmojiResponse(emoji *models.CustomEmoji) ReactionEmojiResponse {
+	return ReactionEmojiResponse{
+		ID:       emoji.ID,
+		Name:     emoji.Name,
+		ImageURL: emoji.ImageURL,
+		Custom:   true,
+	}
+}
@@ -0,0 +1,96 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// ReportResponse represents an abuse report in the admin queue
+type ReportResponse struct {
+	ID              uint                    `json:"id"`
+	TargetType      models.ReportTargetType `json:"target_type"`
+	TargetID        uint                    `json:"target_id"`
+	BoardID         uint                    `json:"board_id"`
+	ReporterID      *uint                   `json:"reporter_id"`
+	Reason          models.ReportReason     `json:"reason"`
+	Details         string                  `json:"details"`
+	Status          models.ReportStatus     `json:"status"`
+	Action          models.ReportAction     `json:"action,omitempty"`
+	ResolutionNote  string                  `json:"resolution_note,omitempty"`
+	ResolvedByID    *uint                   `json:"resolved_by_id,omitempty"`
+	ResolvedAt      *time.Time              `json:"resolved_at,omitempty"`
+	OpenReportCount int64                   `json:"open_report_count"`
+	Board           *ReportedBoardResponse  `json:"board"`
+	Post            *ReportedPostResponse   `json:"post,omitempty"`
+	CreatedAt       time.Time               `json:"created_at"`
+}
+
+// ReportedBoardResponse summarizes the board a report is about or was made on
+type ReportedBoardResponse struct {
+	ID        uint   `json:"id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	CreatorID uint   `json:"creator_id"`
+	IsLocked  bool   `json:"is_locked"`
+	IsHidden  bool   `json:"is_hidden"`
+	IsDeleted bool   `json:"is_deleted"`
+}
+
+// ReportedPostResponse summarizes a reported post
+type ReportedPostResponse struct {
+	ID         uint              `json:"id"`
+	AuthorID   *uint             `json:"author_id"`
+	AuthorName string            `json:"author_name"`
+	Content    string            `json:"content"`
+	MediaPath  string            `json:"media_path"`
+	MediaType  string            `json:"media_type"`
+	Status     models.PostStatus `json:"status"`
+	IsDeleted  bool              `json:"is_deleted"`
+}
+
+// NewReportResponse creates a new report response from a report and the reported content
+func NewReportResponse(report *models.Report, board *models.Board, post *models.Post, openReportCount int64) ReportResponse {
+	response := ReportResponse{
+		ID:              report.ID,
+		TargetType:      report.TargetType,
+		TargetID:        report.TargetID,
+		BoardID:         report.BoardID,
+		ReporterID:      report.ReporterID,
+		Reason:          report.Reason,
+		Details:         report.Details,
+		Status:          report.Status,
+		Action:          report.Action,
+		ResolutionNote:  report.ResolutionNote,
+		ResolvedByID:    report.ResolvedByID,
+		ResolvedAt:      report.ResolvedAt,
+		OpenReportCount: openReportCount,
+		CreatedAt:       report.CreatedAt,
+	}
+
+	if board != nil {
+		response.Board = &ReportedBoardResponse{
+			ID:        board.ID,
+			Title:     board.Title,
+			Slug:      board.Slug,
+			CreatorID: board.CreatorID,
+			IsLocked:  board.IsLocked,
+			IsHidden:  board.HiddenAt != nil,
+			IsDeleted: board.DeletedAt.Valid,
+		}
+	}
+
+	if post != nil {
+		response.Post = &ReportedPostResponse{
+			ID:         post.ID,
+			AuthorID:   post.AuthorID,
+			AuthorName: post.AuthorName,
+			Content:    post.Content,
+			MediaPath:  post.MediaPath,
+			MediaType:  post.MediaType,
+			Status:     post.Status,
+			IsDeleted:  post.DeletedAt.Valid,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,34 @@
+package responses
+
+import "time"
+
+// BoardSearchResultResponse represents a board matching a search.
+// Highlights are HTML-escaped with the matching words wrapped in <mark> tags.
+type BoardSearchResultResponse struct {
+	ID                    uint      `json:"id"`
+	Title                 string    `json:"title"`
+	Slug                  string    `json:"slug"`
+	ReceiverName          string    `json:"receiver_name"`
+	IsPrivate             bool      `json:"is_private"`
+	CreatorID             uint      `json:"creator_id"`
+	CreatedAt             time.Time `json:"created_at"`
+	Rank                  float64   `json:"rank"`
+	TitleHighlight        string    `json:"title_highlight"`
+	ReceiverNameHighlight string    `json:"receiver_name_highlight"`
+}
+
+// PostSearchResultResponse represents a post matching a search.
+// The snippet is HTML-escaped with the matching words wrapped in <mark> tags.
+type PostSearchResultResponse struct {
+	PostResponse
+	Board   SearchResultBoardResponse `json:"board"`
+	Rank    float64                   `json:"rank"`
+	Snippet string                    `json:"snippet"`
+}
+
+// SearchResultBoardResponse identifies the board a matching post is on
+type SearchResultBoardResponse struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
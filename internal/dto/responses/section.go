@@ -0,0 +1,40 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// SectionResponse represents a board section in API responses
+type SectionResponse struct {
+	ID        uint      `json:"id"`
+	BoardID   uint      `json:"board_id"`
+	Title     string    `json:"title"`
+	Color     string    `json:"color"`
+	Position  int       `json:"position"`
+	IsDefault bool      `json:"is_default"`
+	PostCount int64     `json:"post_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewSectionResponse creates a new section response from a section model
+func NewSectionResponse(section *models.BoardSection, postCount int64) SectionResponse {
+	return SectionResponse{
+		ID:        section.ID,
+		BoardID:   section.BoardID,
+		Title:     section.Title,
+		Color:     section.Color,
+		Position:  section.Position,
+		IsDefault: section.IsDefault,
+		PostCount: postCount,
+		CreatedAt: section.CreatedAt,
+		UpdatedAt: section.UpdatedAt,
+	}
+}
+
+// SectionPostsResponse represents a board section with its posts
+type SectionPostsResponse struct {
+	SectionResponse
+	Posts []PostResponse `json:"posts"`
+}
@@ -0,0 +1,93 @@
+package responses
+
+import "kudoboard-api/internal/models"
+
+// BoardStyleResponse represents the background and card defaults of a board in API responses
+type BoardStyleResponse struct {
+	Background *BoardBackgroundResponse `json:"background"`
+	Card       *CardStyleResponse       `json:"card"`
+}
+
+// BoardBackgroundResponse represents the background of a board in API responses
+type BoardBackgroundResponse struct {
+	Type           string                      `json:"type"`
+	Color          string                      `json:"color,omitempty"`
+	Gradient       *BackgroundGradientResponse `json:"gradient,omitempty"`
+	Image          *BackgroundImageResponse    `json:"image,omitempty"`
+	Blur           int                         `json:"blur"`
+	OverlayColor   string                      `json:"overlay_color"`
+	OverlayOpacity float64                     `json:"overlay_opacity"`
+}
+
+// BackgroundGradientResponse represents a gradient background in API responses
+type BackgroundGradientResponse struct {
+	Kind   string   `json:"kind"`
+	Angle  int      `json:"angle"`
+	Colors []string `json:"colors"`
+}
+
+// BackgroundImageResponse represents an image background in API responses. Unsplash photos
+// include what is needed to credit their author.
+type BackgroundImageResponse struct {
+	Source     string `json:"source"`
+	URL        string `json:"url"`
+	Color      string `json:"color,omitempty"`
+	UnsplashID string `json:"unsplash_id,omitempty"`
+	AuthorName string `json:"author_name,omitempty"`
+	AuthorURL  string `json:"author_url,omitempty"`
+	PhotoURL   string `json:"photo_url,omitempty"`
+}
+
+// CardStyleResponse represents the default look of a board's posts in API responses
+type CardStyleResponse struct {
+	CornerRadius    int    `json:"corner_radius"`
+	Shadow          string `json:"shadow"`
+	BackgroundColor string `json:"background_color"`
+	TextColor       string `json:"text_color"`
+}
+
+// NewBoardStyleResponse creates a style response, or nil when the board has no style
+func NewBoardStyleResponse(style models.BoardStyle) *BoardStyleResponse {
+	if style.IsZero() {
+		return nil
+	}
+
+	response := &BoardStyleResponse{}
+	if background := style.Background; background != nil {
+		response.Background = &BoardBackgroundResponse{
+			Type:           background.Type,
+			Color:          background.Color,
+			Blur:           background.Blur,
+			OverlayColor:   background.OverlayColor,
+			OverlayOpacity: background.OverlayOpacity,
+		}
+		if gradient := background.Gradient; gradient != nil {
+			response.Background.Gradient = &BackgroundGradientResponse{
+				Kind:   gradient.Kind,
+				Angle:  gradient.Angle,
+				Colors: gradient.Colors,
+			}
+		}
+		if image := background.Image; image != nil {
+			response.Background.Image = &BackgroundImageResponse{
+				Source:     image.Source,
+				URL:        image.URL,
+				Color:      image.Color,
+				UnsplashID: image.UnsplashID,
+				AuthorName: image.AuthorName,
+				AuthorURL:  image.AuthorURL,
+				PhotoURL:   image.PhotoURL,
+			}
+		}
+	}
+	if card := style.Card; card != nil {
+		response.Card = &CardStyleResponse{
+			CornerRadius:    card.CornerRadius,
+			Shadow:          card.Shadow,
+			BackgroundColor: card.BackgroundColor,
+			TextColor:       card.TextColor,
+		}
+	}
+
+	return response
+}
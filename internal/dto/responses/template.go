@@ -0,0 +1,89 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// TemplateResponse represents a board template in API responses
+type TemplateResponse struct {
+	ID                   uint                   `json:"id"`
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description"`
+	Scope                string                 `json:"scope"`
+	CreatorID            uint                   `json:"creator_id"`
+	TitleFormat          string                 `json:"title_format"`
+	FontName             string                 `json:"font_name"`
+	FontSize             uint                   `json:"font_size"`
+	HeaderColor          string                 `json:"header_color"`
+	ShowHeaderColor      bool                   `json:"show_header_color"`
+	ThemeID              *uint                  `json:"theme_id,omitempty"`
+	Effect               *BoardEffectResponse   `json:"effect"`
+	Style                *BoardStyleResponse    `json:"style"`
+	EnableIntroAnimation bool                   `json:"enable_intro_animation"`
+	IsPrivate            bool                   `json:"is_private"`
+	AllowAnonymous       bool                   `json:"allow_anonymous"`
+	EnableReminders      bool                   `json:"enable_reminders"`
+	RequireApproval      bool                   `json:"require_approval"`
+	Posts                []TemplatePostResponse `json:"posts"`
+	CreatedAt            time.Time              `json:"created_at"`
+	UpdatedAt            time.Time              `json:"updated_at"`
+}
+
+// TemplatePostResponse represents a seeded prompt post of a template in API responses
+type TemplatePostResponse struct {
+	ID              uint   `json:"id"`
+	AuthorName      string `json:"author_name"`
+	Content         string `json:"content"`
+	ContentFormat   string `json:"content_format"`
+	MediaPath       string `json:"media_path"`
+	MediaType       string `json:"media_type"`
+	MediaSource     string `json:"media_source"`
+	BackgroundColor string `json:"background_color"`
+	TextColor       string `json:"text_color"`
+	Position        int    `json:"position"`
+}
+
+// NewTemplateResponse creates a new template response from a template model
+func NewTemplateResponse(template *models.BoardTemplate) TemplateResponse {
+	response := TemplateResponse{
+		ID:                   template.ID,
+		Name:                 template.Name,
+		Description:          template.Description,
+		Scope:                string(template.Scope),
+		CreatorID:            template.CreatorID,
+		TitleFormat:          template.TitleFormat,
+		FontName:             template.FontName,
+		FontSize:             template.FontSize,
+		HeaderColor:          template.HeaderColor,
+		ShowHeaderColor:      template.ShowHeaderColor,
+		ThemeID:              template.ThemeID,
+		Effect:               NewBoardEffectResponse(template.Effect),
+		Style:                NewBoardStyleResponse(template.Style),
+		EnableIntroAnimation: template.EnableIntroAnimation,
+		IsPrivate:            template.IsPrivate,
+		AllowAnonymous:       template.AllowAnonymous,
+		EnableReminders:      template.EnableReminders,
+		RequireApproval:      template.RequireApproval,
+		Posts:                make([]TemplatePostResponse, len(template.Posts)),
+		CreatedAt:            template.CreatedAt,
+		UpdatedAt:            template.UpdatedAt,
+	}
+
+	for i, post := range template.Posts {
+		response.Posts[i] = TemplatePostResponse{
+			ID:              post.ID,
+			AuthorName:      post.AuthorName,
+			Content:         post.Content,
+			ContentFormat:   string(post.ContentFormat),
+			MediaPath:       post.MediaPath,
+			MediaType:       post.MediaType,
+			MediaSource:     post.MediaSource,
+			BackgroundColor: post.BackgroundColor,
+			TextColor:       post.TextColor,
+			Position:        post.Position,
+		}
+	}
+
+	return response
+}
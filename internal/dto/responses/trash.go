@@ -0,0 +1,64 @@
+package responses
+
+import (
+	"kudoboard-api/internal/models"
+	"time"
+)
+
+// TrashResponse lists the deleted boards and posts a user can restore
+type TrashResponse struct {
+	Boards []TrashedBoardResponse `json:"boards"`
+	Posts  []TrashedPostResponse  `json:"posts"`
+}
+
+// TrashedBoardResponse represents a deleted board in API responses
+type TrashedBoardResponse struct {
+	ID           uint      `json:"id"`
+	Title        string    `json:"title"`
+	ReceiverName string    `json:"receiver_name"`
+	Slug         string    `json:"slug"`
+	PostCount    int64     `json:"post_count"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	PurgeAt      time.Time `json:"purge_at"`
+}
+
+// TrashedPostResponse represents a deleted post in API responses
+type TrashedPostResponse struct {
+	ID         uint      `json:"id"`
+	BoardID    uint      `json:"board_id"`
+	BoardTitle string    `json:"board_title"`
+	AuthorName string    `json:"author_name"`
+	Content    string    `json:"content"`
+	MediaPath  string    `json:"media_path"`
+	MediaType  string    `json:"media_type"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAt    time.Time `json:"purge_at"`
+}
+
+// NewTrashedBoardResponse creates a new trashed board response from a board model
+func NewTrashedBoardResponse(board *models.Board, postCount int64, purgeAt time.Time) TrashedBoardResponse {
+	return TrashedBoardResponse{
+		ID:           board.ID,
+		Title:        board.Title,
+		ReceiverName: board.ReceiverName,
+		Slug:         board.Slug,
+		PostCount:    postCount,
+		DeletedAt:    board.DeletedAt.Time,
+		PurgeAt:      purgeAt,
+	}
+}
+
+// NewTrashedPostResponse creates a new trashed post response from a post model
+func NewTrashedPostResponse(post *models.Post, boardTitle string, purgeAt time.Time) TrashedPostResponse {
+	return TrashedPostResponse{
+		ID:         post.ID,
+		BoardID:    post.BoardID,
+		BoardTitle: boardTitle,
+		AuthorName: post.AuthorName,
+		Content:    post.Content,
+		MediaPath:  post.MediaPath,
+		MediaType:  post.MediaType,
+		DeletedAt:  post.DeletedAt.Time,
+		PurgeAt:    purgeAt,
+	}
+}
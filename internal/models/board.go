@@ -3,6 +3,15 @@ package models
 import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"time"
+)
+
+// BoardLayoutMode defines how a board's posts are laid out
+type BoardLayoutMode string
+
+const (
+	BoardLayoutGrid   BoardLayoutMode = "grid"   // Posts flow in order of their position
+	BoardLayoutCanvas BoardLayoutMode = "canvas" // Posts are placed freely with their layout
 )
 
 // Board represents a kudoboard where users can post messages
@@ -10,7 +19,7 @@ type Board struct {
 	gorm.Model
 	Title                string `gorm:"not null"`
 	ReceiverName         string `gorm:"not null"`
-	Slug                 string `gorm:"uniqueIndex;not null"`
+	Slug                 string `gorm:"uniqueIndex:idx_boards_active_slug,where:deleted_at IS NULL;not null"`
 	MaxPost              uint   `gorm:"default:10"`
 	CreatorID            uint   `gorm:"not null"`
 	FontName             string `gorm:"not null"`
@@ -18,11 +27,18 @@ type Board struct {
 	HeaderColor          string `gorm:"default:'#ffffff'"`
 	ShowHeaderColor      bool   `gorm:"default:true"`
 	ThemeID              *uint
-	Effect               string `gorm:"type:jsonb"`
-	EnableIntroAnimation bool   `gorm:"default:false"`
-	IsPrivate            bool   `gorm:"default:false"`
-	IsLocked             bool   `gorm:"default:false"`
-	AllowAnonymous       bool   `gorm:"default:true"`
+	Effect               BoardEffect `gorm:"type:jsonb"`
+	Style                BoardStyle  `gorm:"type:jsonb"`
+	EnableIntroAnimation bool        `gorm:"default:false"`
+	IsPrivate            bool        `gorm:"default:false"`
+	IsLocked             bool        `gorm:"default:false"`
+	AllowAnonymous       bool        `gorm:"default:true"`
+	DeliveryAt           *time.Time
+	EnableReminders      bool            `gorm:"default:true"`
+	RequireApproval      bool            `gorm:"default:false"`
+	AllowEmbed           bool            `gorm:"default:true"`
+	LayoutMode           BoardLayoutMode `gorm:"type:varchar(20);default:'grid'"`
+	HiddenAt             *time.Time      // Set while the board is hidden after abuse reports
 }
 
 // BeforeCreate hook to generate a unique slug for new boards
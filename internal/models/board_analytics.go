@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// BoardView records one visitor opening a board on a given day. VisitorKey is the user ID
+// for signed-in visitors, or a hash of the IP address and user agent salted with the day,
+// so anonymous visitors can't be followed from one day to the next.
+type BoardView struct {
+	ID          uint      `gorm:"primaryKey"`
+	BoardID     uint      `gorm:"not null;uniqueIndex:idx_board_views_visitor_day"`
+	Day         time.Time `gorm:"type:date;not null;uniqueIndex:idx_board_views_visitor_day;index"`
+	VisitorKey  string    `gorm:"type:varchar(80);not null;uniqueIndex:idx_board_views_visitor_day"`
+	UserID      *uint     `gorm:"index"`
+	ViewCount   int       `gorm:"not null;default:1"`
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
+
+// BoardDailyStat is the daily rollup of a board's views and activity
+type BoardDailyStat struct {
+	BoardID         uint      `gorm:"primaryKey"`
+	Day             time.Time `gorm:"type:date;primaryKey"`
+	Views           int64     `gorm:"not null;default:0"`
+	UniqueVisitors  int64     `gorm:"not null;default:0"`
+	NewPosts        int64     `gorm:"not null;default:0"`
+	NewLikes        int64     `gorm:"not null;default:0"`
+	NewContributors int64     `gorm:"not null;default:0"`
+	UpdatedAt       time.Time
+}
@@ -8,15 +8,17 @@ type Role string
 const (
 	RoleViewer      Role = "viewer"
 	RoleContributor Role = "contributor"
+	RoleModerator   Role = "moderator"
 	RoleAdmin       Role = "admin"
 )
 
 // BoardContributor represents a user who has access to a board
 type BoardContributor struct {
-	BoardID    uint `gorm:"primaryKey"`
-	UserID     uint `gorm:"primaryKey"`
-	Role       Role `gorm:"type:varchar(20);default:'viewer'"`
-	IsFavorite bool `gorm:"default:false"`
-	IsArchived bool `gorm:"default:false"`
+	BoardID    uint  `gorm:"primaryKey"`
+	UserID     uint  `gorm:"primaryKey"`
+	Role       Role  `gorm:"type:varchar(20);default:'viewer'"`
+	IsFavorite bool  `gorm:"default:false"`
+	IsArchived bool  `gorm:"default:false"`
+	FolderID   *uint `gorm:"index"`
 	CreatedAt  time.Time
 }
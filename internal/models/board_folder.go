@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// BoardFolder is a user-defined folder for organizing boards on the user's dashboard.
+// Folders can be nested by setting ParentID.
+type BoardFolder struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	ParentID  *uint  `gorm:"index"`
+	Name      string `gorm:"not null"`
+	Color     string `gorm:"default:'#9e9e9e'"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// BoardInvitation represents a pending board invitation for an email without an account.
+// It is turned into a BoardContributor when a user signs up with that email.
+type BoardInvitation struct {
+	ID          uint   `gorm:"primaryKey"`
+	BoardID     uint   `gorm:"not null;uniqueIndex:idx_board_invitations_board_email"`
+	Email       string `gorm:"not null;uniqueIndex:idx_board_invitations_board_email"`
+	Role        Role   `gorm:"type:varchar(20);default:'viewer'"`
+	InvitedByID uint   `gorm:"not null"`
+	CreatedAt   time.Time
+}
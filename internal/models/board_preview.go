@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// BoardPreview is the cached social preview image of a board. Fingerprint identifies the
+// board content the image was drawn from, so a changed board gets a new image.
+type BoardPreview struct {
+	BoardID     uint   `gorm:"primaryKey"`
+	ImageURL    string `gorm:"not null"`
+	Fingerprint string `gorm:"type:varchar(64);not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// BoardSection groups the posts of a board under a heading. Posts without a section
+// belong to the board's default section, which is created with the board's first section.
+type BoardSection struct {
+	ID        uint   `gorm:"primaryKey"`
+	BoardID   uint   `gorm:"not null;index;uniqueIndex:idx_board_sections_default,where:is_default"`
+	Title     string `gorm:"not null"`
+	Color     string
+	Position  int  `gorm:"default:0"`
+	IsDefault bool `gorm:"default:false"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
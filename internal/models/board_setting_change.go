@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// BoardSettingChange records a single board setting changed by an update.
+// All fields changed by the same update share a version number.
+// Values are stored JSON-encoded so they can be restored with their original type.
+type BoardSettingChange struct {
+	ID        uint   `gorm:"primaryKey"`
+	BoardID   uint   `gorm:"not null;uniqueIndex:idx_board_setting_changes_version_field"`
+	Version   int    `gorm:"not null;uniqueIndex:idx_board_setting_changes_version_field"`
+	Field     string `gorm:"not null;uniqueIndex:idx_board_setting_changes_version_field"`
+	OldValue  string `gorm:"type:text"`
+	NewValue  string `gorm:"type:text"`
+	ActorID   uint   `gorm:"not null"`
+	CreatedAt time.Time
+}
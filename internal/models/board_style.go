@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Background types of a board style
+const (
+	BackgroundTypeColor    = "color"
+	BackgroundTypeGradient = "gradient"
+	BackgroundTypeImage    = "image"
+)
+
+// Sources of a background image
+const (
+	BackgroundSourceUpload   = "upload"
+	BackgroundSourceUnsplash = "unsplash"
+)
+
+// BoardStyle is how a board looks beyond its theme, stored as JSON. Without a background
+// the theme's background is shown.
+type BoardStyle struct {
+	Background *BoardBackground `json:"background,omitempty"`
+	Card       *CardStyle       `json:"card,omitempty"`
+}
+
+// BoardBackground is a solid color, gradient or image behind a board's posts, with an
+// optional blur and a translucent overlay to keep posts readable
+type BoardBackground struct {
+	Type           string              `json:"type"`
+	Color          string              `json:"color,omitempty"`
+	Gradient       *BackgroundGradient `json:"gradient,omitempty"`
+	Image          *BackgroundImage    `json:"image,omitempty"`
+	Blur           int                 `json:"blur"`
+	OverlayColor   string              `json:"overlay_color"`
+	OverlayOpacity float64             `json:"overlay_opacity"`
+}
+
+// BackgroundGradient is a linear or radial gradient through a few colors
+type BackgroundGradient struct {
+	Kind   string   `json:"kind"`
+	Angle  int      `json:"angle"`
+	Colors []string `json:"colors"`
+}
+
+// BackgroundImage is an uploaded image or an Unsplash photo. Unsplash photos keep what
+// their attribution needs.
+type BackgroundImage struct {
+	Source     string `json:"source"`
+	URL        string `json:"url"`
+	Color      string `json:"color,omitempty"` // Shown while the image loads
+	UnsplashID string `json:"unsplash_id,omitempty"`
+	AuthorName string `json:"author_name,omitempty"`
+	AuthorURL  string `json:"author_url,omitempty"`
+	PhotoURL   string `json:"photo_url,omitempty"`
+}
+
+// CardStyle is how the posts of a board look unless a post sets its own colors
+type CardStyle struct {
+	CornerRadius    int    `json:"corner_radius"`
+	Shadow          string `json:"shadow"`
+	BackgroundColor string `json:"background_color"`
+	TextColor       string `json:"text_color"`
+}
+
+// IsZero reports whether the style changes nothing about the board
+func (s BoardStyle) IsZero() bool {
+	return s.Background == nil && s.Card == nil
+}
+
+// Value stores the style as JSON, or as NULL when there is no style
+func (s BoardStyle) Value() (driver.Value, error) {
+	if s.IsZero() {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads a style stored as JSON
+func (s *BoardStyle) Scan(value interface{}) error {
+	*s = BoardStyle{}
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("unsupported style value of type %T", value)
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import "gorm.io/gorm"
+
+// TemplateScope defines who can use a board template
+type TemplateScope string
+
+const (
+	TemplateScopePersonal TemplateScope = "personal"
+	TemplateScopeOrg      TemplateScope = "org"
+)
+
+// BoardTemplate represents reusable board settings saved from an existing board
+type BoardTemplate struct {
+	gorm.Model
+	Name                 string `gorm:"not null"`
+	Description          string
+	Scope                TemplateScope `gorm:"type:varchar(20);default:'personal'"`
+	CreatorID            uint          `gorm:"not null;index"`
+	TitleFormat          string        `gorm:"not null"`
+	FontName             string        `gorm:"not null"`
+	FontSize             uint          `gorm:"not null;default:14"`
+	HeaderColor          string        `gorm:"default:'#ffffff'"`
+	ShowHeaderColor      bool
+	ThemeID              *uint
+	Effect               BoardEffect `gorm:"type:jsonb"`
+	Style                BoardStyle  `gorm:"type:jsonb"`
+	EnableIntroAnimation bool
+	IsPrivate            bool
+	AllowAnonymous       bool
+	EnableReminders      bool
+	RequireApproval      bool
+	Posts                []BoardTemplatePost `gorm:"foreignKey:TemplateID"`
+}
+
+// BoardTemplatePost represents a prompt post seeded into boards created from a template
+type BoardTemplatePost struct {
+	ID              uint              `gorm:"primaryKey"`
+	TemplateID      uint              `gorm:"not null;index"`
+	AuthorName      string            `gorm:"not null"`
+	Content         string            `gorm:"not null"`
+	ContentFormat   PostContentFormat `gorm:"type:varchar(20);not null;default:'plain'"`
+	MediaPath       string
+	MediaType       string
+	MediaSource     string
+	BackgroundColor string `gorm:"default:'#ffffff'"`
+	TextColor       string `gorm:"default:'#000000'"`
+	Position        int    `gorm:"default:0"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// ContentFilterAction defines what happens to text that matches a content filter list
+type ContentFilterAction string
+
+// Content filter actions, from least to most severe
+const (
+	ContentFilterActionMask     ContentFilterAction = "mask"
+	ContentFilterActionModerate ContentFilterAction = "moderate"
+	ContentFilterActionReject   ContentFilterAction = "reject"
+)
+
+// ContentFilterList is a list of blocked words or phrases. Lists without a board apply
+// site-wide; board lists only apply to that board's posts and settings.
+type ContentFilterList struct {
+	ID          uint                `gorm:"primaryKey"`
+	BoardID     *uint               `gorm:"index"`
+	Name        string              `gorm:"not null"`
+	Action      ContentFilterAction `gorm:"type:varchar(20);not null"`
+	Words       string              `gorm:"type:text"` // One word or phrase per line
+	IsEnabled   bool                `gorm:"default:true"`
+	CreatedByID uint                `gorm:"not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ContentFilterEvent records text that was caught by a content filter list
+type ContentFilterEvent struct {
+	ID           uint                `gorm:"primaryKey"`
+	ListID       uint                `gorm:"not null;index"`
+	BoardID      *uint               `gorm:"index"`
+	UserID       *uint               `gorm:"index"`
+	Field        string              `gorm:"type:varchar(50);not null"`
+	Action       ContentFilterAction `gorm:"type:varchar(20);not null"`
+	MatchedWords string              `gorm:"type:text"`
+	Excerpt      string              `gorm:"type:text"`
+	CreatedAt    time.Time           `gorm:"index"`
+}
@@ -0,0 +1,324 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// EffectSchemaVersion is the version of the effect schema new effects are saved with
+const EffectSchemaVersion = 1
+
+// EffectParamKind defines what kind of value an effect parameter takes
+type EffectParamKind string
+
+const (
+	EffectParamInteger EffectParamKind = "integer"
+	EffectParamNumber  EffectParamKind = "number"
+	EffectParamBoolean EffectParamKind = "boolean"
+	EffectParamColor   EffectParamKind = "color"
+	EffectParamColors  EffectParamKind = "colors"
+	EffectParamEnum    EffectParamKind = "enum"
+)
+
+// EffectParam describes a parameter of an effect and the values it accepts
+type EffectParam struct {
+	Name        string
+	Description string
+	Kind        EffectParamKind
+	Min         float64 // For integer and number parameters
+	Max         float64 // For integer and number parameters
+	MaxItems    int     // For colors parameters
+	Options     []string
+	Default     interface{}
+}
+
+// EffectDefinition describes an effect boards can show
+type EffectDefinition struct {
+	Type        string
+	Name        string
+	Description string
+	Params      []EffectParam
+}
+
+// defaultEffectColors is the palette of effects with several colors
+var defaultEffectColors = []string{"#ff595e", "#ffca3a", "#8ac926", "#1982c4", "#6a4c93"}
+
+// speedParam is shared by the effects that move
+var speedParam = EffectParam{
+	Name:        "speed",
+	Description: "Animation speed, 1 is normal",
+	Kind:        EffectParamNumber,
+	Min:         0.25,
+	Max:         3,
+	Default:     1.0,
+}
+
+// durationParam is shared by the effects that stop after a while
+func durationParam(defaultSeconds int) EffectParam {
+	return EffectParam{
+		Name:        "duration_seconds",
+		Description: "How long the effect plays after the board opens, 0 plays it forever",
+		Kind:        EffectParamInteger,
+		Min:         0,
+		Max:         60,
+		Default:     defaultSeconds,
+	}
+}
+
+// colorsParam is shared by the effects with several colors
+var colorsParam = EffectParam{
+	Name:        "colors",
+	Description: "Colors to pick from, as hex codes",
+	Kind:        EffectParamColors,
+	MaxItems:    8,
+	Default:     defaultEffectColors,
+}
+
+// EffectDefinitions lists the effects boards can show
+var EffectDefinitions = []EffectDefinition{
+	{
+		Type:        "confetti",
+		Name:        "Confetti",
+		Description: "Confetti bursting over the board",
+		Params: []EffectParam{
+			{Name: "particle_count", Description: "Pieces of confetti on screen", Kind: EffectParamInteger, Min: 20, Max: 500, Default: 150},
+			speedParam,
+			colorsParam,
+			durationParam(8),
+		},
+	},
+	{
+		Type:        "snow",
+		Name:        "Snow",
+		Description: "Snowflakes drifting down the board",
+		Params: []EffectParam{
+			{Name: "flake_count", Description: "Snowflakes on screen", Kind: EffectParamInteger, Min: 10, Max: 400, Default: 120},
+			speedParam,
+			{Name: "wind", Description: "Sideways drift, negative blows left", Kind: EffectParamNumber, Min: -1, Max: 1, Default: 0.0},
+			{Name: "color", Description: "Snowflake color, as a hex code", Kind: EffectParamColor, Default: "#ffffff"},
+		},
+	},
+	{
+		Type:        "balloons",
+		Name:        "Balloons",
+		Description: "Balloons floating up the board",
+		Params: []EffectParam{
+			{Name: "balloon_count", Description: "Balloons on screen", Kind: EffectParamInteger, Min: 1, Max: 50, Default: 12},
+			speedParam,
+			colorsParam,
+			durationParam(10),
+		},
+	},
+	{
+		Type:        "fireworks",
+		Name:        "Fireworks",
+		Description: "Fireworks bursting behind the posts",
+		Params: []EffectParam{
+			{Name: "bursts_per_second", Description: "How often a firework bursts", Kind: EffectParamNumber, Min: 0.2, Max: 5, Default: 1.0},
+			colorsParam,
+			durationParam(10),
+		},
+	},
+	{
+		Type:        "hearts",
+		Name:        "Hearts",
+		Description: "Hearts floating up the board",
+		Params: []EffectParam{
+			{Name: "heart_count", Description: "Hearts on screen", Kind: EffectParamInteger, Min: 5, Max: 200, Default: 40},
+			speedParam,
+			{Name: "color", Description: "Heart color, as a hex code", Kind: EffectParamColor, Default: "#e63946"},
+		},
+	},
+	{
+		Type:        "sparkles",
+		Name:        "Sparkles",
+		Description: "Sparkles twinkling across the board",
+		Params: []EffectParam{
+			{Name: "sparkle_count", Description: "Sparkles on screen", Kind: EffectParamInteger, Min: 10, Max: 300, Default: 80},
+			{Name: "color", Description: "Sparkle color, as a hex code", Kind: EffectParamColor, Default: "#ffd700"},
+			{Name: "size", Description: "Sparkle size", Kind: EffectParamEnum, Options: []string{"small", "medium", "large"}, Default: "medium"},
+		},
+	},
+}
+
+// FindEffectDefinition finds the definition of an effect type
+func FindEffectDefinition(effectType string) (*EffectDefinition, bool) {
+	for i := range EffectDefinitions {
+		if EffectDefinitions[i].Type == effectType {
+			return &EffectDefinitions[i], true
+		}
+	}
+	return nil, false
+}
+
+// hexColorPattern matches colors like #fff or #ffffff
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Normalize checks a value of the parameter and returns it in its stored form.
+// The error message describes the values the parameter accepts.
+func (p EffectParam) Normalize(value interface{}) (interface{}, error) {
+	switch p.Kind {
+	case EffectParamInteger:
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) || number < p.Min || number > p.Max {
+			return nil, fmt.Errorf("must be a whole number from %v to %v", p.Min, p.Max)
+		}
+		return int(number), nil
+	case EffectParamNumber:
+		number, ok := value.(float64)
+		if !ok || number < p.Min || number > p.Max {
+			return nil, fmt.Errorf("must be a number from %v to %v", p.Min, p.Max)
+		}
+		return number, nil
+	case EffectParamBoolean:
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("must be true or false")
+		}
+		return flag, nil
+	case EffectParamColor:
+		color, ok := NormalizeHexColor(value)
+		if !ok {
+			return nil, fmt.Errorf("must be a hex color like #ff0000")
+		}
+		return color, nil
+	case EffectParamColors:
+		items, ok := value.([]interface{})
+		if !ok || len(items) == 0 || len(items) > p.MaxItems {
+			return nil, fmt.Errorf("must be a list of 1 to %d hex colors", p.MaxItems)
+		}
+		colors := make([]string, len(items))
+		for i, item := range items {
+			color, ok := NormalizeHexColor(item)
+			if !ok {
+				return nil, fmt.Errorf("must be a list of 1 to %d hex colors", p.MaxItems)
+			}
+			colors[i] = color
+		}
+		return colors, nil
+	case EffectParamEnum:
+		option, ok := value.(string)
+		if ok {
+			for _, allowed := range p.Options {
+				if option == allowed {
+					return option, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("must be one of %s", strings.Join(p.Options, ", "))
+	}
+	return nil, fmt.Errorf("has an unknown kind")
+}
+
+// NormalizeHexColor lowercases a hex color and expands the short form
+func NormalizeHexColor(value interface{}) (string, bool) {
+	color, ok := value.(string)
+	if !ok || !hexColorPattern.MatchString(color) {
+		return "", false
+	}
+	color = strings.ToLower(color)
+	if len(color) == 4 {
+		color = "#" + strings.Repeat(color[1:2], 2) + strings.Repeat(color[2:3], 2) + strings.Repeat(color[3:4], 2)
+	}
+	return color, true
+}
+
+// BoardEffect is the animated effect of a board or template, stored as versioned JSON.
+// An empty type means there is no effect.
+type BoardEffect struct {
+	Version int                    `json:"version"`
+	Type    string                 `json:"type"`
+	Params  map[string]interface{} `json:"params"`
+}
+
+// IsZero reports whether there is no effect
+func (e BoardEffect) IsZero() bool {
+	return e.Type == ""
+}
+
+// Value stores the effect as JSON, or as NULL when there is no effect
+func (e BoardEffect) Value() (driver.Value, error) {
+	if e.IsZero() {
+		return nil, nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads an effect stored as JSON. Effects saved before the schema existed are
+// read as the closest effect of the current schema.
+func (e *BoardEffect) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*e = BoardEffect{}
+	case []byte:
+		*e = UpgradeEffect(v)
+	case string:
+		*e = UpgradeEffect([]byte(v))
+	default:
+		return fmt.Errorf("unsupported effect value of type %T", value)
+	}
+	return nil
+}
+
+// UpgradeEffect reads an effect saved by any version of the app, including the bare effect
+// names and free-form objects saved before the schema existed. Unknown effects become no
+// effect, and invalid parameters fall back to their defaults.
+func UpgradeEffect(data []byte) BoardEffect {
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return BoardEffect{}
+	}
+
+	var effectType string
+	params := map[string]interface{}{}
+	switch v := decoded.(type) {
+	case string:
+		// Effects were saved as JSON-encoded strings, which hold either a name or more JSON
+		trimmed := strings.TrimSpace(v)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "\"") {
+			return UpgradeEffect([]byte(trimmed))
+		}
+		effectType = trimmed
+	case map[string]interface{}:
+		for _, key := range []string{"type", "name", "effect"} {
+			if name, ok := v[key].(string); ok {
+				effectType = name
+				break
+			}
+		}
+		if nested, ok := v["params"].(map[string]interface{}); ok {
+			params = nested
+		} else {
+			params = v
+		}
+	}
+
+	definition, exists := FindEffectDefinition(strings.ToLower(strings.TrimSpace(effectType)))
+	if !exists {
+		return BoardEffect{}
+	}
+
+	effect := BoardEffect{
+		Version: EffectSchemaVersion,
+		Type:    definition.Type,
+		Params:  make(map[string]interface{}, len(definition.Params)),
+	}
+	for _, param := range definition.Params {
+		effect.Params[param.Name] = param.Default
+		if value, provided := params[param.Name]; provided {
+			if normalized, err := param.Normalize(value); err == nil {
+				effect.Params[param.Name] = normalized
+			}
+		}
+	}
+
+	return effect
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ExpectedContributor represents a person an organizer expects to sign a board.
+// Completion is derived from whether a user with this email has posted on the board.
+type ExpectedContributor struct {
+	ID             uint   `gorm:"primaryKey"`
+	BoardID        uint   `gorm:"not null;uniqueIndex:idx_expected_contributors_board_email"`
+	Email          string `gorm:"not null;uniqueIndex:idx_expected_contributors_board_email"`
+	Name           string
+	ReminderCount  int `gorm:"default:0"`
+	LastRemindedAt *time.Time
+	OptedOut       bool   `gorm:"default:false"`
+	OptOutToken    string `gorm:"uniqueIndex;not null"`
+	CreatedAt      time.Time
+}
@@ -1,18 +1,63 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// PostStatus defines where a post is in the moderation workflow
+type PostStatus string
+
+const (
+	PostStatusApproved PostStatus = "approved"
+	PostStatusPending  PostStatus = "pending"
+	PostStatusRejected PostStatus = "rejected"
+	PostStatusHidden   PostStatus = "hidden" // Hidden after abuse reports, only site admins can restore it
+)
+
+// PostContentFormat is how the content of a post is written
+type PostContentFormat string
+
+const (
+	PostContentPlain    PostContentFormat = "plain"    // Shown exactly as written
+	PostContentMarkdown PostContentFormat = "markdown" // A subset of Markdown, see utils.RenderMarkdown
+)
 
 // Post represents a message on a kudoboard
 type Post struct {
 	gorm.Model
-	BoardID         uint `gorm:"not null"`
-	AuthorID        *uint
-	AuthorName      string `gorm:"not null"`
-	Content         string `gorm:"not null"`
-	MediaPath       string
-	MediaType       string
-	MediaSource     string
-	BackgroundColor string `gorm:"default:'#ffffff'"`
-	TextColor       string `gorm:"default:'#000000'"`
-	Position        int    `gorm:"default:0"`
+	BoardID          uint  `gorm:"not null;index:idx_posts_board_rank"`
+	SectionID        *uint `gorm:"index"` // Nil for posts in the board's default section
+	AuthorID         *uint
+	AuthorName       string            `gorm:"not null"`
+	Content          string            `gorm:"not null"`
+	ContentFormat    PostContentFormat `gorm:"type:varchar(20);not null;default:'plain'"`
+	ContentHTML      string            `gorm:"type:text;not null;default:''"` // Content rendered in its format and sanitized
+	MediaPath        string
+	MediaType        string
+	MediaSource      string
+	BackgroundColor  string     `gorm:"default:'#ffffff'"`
+	TextColor        string     `gorm:"default:'#000000'"`
+	Position         int        `gorm:"default:0"`
+	Rank             string     `gorm:"type:varchar(64) COLLATE \"C\";not null;default:'';index:idx_posts_board_rank"` // Orders the posts of a board, see utils.RankBetween
+	Layout           PostLayout `gorm:"embedded;embeddedPrefix:layout_"`
+	Status           PostStatus `gorm:"type:varchar(20);default:'approved';index"`
+	ModerationReason string
+	ModeratedByID    *uint
+	ModeratedAt      *time.Time
+	EditedAt         *time.Time // Set when an edit changes the content or media, see PostRevision
+	EditedByID       *uint
+}
+
+// PostLayout is where a post sits on a canvas board, in canvas units from the top left
+// corner. Version is stamped on every change so concurrent moves resolve to the last
+// writer; zero means the post hasn't been placed yet.
+type PostLayout struct {
+	X        float64 `gorm:"not null;default:0"`
+	Y        float64 `gorm:"not null;default:0"`
+	Width    float64 `gorm:"not null;default:0"`
+	Height   float64 `gorm:"not null;default:0"`
+	Rotation float64 `gorm:"not null;default:0"`
+	ZIndex   int     `gorm:"not null;default:0"`
+	Version  int64   `gorm:"not null;default:0"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PostComment is a comment on a post. Comments are threaded one level deep: a comment
+// either replies to the post or to a top-level comment, never to a reply.
+type PostComment struct {
+	ID         uint   `gorm:"primarykey"`
+	PostID     uint   `gorm:"not null;index"`
+	BoardID    uint   `gorm:"not null;index"`
+	ParentID   *uint  `gorm:"index"` // Nil for top-level comments
+	AuthorID   *uint  // Nil for anonymous comments
+	AuthorName string `gorm:"not null"`
+	Content    string `gorm:"type:text;not null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	EditedAt   *time.Time // Set when the author changes the content
+}
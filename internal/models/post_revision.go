@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PostRevision is an earlier version of a post's content and media, saved when an edit
+// replaced it. EditorID is the user whose edit replaced it.
+type PostRevision struct {
+	ID            uint              `gorm:"primarykey"`
+	PostID        uint              `gorm:"not null;index"`
+	EditorID      uint              `gorm:"not null"`
+	Content       string            `gorm:"not null"`
+	ContentFormat PostContentFormat `gorm:"type:varchar(20);not null;default:'plain'"`
+	ContentHTML   string            `gorm:"type:text;not null;default:''"`
+	MediaPath     string
+	MediaType     string
+	MediaSource   string
+	CreatedAt     time.Time
+}
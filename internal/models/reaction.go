@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// ReactionHeart is the reaction that likes used to be
+const ReactionHeart = "heart"
+
+// PostReaction represents a user reacting to a post with an emoji. A user can react to a
+// post with several emoji, but only once with each.
+type PostReaction struct {
+	PostID    uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"primaryKey"`
+	Emoji     string `gorm:"primaryKey;type:varchar(64)"` // Shortcode of a standard or custom emoji
+	CreatedAt time.Time
+}
+
+// CustomEmoji represents an emoji image added by site admins that posts can be reacted with
+type CustomEmoji struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"type:varchar(32);not null;uniqueIndex"` // Shortcode without the colons
+	ImageURL  string `gorm:"not null"`
+	CreatorID uint   `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// ReactionCount is how many users reacted to a post with an emoji
+type ReactionCount struct {
+	Emoji string
+	Count int64
+}
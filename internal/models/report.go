@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// ReportTargetType defines what kind of content an abuse report is about
+type ReportTargetType string
+
+const (
+	ReportTargetPost  ReportTargetType = "post"
+	ReportTargetBoard ReportTargetType = "board"
+)
+
+// ReportReason is the category a reporter picks for an abuse report
+type ReportReason string
+
+const (
+	ReportReasonSpam          ReportReason = "spam"
+	ReportReasonHarassment    ReportReason = "harassment"
+	ReportReasonHateSpeech    ReportReason = "hate_speech"
+	ReportReasonSexualContent ReportReason = "sexual_content"
+	ReportReasonViolence      ReportReason = "violence"
+	ReportReasonPersonalInfo  ReportReason = "personal_info"
+	ReportReasonOther         ReportReason = "other"
+)
+
+// ReportStatus defines where an abuse report is in the review workflow
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusDismissed ReportStatus = "dismissed"
+	ReportStatusResolved  ReportStatus = "resolved"
+)
+
+// ReportAction is what a site admin did when resolving an abuse report
+type ReportAction string
+
+const (
+	ReportActionDismiss     ReportAction = "dismiss"
+	ReportActionHidePost    ReportAction = "hide_post"
+	ReportActionLockBoard   ReportAction = "lock_board"
+	ReportActionSuspendUser ReportAction = "suspend_user"
+)
+
+// Report is an abuse report about a post or a board. ReporterKey identifies the reporter,
+// either by user ID or by a hash of their IP address, so each reporter counts once per target.
+type Report struct {
+	ID             uint             `gorm:"primaryKey"`
+	TargetType     ReportTargetType `gorm:"type:varchar(20);not null;uniqueIndex:idx_reports_target_reporter"`
+	TargetID       uint             `gorm:"not null;uniqueIndex:idx_reports_target_reporter"`
+	ReporterKey    string           `gorm:"type:varchar(80);not null;uniqueIndex:idx_reports_target_reporter"`
+	BoardID        uint             `gorm:"not null;index"`
+	ReporterID     *uint            `gorm:"index"`
+	Reason         ReportReason     `gorm:"type:varchar(30);not null"`
+	Details        string           `gorm:"type:text"`
+	Status         ReportStatus     `gorm:"type:varchar(20);default:'open';index"`
+	Action         ReportAction     `gorm:"type:varchar(20)"`
+	ResolutionNote string           `gorm:"type:text"`
+	ResolvedByID   *uint
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"time"
 )
 
 // User represents a user in the system
@@ -13,10 +14,13 @@ type User struct {
 	Password       string `gorm:"not null"`
 	ProfilePicture string
 	IsVerified     bool    `gorm:"default:false"`
+	VerifyToken    *string `gorm:"uniqueIndex;default:null"` // Set until the email address is confirmed
 	IsAdmin        bool    `gorm:"default:false"`
 	GoogleID       *string `gorm:"uniqueIndex;default:null"`
 	FacebookID     *string `gorm:"uniqueIndex;default:null"`
 	AuthProvider   string  `gorm:"default:'local'"`
+	SuspendedAt    *time.Time
+	SuspendReason  string
 }
 
 // BeforeSave hook is called before saving a User to hash the password
@@ -0,0 +1,476 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"time"
+)
+
+// maxAnalyticsRange limits how many days a single analytics request can cover
+const maxAnalyticsRange = 366
+
+// dayFormat is the format of days in analytics keys and requests
+const dayFormat = "2006-01-02"
+
+// DailyBoardStats are a board's views and activity on one day, with running totals
+type DailyBoardStats struct {
+	Day               time.Time
+	Views             int64
+	UniqueVisitors    int64
+	NewPosts          int64
+	NewLikes          int64
+	NewContributors   int64
+	TotalPosts        int64
+	TotalLikes        int64
+	TotalContributors int64
+}
+
+// BoardAnalytics summarizes a board's views and activity over a range of days
+type BoardAnalytics struct {
+	From              time.Time
+	To                time.Time
+	Views             int64
+	UniqueVisitors    int64
+	NewPosts          int64
+	NewLikes          int64
+	NewContributors   int64
+	TotalPosts        int64
+	TotalLikes        int64
+	TotalContributors int64
+	Daily             []DailyBoardStats
+}
+
+// BoardViewer is a signed-in user who opened a board
+type BoardViewer struct {
+	User          models.User
+	FirstViewedAt time.Time
+	LastViewedAt  time.Time
+	VisitDays     int64
+	ViewCount     int64
+}
+
+// AnalyticsService records board views and reports views and activity over time
+type AnalyticsService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	boardService *BoardService
+}
+
+// NewAnalyticsService creates a new AnalyticsService
+func NewAnalyticsService(db *gorm.DB, cfg *config.Config, boardService *BoardService) *AnalyticsService {
+	return &AnalyticsService{
+		db:           db,
+		cfg:          cfg,
+		boardService: boardService,
+	}
+}
+
+// RecordView records a visitor opening a board. Each visitor is counted once per day,
+// however many times they open the board.
+func (s *AnalyticsService) RecordView(boardID, userID uint, clientIP, userAgent string) {
+	now := time.Now().UTC()
+	day := startOfDay(now)
+
+	view := models.BoardView{
+		BoardID:     boardID,
+		Day:         day,
+		VisitorKey:  s.visitorKey(userID, clientIP, userAgent, day),
+		ViewCount:   1,
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	}
+	if userID != 0 {
+		view.UserID = &userID
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "board_id"}, {Name: "day"}, {Name: "visitor_key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"view_count":   gorm.Expr("board_views.view_count + 1"),
+			"last_seen_at": now,
+		}),
+	}).Create(&view).Error; err != nil {
+		log.Warn("Failed to record board view",
+			zap.Uint("board_id", boardID),
+			zap.Error(err))
+	}
+}
+
+// visitorKey identifies a visitor for one day. Anonymous visitors are identified by a hash
+// that changes every day, so their visits can't be linked across days.
+func (s *AnalyticsService) visitorKey(userID uint, clientIP, userAgent string, day time.Time) string {
+	if userID != 0 {
+		return fmt.Sprintf("user:%d", userID)
+	}
+	sum := sha256.Sum256([]byte(s.cfg.JWTSecret + "|" + day.Format(dayFormat) + "|" + clientIP + "|" + userAgent))
+	return "anon:" + hex.EncodeToString(sum[:])
+}
+
+// GetBoardAnalytics returns a board's views and activity for each day from one day to another.
+// Past days come from the daily rollups; today is computed live.
+func (s *AnalyticsService) GetBoardAnalytics(boardID, userID uint, from, to time.Time) (*BoardAnalytics, error) {
+	board, err := s.boardService.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user can manage the board
+	if !s.boardService.IsBoardAdmin(board, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to view this board's analytics").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	from, to = startOfDay(from), startOfDay(to)
+	if from.After(to) {
+		return nil, utils.NewBadRequestError("The start of the range must be before its end").
+			WithField("from", from.Format(dayFormat)).
+			WithField("to", to.Format(dayFormat))
+	}
+	if to.Sub(from) >= maxAnalyticsRange*24*time.Hour {
+		return nil, utils.NewBadRequestError(fmt.Sprintf("The range can't be longer than %d days", maxAnalyticsRange))
+	}
+
+	// Load the rollups, then compute today live since it isn't finished yet
+	var rollups []models.BoardDailyStat
+	if err := s.db.Where("board_id = ? AND day BETWEEN ? AND ?", boardID, from, to).
+		Find(&rollups).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch board analytics", err).
+			WithField("board_id", boardID)
+	}
+
+	statsByDay := make(map[string]models.BoardDailyStat, len(rollups))
+	for _, rollup := range rollups {
+		statsByDay[rollup.Day.Format(dayFormat)] = rollup
+	}
+
+	today := startOfDay(time.Now())
+	if !today.Before(from) && !today.After(to) {
+		live, err := s.computeDailyStats(boardID, today, today)
+		if err != nil {
+			return nil, err
+		}
+		statsByDay[today.Format(dayFormat)] = live[boardID][today.Format(dayFormat)]
+	}
+
+	// Running totals start from everything created before the range
+	postsBefore, likesBefore, contributorsBefore, err := s.countActivity(boardID, &from)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &BoardAnalytics{From: from, To: to}
+	totalPosts, totalLikes, totalContributors := postsBefore, likesBefore, contributorsBefore
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		stat := statsByDay[day.Format(dayFormat)]
+		totalPosts += stat.NewPosts
+		totalLikes += stat.NewLikes
+		totalContributors += stat.NewContributors
+
+		analytics.Daily = append(analytics.Daily, DailyBoardStats{
+			Day:               day,
+			Views:             stat.Views,
+			UniqueVisitors:    stat.UniqueVisitors,
+			NewPosts:          stat.NewPosts,
+			NewLikes:          stat.NewLikes,
+			NewContributors:   stat.NewContributors,
+			TotalPosts:        totalPosts,
+			TotalLikes:        totalLikes,
+			TotalContributors: totalContributors,
+		})
+
+		analytics.Views += stat.Views
+		analytics.UniqueVisitors += stat.UniqueVisitors
+		analytics.NewPosts += stat.NewPosts
+		analytics.NewLikes += stat.NewLikes
+		analytics.NewContributors += stat.NewContributors
+	}
+
+	// Current totals
+	analytics.TotalPosts, analytics.TotalLikes, analytics.TotalContributors, err = s.countActivity(boardID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return analytics, nil
+}
+
+// GetBoardViewers lists the signed-in users who opened a private board, most recent first.
+// Only views within the analytics retention window are available.
+func (s *AnalyticsService) GetBoardViewers(boardID, userID uint) ([]BoardViewer, error) {
+	board, err := s.boardService.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user can manage the board
+	if !s.boardService.IsBoardAdmin(board, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to view this board's viewers").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	if !board.IsPrivate {
+		return nil, utils.NewBadRequestError("Viewer lists are only available for private boards").
+			WithField("board_id", boardID)
+	}
+
+	type viewerRow struct {
+		UserID        uint
+		FirstViewedAt time.Time
+		LastViewedAt  time.Time
+		VisitDays     int64
+		ViewCount     int64
+	}
+	var rows []viewerRow
+	if err := s.db.Model(&models.BoardView{}).
+		Select("user_id, MIN(first_seen_at) AS first_viewed_at, MAX(last_seen_at) AS last_viewed_at, "+
+			"COUNT(*) AS visit_days, SUM(view_count) AS view_count").
+		Where("board_id = ? AND user_id IS NOT NULL", boardID).
+		Group("user_id").
+		Order("last_viewed_at desc").
+		Scan(&rows).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch board viewers", err).
+			WithField("board_id", boardID)
+	}
+
+	userIDs := make([]uint, len(rows))
+	for i, row := range rows {
+		userIDs[i] = row.UserID
+	}
+
+	usersByID := make(map[uint]models.User, len(rows))
+	if len(userIDs) > 0 {
+		var users []models.User
+		if err := s.db.Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to fetch board viewers", err).
+				WithField("board_id", boardID)
+		}
+		for _, user := range users {
+			usersByID[user.ID] = user
+		}
+	}
+
+	viewers := make([]BoardViewer, 0, len(rows))
+	for _, row := range rows {
+		user, exists := usersByID[row.UserID]
+		if !exists {
+			continue
+		}
+		viewers = append(viewers, BoardViewer{
+			User:          user,
+			FirstViewedAt: row.FirstViewedAt,
+			LastViewedAt:  row.LastViewedAt,
+			VisitDays:     row.VisitDays,
+			ViewCount:     row.ViewCount,
+		})
+	}
+
+	return viewers, nil
+}
+
+// RollupDailyStats stores the daily rollups of every board with views or activity on a day.
+// Running it again for the same day replaces that day's rollups.
+func (s *AnalyticsService) RollupDailyStats(day time.Time) error {
+	day = startOfDay(day)
+
+	stats, err := s.computeDailyStats(0, day, day)
+	if err != nil {
+		return err
+	}
+
+	var rollups []models.BoardDailyStat
+	for _, boardStats := range stats {
+		for _, stat := range boardStats {
+			rollups = append(rollups, stat)
+		}
+	}
+	if len(rollups) == 0 {
+		return nil
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "board_id"}, {Name: "day"}},
+		DoUpdates: clause.AssignmentColumns([]string{"views", "unique_visitors", "new_posts", "new_likes", "new_contributors", "updated_at"}),
+	}).CreateInBatches(&rollups, 500).Error; err != nil {
+		return fmt.Errorf("failed to store daily board stats: %w", err)
+	}
+
+	log.Info("Board analytics rollup completed",
+		zap.String("day", day.Format(dayFormat)),
+		zap.Int("boards", len(rollups)))
+
+	return nil
+}
+
+// PruneViews removes individual board views older than the retention window.
+// Their daily rollups are kept.
+func (s *AnalyticsService) PruneViews() error {
+	cutoff := startOfDay(time.Now().Add(-s.cfg.AnalyticsRetention))
+
+	result := s.db.Where("day < ?", cutoff).Delete(&models.BoardView{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to prune board views: %w", result.Error)
+	}
+
+	log.Info("Board view pruning completed",
+		zap.Int64("pruned", result.RowsAffected))
+
+	return nil
+}
+
+// computeDailyStats computes views and activity per board and day from the raw tables.
+// A board ID of 0 computes the stats of every board.
+func (s *AnalyticsService) computeDailyStats(boardID uint, from, to time.Time) (map[uint]map[string]models.BoardDailyStat, error) {
+	end := to.AddDate(0, 0, 1)
+	stats := make(map[uint]map[string]models.BoardDailyStat)
+	stat := func(boardID uint, day time.Time) *models.BoardDailyStat {
+		if stats[boardID] == nil {
+			stats[boardID] = make(map[string]models.BoardDailyStat)
+		}
+		key := day.Format(dayFormat)
+		current, exists := stats[boardID][key]
+		if !exists {
+			current = models.BoardDailyStat{BoardID: boardID, Day: startOfDay(day)}
+		}
+		stats[boardID][key] = current
+		return &current
+	}
+	save := func(row *models.BoardDailyStat) {
+		stats[row.BoardID][row.Day.Format(dayFormat)] = *row
+	}
+
+	// Views and unique visitors
+	type viewRow struct {
+		BoardID        uint
+		Day            time.Time
+		Views          int64
+		UniqueVisitors int64
+	}
+	var views []viewRow
+	viewQuery := s.db.Model(&models.BoardView{}).
+		Select("board_id, day, SUM(view_count) AS views, COUNT(*) AS unique_visitors").
+		Where("day >= ? AND day < ?", from, end).
+		Group("board_id, day")
+	if boardID != 0 {
+		viewQuery = viewQuery.Where("board_id = ?", boardID)
+	}
+	if err := viewQuery.Scan(&views).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to compute board views", err)
+	}
+	for _, view := range views {
+		row := stat(view.BoardID, view.Day)
+		row.Views, row.UniqueVisitors = view.Views, view.UniqueVisitors
+		save(row)
+	}
+
+	// Published posts, likes and contributors added each day
+	type countRow struct {
+		BoardID uint
+		Day     time.Time
+		Count   int64
+	}
+	activity := []struct {
+		query *gorm.DB
+		apply func(*models.BoardDailyStat, int64)
+	}{
+		{
+			query: s.db.Model(&models.Post{}).
+				Select("board_id, DATE(created_at AT TIME ZONE 'UTC') AS day, COUNT(*) AS count").
+				Where("status = ? AND created_at >= ? AND created_at < ?", models.PostStatusApproved, from, end).
+				Group("board_id, day"),
+			apply: func(row *models.BoardDailyStat, count int64) { row.NewPosts = count },
+		},
+		{
+			query: s.db.Model(&models.PostReaction{}).
+				Select("posts.board_id, DATE(post_reactions.created_at AT TIME ZONE 'UTC') AS day, COUNT(*) AS count").
+				Joins("JOIN posts ON posts.id = post_reactions.post_id AND posts.deleted_at IS NULL").
+				Where("post_reactions.created_at >= ? AND post_reactions.created_at < ?", from, end).
+				Group("posts.board_id, day"),
+			apply: func(row *models.BoardDailyStat, count int64) { row.NewLikes = count },
+		},
+		{
+			query: s.db.Model(&models.BoardContributor{}).
+				Select("board_id, DATE(created_at AT TIME ZONE 'UTC') AS day, COUNT(*) AS count").
+				Where("created_at >= ? AND created_at < ?", from, end).
+				Group("board_id, day"),
+			apply: func(row *models.BoardDailyStat, count int64) { row.NewContributors = count },
+		},
+	}
+
+	for i, source := range activity {
+		query := source.query
+		if boardID != 0 {
+			if i == 1 {
+				query = query.Where("posts.board_id = ?", boardID)
+			} else {
+				query = query.Where("board_id = ?", boardID)
+			}
+		}
+
+		var counts []countRow
+		if err := query.Scan(&counts).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to compute board activity", err)
+		}
+		for _, count := range counts {
+			row := stat(count.BoardID, count.Day)
+			source.apply(row, count.Count)
+			save(row)
+		}
+	}
+
+	// Make sure the requested board has a row even without any activity
+	if boardID != 0 {
+		for day := from; day.Before(end); day = day.AddDate(0, 0, 1) {
+			save(stat(boardID, day))
+		}
+	}
+
+	return stats, nil
+}
+
+// countActivity counts a board's published posts, likes and contributors,
+// optionally only those added before a day
+func (s *AnalyticsService) countActivity(boardID uint, before *time.Time) (int64, int64, int64, error) {
+	var posts, likes, contributors int64
+
+	postQuery := s.db.Model(&models.Post{}).Where("board_id = ? AND status = ?", boardID, models.PostStatusApproved)
+	likeQuery := s.db.Model(&models.PostReaction{}).
+		Joins("JOIN posts ON posts.id = post_reactions.post_id AND posts.deleted_at IS NULL").
+		Where("posts.board_id = ?", boardID)
+	contributorQuery := s.db.Model(&models.BoardContributor{}).Where("board_id = ?", boardID)
+	if before != nil {
+		postQuery = postQuery.Where("created_at < ?", *before)
+		likeQuery = likeQuery.Where("post_reactions.created_at < ?", *before)
+		contributorQuery = contributorQuery.Where("created_at < ?", *before)
+	}
+
+	if err := postQuery.Count(&posts).Error; err != nil {
+		return 0, 0, 0, utils.NewInternalError("Failed to count board posts", err).
+			WithField("board_id", boardID)
+	}
+	if err := likeQuery.Count(&likes).Error; err != nil {
+		return 0, 0, 0, utils.NewInternalError("Failed to count board likes", err).
+			WithField("board_id", boardID)
+	}
+	if err := contributorQuery.Count(&contributors).Error; err != nil {
+		return 0, 0, 0, utils.NewInternalError("Failed to count board contributors", err).
+			WithField("board_id", boardID)
+	}
+
+	return posts, likes, contributors, nil
+}
+
+// startOfDay truncates a time to midnight UTC
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -3,30 +3,35 @@ package services
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"kudoboard-api/internal/config"
 	"kudoboard-api/internal/log"
 	"kudoboard-api/internal/models"
 	"kudoboard-api/internal/services/storage"
 	"kudoboard-api/internal/utils"
 	"net/http"
+	"strings"
 )
 
 // AuthService handles authentication logic
 type AuthService struct {
-	db         *gorm.DB
-	storage    storage.StorageService
-	cfg        *config.Config
-	httpClient *http.Client
+	db           *gorm.DB
+	storage      storage.StorageService
+	cfg          *config.Config
+	emailService *EmailService
+	httpClient   *http.Client
 }
 
 // NewAuthService creates a new AuthService
-func NewAuthService(db *gorm.DB, storage storage.StorageService, cfg *config.Config) *AuthService {
+func NewAuthService(db *gorm.DB, storage storage.StorageService, cfg *config.Config, emailService *EmailService) *AuthService {
 	return &AuthService{
-		db:      db,
-		storage: storage,
-		cfg:     cfg,
+		db:           db,
+		storage:      storage,
+		cfg:          cfg,
+		emailService: emailService,
 		httpClient: &http.Client{
 			Timeout: cfg.HTTPClientTimeout,
 		},
@@ -42,11 +47,13 @@ func (s *AuthService) RegisterUser(name, email, password string) (*models.User,
 			WithField("email", email)
 	}
 
-	// Create new user
+	// Create new user, unverified until the emailed link is opened
+	verifyToken := uuid.New().String()
 	user := models.User{
-		Name:     name,
-		Email:    email,
-		Password: password,
+		Name:        name,
+		Email:       email,
+		Password:    password,
+		VerifyToken: &verifyToken,
 	}
 
 	// Save user to database
@@ -56,6 +63,9 @@ func (s *AuthService) RegisterUser(name, email, password string) (*models.User,
 			WithField("name", name)
 	}
 
+	// Board invitations for this email are redeemed once it is verified
+	s.sendVerificationEmail(&user)
+
 	// Generate token
 	token, err := utils.GenerateToken(user.ID, s.cfg.JWTSecret, s.cfg.JWTExpiresIn)
 	if err != nil {
@@ -84,6 +94,11 @@ func (s *AuthService) LoginUser(email, password string) (*models.User, string, e
 			WithField("error_type", "invalid_password")
 	}
 
+	// Suspended users can't sign in
+	if err := checkNotSuspended(&user); err != nil {
+		return nil, "", err
+	}
+
 	// Generate token
 	token, err := utils.GenerateToken(user.ID, s.cfg.JWTSecret, s.cfg.JWTExpiresIn)
 	if err != nil {
@@ -148,6 +163,8 @@ func (s *AuthService) GoogleLogin(accessToken string) (*models.User, string, err
 				WithField("email", tokenInfo.Email).
 				WithField("google_id", tokenInfo.Sub)
 		}
+
+		s.acceptBoardInvitations(&user)
 	} else {
 		// User exists, update Google ID and profile if needed
 		updates := false
@@ -172,6 +189,11 @@ func (s *AuthService) GoogleLogin(accessToken string) (*models.User, string, err
 		}
 	}
 
+	// Suspended users can't sign in
+	if err := checkNotSuspended(&user); err != nil {
+		return nil, "", err
+	}
+
 	// Generate JWT token
 	token, err := utils.GenerateToken(user.ID, s.cfg.JWTSecret, s.cfg.JWTExpiresIn)
 	if err != nil {
@@ -242,6 +264,8 @@ func (s *AuthService) FacebookLogin(accessToken string) (*models.User, string, e
 				WithField("email", fbUserInfo.Email).
 				WithField("facebook_id", fbUserInfo.ID)
 		}
+
+		s.acceptBoardInvitations(&user)
 	} else {
 		// User exists, update Facebook ID and profile if needed
 		updates := false
@@ -268,6 +292,11 @@ func (s *AuthService) FacebookLogin(accessToken string) (*models.User, string, e
 		}
 	}
 
+	// Suspended users can't sign in
+	if err := checkNotSuspended(&user); err != nil {
+		return nil, "", err
+	}
+
 	// Generate JWT token
 	token, err := utils.GenerateToken(user.ID, s.cfg.JWTSecret, s.cfg.JWTExpiresIn)
 	if err != nil {
@@ -278,6 +307,116 @@ func (s *AuthService) FacebookLogin(accessToken string) (*models.User, string, e
 	return &user, token, nil
 }
 
+// acceptBoardInvitations turns pending board invitations for the user's email into contributor records
+// Invitations are only redeemed for verified email addresses.
+func (s *AuthService) acceptBoardInvitations(user *models.User) {
+	if !user.IsVerified {
+		return
+	}
+
+	var invitations []models.BoardInvitation
+	if err := s.db.Where("email = ?", strings.ToLower(user.Email)).Find(&invitations).Error; err != nil {
+		log.Warn("Failed to fetch board invitations",
+			zap.Uint("user_id", user.ID),
+			zap.Error(err))
+		return
+	}
+
+	if len(invitations) == 0 {
+		return
+	}
+
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		for _, invitation := range invitations {
+			contributor := models.BoardContributor{
+				BoardID: invitation.BoardID,
+				UserID:  user.ID,
+				Role:    invitation.Role,
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&contributor).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&invitations).Error
+	})
+
+	if err != nil {
+		log.Warn("Failed to accept board invitations",
+			zap.Uint("user_id", user.ID),
+			zap.Error(err))
+	}
+}
+
+// VerifyEmail confirms the email address owning the token and redeems its board invitations
+func (s *AuthService) VerifyEmail(token string) (*models.User, error) {
+	var user models.User
+	if result := s.db.Where("verify_token = ?", token).First(&user); result.Error != nil {
+		return nil, utils.NewNotFoundError("Invalid verification token")
+	}
+
+	if err := s.db.Model(&user).Updates(map[string]interface{}{
+		"is_verified":  true,
+		"verify_token": nil,
+	}).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to verify email", err).
+			WithField("user_id", user.ID)
+	}
+	user.IsVerified = true
+	user.VerifyToken = nil
+
+	s.acceptBoardInvitations(&user)
+
+	return &user, nil
+}
+
+// ResendVerification emails a new verification link to a user whose email isn't verified yet
+func (s *AuthService) ResendVerification(userID uint) error {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsVerified {
+		return utils.NewBadRequestError("Email is already verified").
+			WithField("user_id", userID)
+	}
+
+	// Accounts created before verification existed don't have a token yet
+	if user.VerifyToken == nil {
+		verifyToken := uuid.New().String()
+		if err := s.db.Model(user).Update("verify_token", verifyToken).Error; err != nil {
+			return utils.NewInternalError("Failed to create verification token", err).
+				WithField("user_id", userID)
+		}
+		user.VerifyToken = &verifyToken
+	}
+
+	s.sendVerificationEmail(user)
+	return nil
+}
+
+// sendVerificationEmail sends a user the link that confirms their email address
+func (s *AuthService) sendVerificationEmail(user *models.User) {
+	subject := "Confirm your email address"
+	body := fmt.Sprintf(
+		"Hi %s,\n\n"+
+			"Please confirm your email address to finish setting up your account "+
+			"and get access to the boards you have been invited to:\n%s/verify-email?token=%s\n",
+		user.Name, s.cfg.ClientURL, *user.VerifyToken,
+	)
+	s.emailService.SendAsync(user.Email, subject, body)
+}
+
+// checkNotSuspended returns an error if a user's account has been suspended
+func checkNotSuspended(user *models.User) error {
+	if user.SuspendedAt != nil {
+		return utils.NewForbiddenError("This account has been suspended").
+			WithField("user_id", user.ID)
+	}
+	return nil
+}
+
 // GetUserByID gets a user by ID
 func (s *AuthService) GetUserByID(userID uint) (*models.User, error) {
 	var user models.User
@@ -1,35 +1,83 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
-	"go.uber.org/zap"
+	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"kudoboard-api/internal/config"
 	"kudoboard-api/internal/dto/requests"
-	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/dto/responses"
 	"kudoboard-api/internal/models"
 	"kudoboard-api/internal/services/storage"
 	"kudoboard-api/internal/utils"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// Bulk contributor row statuses
+const (
+	BulkStatusAdded          = "added"
+	BulkStatusAlreadyPresent = "already_present"
+	BulkStatusInvited        = "invited"
+	BulkStatusInvalid        = "invalid"
+	BulkStatusUpdated        = "updated"
+	BulkStatusRemoved        = "removed"
+	BulkStatusUnchanged      = "unchanged"
+	BulkStatusNotFound       = "not_found"
+)
+
+// maxBulkContributorRows limits the number of rows in a single bulk contributor operation
+const maxBulkContributorRows = 500
+
+// errDryRun is returned from a transaction to roll back the changes of a dry run
+var errDryRun = errors.New("dry run")
+
 // BoardService handles board-related business logic
 type BoardService struct {
-	db      *gorm.DB
-	storage storage.StorageService
-	cfg     *config.Config
+	db              *gorm.DB
+	storage         storage.StorageService
+	cfg             *config.Config
+	emailService    *EmailService
+	contentFilter   *ContentFilterService
+	unsplashService *UnsplashService
 }
 
 // NewBoardService creates a new BoardService
-func NewBoardService(db *gorm.DB, storage storage.StorageService, cfg *config.Config) *BoardService {
+func NewBoardService(db *gorm.DB, storage storage.StorageService, cfg *config.Config, emailService *EmailService, contentFilter *ContentFilterService, unsplashService *UnsplashService) *BoardService {
 	return &BoardService{
-		db:      db,
-		storage: storage,
-		cfg:     cfg,
+		db:              db,
+		storage:         storage,
+		cfg:             cfg,
+		emailService:    emailService,
+		contentFilter:   contentFilter,
+		unsplashService: unsplashService,
 	}
 }
 
 // CreateBoard creates a new board
 func (s *BoardService) CreateBoard(userID uint, input requests.CreateBoardRequest) (*models.Board, error) {
+	// Check the title and receiver name against the content filter
+	if err := s.filterBoardText(0, userID, &input.Title, &input.ReceiverName); err != nil {
+		return nil, err
+	}
+
+	// Validate the effect and fill in its defaults
+	effect, err := ParseBoardEffect(input.Effect)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the style and fill in its defaults
+	style, err := s.parseBoardStyle(input.Style, models.BoardStyle{})
+	if err != nil {
+		return nil, err
+	}
+
 	// Create new board
 	board := models.Board{
 		Title:                input.Title,
@@ -39,19 +87,40 @@ func (s *BoardService) CreateBoard(userID uint, input requests.CreateBoardReques
 		FontSize:             input.FontSize,
 		HeaderColor:          input.HeaderColor,
 		ThemeID:              input.ThemeID,
-		Effect:               input.Effect,
+		Effect:               effect,
+		Style:                style,
 		EnableIntroAnimation: input.EnableIntroAnimation,
 		IsPrivate:            input.IsPrivate,
 		AllowAnonymous:       input.AllowAnonymous,
+		DeliveryAt:           input.DeliveryAt,
+		EnableReminders:      true,
+		RequireApproval:      input.RequireApproval,
+		AllowEmbed:           true,
+		LayoutMode:           models.BoardLayoutGrid,
+	}
+	if input.LayoutMode != "" {
+		board.LayoutMode = models.BoardLayoutMode(input.LayoutMode)
 	}
 
 	// Use transaction to ensure both operations succeed or fail together
-	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+	err = utils.WithTransaction(s.db, func(tx *gorm.DB) error {
 		// Save board to database
 		if err := tx.Create(&board).Error; err != nil {
 			return utils.NewInternalError("Failed to create board", err)
 		}
 
+		// Zero values are replaced by column defaults on create, so disable reminders explicitly
+		if input.EnableReminders != nil && !*input.EnableReminders {
+			if err := tx.Model(&board).Update("enable_reminders", false).Error; err != nil {
+				return utils.NewInternalError("Failed to create board", err)
+			}
+		}
+		if input.AllowEmbed != nil && !*input.AllowEmbed {
+			if err := tx.Model(&board).Update("allow_embed", false).Error; err != nil {
+				return utils.NewInternalError("Failed to create board", err)
+			}
+		}
+
 		// Add creator as admin contributor
 		contributor := models.BoardContributor{
 			BoardID: board.ID,
@@ -84,8 +153,9 @@ func (s *BoardService) GetBoardByID(boardID uint) (*models.Board, error) {
 	return &board, nil
 }
 
-// GetBoardBySlug gets a board by slug
-func (s *BoardService) GetBoardBySlug(slug string) (*models.Board, *models.User, []models.Post, error) {
+// GetBoardBySlug gets a board by slug, with the posts the viewer is allowed to see if withPosts is set.
+// Pending and rejected posts are only visible to their author and to board moderators.
+func (s *BoardService) GetBoardBySlug(slug string, viewerID uint, withPosts bool) (*models.Board, *models.User, []models.Post, error) {
 	// Find board by slug
 	var board models.Board
 	if result := s.db.Where("slug = ?", slug).First(&board); result.Error != nil {
@@ -93,6 +163,12 @@ func (s *BoardService) GetBoardBySlug(slug string) (*models.Board, *models.User,
 			WithField("slug", slug)
 	}
 
+	// Boards hidden after abuse reports are only visible to the people managing them
+	if board.HiddenAt != nil && !s.IsBoardAdmin(&board, viewerID) {
+		return nil, nil, nil, utils.NewNotFoundError("Board not found").
+			WithField("slug", slug)
+	}
+
 	// Get board creator
 	var creator models.User
 	if result := s.db.First(&creator, board.CreatorID); result.Error != nil {
@@ -100,9 +176,15 @@ func (s *BoardService) GetBoardBySlug(slug string) (*models.Board, *models.User,
 			WithField("slug", slug)
 	}
 
+	if !withPosts {
+		return &board, &creator, nil, nil
+	}
+
 	// Get posts
+	query := s.scopeVisiblePosts(s.db.Where("board_id = ?", board.ID), &board, viewerID)
+
 	var posts []models.Post
-	if result := s.db.Where("board_id = ?", board.ID).Order("created_at desc").Find(&posts); result.Error != nil {
+	if result := query.Order("created_at desc").Find(&posts); result.Error != nil {
 		return nil, nil, nil, utils.NewInternalError("Unable to load board content", result.Error).
 			WithField("slug", slug)
 	}
@@ -110,8 +192,51 @@ func (s *BoardService) GetBoardBySlug(slug string) (*models.Board, *models.User,
 	return &board, &creator, posts, nil
 }
 
+// GetViewableBoard gets a board the viewer is allowed to open
+func (s *BoardService) GetViewableBoard(boardID, viewerID uint) (*models.Board, error) {
+	board, err := s.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Boards hidden after abuse reports are only visible to the people managing them
+	if board.HiddenAt != nil && !s.IsBoardAdmin(board, viewerID) {
+		return nil, utils.NewNotFoundError("Board not found").
+			WithField("board_id", boardID)
+	}
+
+	// Check if user can access the board
+	canAccess, err := s.CanAccessBoard(boardID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, utils.NewForbiddenError("You don't have access to this board").
+			WithField("board_id", boardID)
+	}
+
+	return board, nil
+}
+
+// scopeVisiblePosts limits a query of a board's posts to those the viewer is allowed to see
+func (s *BoardService) scopeVisiblePosts(query *gorm.DB, board *models.Board, viewerID uint) *gorm.DB {
+	if s.CanModerateBoard(board, viewerID) {
+		return query
+	}
+	if viewerID != 0 {
+		return query.Where("posts.status = ? OR posts.author_id = ?", models.PostStatusApproved, viewerID)
+	}
+	return query.Where("posts.status = ?", models.PostStatusApproved)
+}
+
 // UpdateBoard updates a board
 func (s *BoardService) UpdateBoard(boardID, userID uint, input requests.UpdateBoardRequest) (*models.Board, error) {
+	return s.updateBoard(boardID, userID, input, nil)
+}
+
+// updateBoard updates a board and records the changed settings as a new version.
+// Fields listed in clearFields are reset to null, which an update request can't express.
+func (s *BoardService) updateBoard(boardID, userID uint, input requests.UpdateBoardRequest, clearFields map[string]bool) (*models.Board, error) {
 	// Find board
 	var board models.Board
 	if result := s.db.First(&board, boardID); result.Error != nil {
@@ -132,6 +257,14 @@ func (s *BoardService) UpdateBoard(boardID, userID uint, input requests.UpdateBo
 			WithField("user_id", userID)
 	}
 
+	// Check the new title and receiver name against the content filter
+	if err := s.filterBoardText(board.ID, userID, input.Title, input.ReceiverName); err != nil {
+		return nil, err
+	}
+
+	// Remember the current settings to record what changed
+	before := boardSettings(&board)
+
 	// Update fields if provided
 	if input.Title != nil {
 		board.Title = *input.Title
@@ -155,7 +288,18 @@ func (s *BoardService) UpdateBoard(boardID, userID uint, input requests.UpdateBo
 		board.ThemeID = input.ThemeID
 	}
 	if input.Effect != nil {
-		board.Effect = *input.Effect
+		effect, err := ParseBoardEffect(*input.Effect)
+		if err != nil {
+			return nil, err
+		}
+		board.Effect = effect
+	}
+	if input.Style != nil {
+		style, err := s.parseBoardStyle(*input.Style, board.Style)
+		if err != nil {
+			return nil, err
+		}
+		board.Style = style
 	}
 	if input.EnableIntroAnimation != nil {
 		board.EnableIntroAnimation = *input.EnableIntroAnimation
@@ -166,17 +310,52 @@ func (s *BoardService) UpdateBoard(boardID, userID uint, input requests.UpdateBo
 	if input.AllowAnonymous != nil {
 		board.AllowAnonymous = *input.AllowAnonymous
 	}
+	if input.DeliveryAt != nil {
+		board.DeliveryAt = input.DeliveryAt
+	}
+	if input.EnableReminders != nil {
+		board.EnableReminders = *input.EnableReminders
+	}
+	if input.RequireApproval != nil {
+		board.RequireApproval = *input.RequireApproval
+	}
+	if input.AllowEmbed != nil {
+		board.AllowEmbed = *input.AllowEmbed
+	}
+	if input.LayoutMode != nil {
+		board.LayoutMode = models.BoardLayoutMode(*input.LayoutMode)
+	}
+	if clearFields["theme_id"] {
+		board.ThemeID = nil
+	}
+	if clearFields["delivery_at"] {
+		board.DeliveryAt = nil
+	}
+	if clearFields["effect"] {
+		board.Effect = models.BoardEffect{}
+	}
+	if clearFields["style"] {
+		board.Style = models.BoardStyle{}
+	}
 
-	// Save changes
-	if result := s.db.Save(&board); result.Error != nil {
-		return nil, utils.NewInternalError("Failed to update board", result.Error).
-			WithField("board_id", boardID)
+	// Save changes together with the change log
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Save(&board).Error; err != nil {
+			return utils.NewInternalError("Failed to update board", err).
+				WithField("board_id", boardID)
+		}
+
+		return s.recordSettingChanges(tx, board.ID, userID, before, boardSettings(&board))
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return &board, nil
 }
 
-// DeleteBoard deletes a board
+// DeleteBoard moves a board to the trash
 func (s *BoardService) DeleteBoard(boardID, userID uint) error {
 	// Find board
 	var board models.Board
@@ -197,35 +376,18 @@ func (s *BoardService) DeleteBoard(boardID, userID uint) error {
 			WithField("creator_id", board.CreatorID)
 	}
 
-	// Get all media for posts on this board to delete after transaction
-	var posts []models.Post
-	if err := s.db.Where("board_id = ?", boardID).Find(&posts).Error; err != nil {
-		return utils.NewInternalError("Failed to fetch board posts for media cleanup", err).
-			WithField("board_id", boardID)
-	}
-
-	// Use transaction for all database operations
+	// Move the board and its posts to the trash. Posts share the board's deletion time so
+	// that restoring the board brings back exactly the posts deleted with it.
+	// Media, reactions, comments and contributors are kept until the board is purged.
+	deletedAt := time.Now()
 	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
-		// Delete all associated posts likes
-		if err := tx.Exec("DELETE FROM post_likes WHERE post_id IN (SELECT id FROM posts WHERE board_id = ?)", boardID).Error; err != nil {
-			return utils.NewInternalError("Failed to delete board post likes", err).
-				WithField("board_id", boardID)
-		}
-
-		// Delete all associated posts
-		if err := tx.Where("board_id = ?", boardID).Delete(&models.Post{}).Error; err != nil {
+		if err := tx.Model(&models.Post{}).Where("board_id = ?", boardID).
+			Update("deleted_at", deletedAt).Error; err != nil {
 			return utils.NewInternalError("Failed to delete board posts", err).
 				WithField("board_id", boardID)
 		}
 
-		// Delete all associated contributors
-		if err := tx.Where("board_id = ?", boardID).Delete(&models.BoardContributor{}).Error; err != nil {
-			return utils.NewInternalError("Failed to delete board contributors", err).
-				WithField("board_id", boardID)
-		}
-
-		// Delete the board
-		if err := tx.Delete(&board).Error; err != nil {
+		if err := tx.Model(&board).Update("deleted_at", deletedAt).Error; err != nil {
 			return utils.NewInternalError("Failed to delete board", err).
 				WithField("board_id", boardID)
 		}
@@ -237,17 +399,6 @@ func (s *BoardService) DeleteBoard(boardID, userID uint) error {
 		return err
 	}
 
-	for _, post := range posts {
-		if post.MediaPath != "" && post.MediaSource == "internal" {
-			if err := s.storage.Delete(post.MediaPath); err != nil {
-				log.Warn("Failed to delete media",
-					zap.Uint("post_id", post.ID),
-					zap.String("file_path", post.MediaPath),
-					zap.Error(err))
-			}
-		}
-	}
-
 	return nil
 }
 
@@ -260,8 +411,8 @@ func (s *BoardService) ToggleBoardLock(boardID, userID uint, isLocked bool) (*mo
 			WithField("board_id", boardID)
 	}
 
-	// Check if user is the creator or admin
-	if board.CreatorID != userID {
+	// Check if user is the creator or admin. Site admins can lock any board to resolve abuse reports.
+	if board.CreatorID != userID && !s.isSiteAdmin(userID) {
 		// Check if user is a board admin
 		var contributor models.BoardContributor
 		result := s.db.Where("board_id = ? AND user_id = ? AND role = ?",
@@ -285,72 +436,132 @@ func (s *BoardService) ToggleBoardLock(boardID, userID uint, isLocked bool) (*mo
 }
 
 // ListUserBoards lists all boards where the user is owner or contributor
-func (s *BoardService) ListUserBoards(userID uint, page, perPage int, search, sortBy, order string) ([]struct {
+func (s *BoardService) ListUserBoards(userID uint, params requests.BoardQuery) ([]struct {
 	models.Board
 	IsOwner    bool
 	IsFavorite bool
 	IsArchived bool
+	FolderID   *uint
 	Creator    models.User
+	PostCount  int64
 }, int64, error) {
-	// Create a subquery to get all board IDs where user is a contributor
-	var contributorBoardIDs []uint
-	if err := s.db.Model(&models.BoardContributor{}).
-		Select("board_id").
-		Where("user_id = ?", userID).
-		Find(&contributorBoardIDs).Error; err != nil {
-		return nil, 0, utils.NewInternalError("Failed to fetch contributor boards", err).WithField("user_id", userID)
+	// Build main query to get all boards where user is creator OR contributor.
+	// Each board joins at most one contributor row for the user, so counts stay exact.
+	query := s.db.Model(&models.Board{}).
+		Joins("LEFT JOIN board_contributors ON board_contributors.board_id = boards.id AND board_contributors.user_id = ?", userID).
+		Where("boards.creator_id = ? OR board_contributors.user_id IS NOT NULL", userID).
+		Where("boards.hidden_at IS NULL OR boards.creator_id = ? OR board_contributors.role = ?", userID, models.RoleAdmin)
+
+	// Add full-text search over titles and receiver names if provided
+	searchQuery := strings.Join(searchTerms(params.Search), " & ")
+	if searchQuery != "" {
+		query = query.Where("boards.search_vector @@ "+boardSearchQuery, searchQuery)
+	}
+
+	// Filter by folder
+	switch params.FolderID {
+	case "":
+	case "none":
+		query = query.Where("board_contributors.folder_id IS NULL")
+	default:
+		folderID, err := strconv.ParseUint(params.FolderID, 10, 32)
+		if err != nil {
+			return nil, 0, utils.NewBadRequestError("Invalid folder ID").
+				WithField("folder_id", params.FolderID)
+		}
+
+		folderIDs := []uint{uint(folderID)}
+		if params.IncludeSubfolders {
+			folders, err := loadUserFolders(s.db, userID)
+			if err != nil {
+				return nil, 0, err
+			}
+			folderIDs = descendantFolderIDs(folders, uint(folderID))
+		}
+		query = query.Where("board_contributors.folder_id IN ?", folderIDs)
 	}
 
-	// Build main query to get all boards where user is creator OR contributor
-	query := s.db.Model(&models.Board{}).
-		Distinct().
-		Where("creator_id = ? OR id IN ?", userID, contributorBoardIDs)
+	// Filter by ownership: shared boards are owned boards with other contributors,
+	// received boards are boards someone else shared with the user
+	switch params.Ownership {
+	case "owned":
+		query = query.Where("boards.creator_id = ?", userID)
+	case "shared":
+		query = query.Where("boards.creator_id = ? AND EXISTS (SELECT 1 FROM board_contributors others WHERE others.board_id = boards.id AND others.user_id <> ?)", userID, userID)
+	case "received":
+		query = query.Where("boards.creator_id <> ?", userID)
+	}
 
-	// Add search if provided
-	if search != "" {
-		query = query.Where("title LIKE ? OR receiver_name LIKE ?", "%"+search+"%", "%"+search+"%")
+	// Filter by preferences
+	if params.IsFavorite != nil {
+		query = query.Where("COALESCE(board_contributors.is_favorite, false) = ?", *params.IsFavorite)
+	}
+	if params.IsArchived != nil {
+		query = query.Where("COALESCE(board_contributors.is_archived, false) = ?", *params.IsArchived)
 	}
 
 	// Count total boards
 	var total int64
-	query.Count(&total)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to count boards", err).
+			WithField("user_id", userID)
+	}
 
 	// Add pagination
-	offset := (page - 1) * perPage
-	query = query.Offset(offset).Limit(perPage)
-
-	// Add ordering
-	if sortBy == "" {
-		sortBy = "created_at"
-	}
-	if order == "" {
-		order = "desc"
+	offset := (params.Page - 1) * params.PerPage
+	query = query.Offset(offset).Limit(params.PerPage)
+
+	// Add ordering, searches are ranked by relevance unless a sort is requested
+	sortBy := params.SortBy
+	if sortBy == "" && searchQuery != "" {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(boards.search_vector, " + boardSearchQuery + ") desc, boards.created_at desc",
+			Vars: []interface{}{searchQuery},
+		}})
+	} else {
+		if sortBy == "" {
+			sortBy = "created_at"
+		}
+		order := params.Order
+		if order == "" {
+			order = "desc"
+		}
+		orderClause := "boards." + sortBy + " " + order
+		query = query.Order(orderClause)
 	}
-	orderClause := sortBy + " " + order
-	query = query.Order(orderClause)
 
 	// Execute query for boards
 	var boards []models.Board
-	if result := query.Find(&boards); result.Error != nil {
+	if result := query.Select("boards.*").Find(&boards); result.Error != nil {
 		return nil, 0, utils.NewInternalError("Failed to fetch boards", result.Error).
 			WithField("user_id", userID)
 	}
 
+	boardIDs := make([]uint, len(boards))
+	creatorIDs := make([]uint, len(boards))
+	for i, board := range boards {
+		boardIDs[i] = board.ID
+		creatorIDs[i] = board.CreatorID
+	}
+
 	// Get contributor info for these boards
 	var contributors []models.BoardContributor
-	if err := s.db.Where("user_id = ? AND board_id IN ?", userID,
-		func() []uint {
-			ids := make([]uint, len(boards))
-			for i, b := range boards {
-				ids[i] = b.ID
-			}
-			return ids
-		}()).
+	if err := s.db.Where("user_id = ? AND board_id IN ?", userID, boardIDs).
 		Find(&contributors).Error; err != nil {
 		return nil, 0, utils.NewInternalError("Failed to fetch board contributors", err).
 			WithField("user_id", userID)
 	}
 
+	// Get the creators and post counts of all boards at once
+	creators, err := loadUsersByID(s.db, creatorIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	postCounts, err := s.countApprovedPosts(boardIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Create a map for quick lookup of contributor info
 	contributorMap := make(map[uint]models.BoardContributor)
 	for _, c := range contributors {
@@ -363,66 +574,124 @@ func (s *BoardService) ListUserBoards(userID uint, page, perPage int, search, so
 		IsOwner    bool
 		IsFavorite bool
 		IsArchived bool
+		FolderID   *uint
 		Creator    models.User
+		PostCount  int64
 	}, len(boards))
 
 	for i, board := range boards {
-		var creator models.User
-		if err := s.db.First(&creator, board.CreatorID).Error; err != nil {
-			continue
-		}
 		result[i].Board = board
 		result[i].IsOwner = board.CreatorID == userID
 
-		// Set favorite/archived status from contributor record if it exists
+		// Set favorite/archived status and folder from contributor record if it exists
 		if contributor, exists := contributorMap[board.ID]; exists {
 			result[i].IsFavorite = contributor.IsFavorite
 			result[i].IsArchived = contributor.IsArchived
+			result[i].FolderID = contributor.FolderID
 		} else {
 			// For boards where user is creator but not in contributors table yet
 			result[i].IsFavorite = false
 			result[i].IsArchived = false
 		}
-		result[i].Creator = creator
+		if creator, exists := creators[board.CreatorID]; exists {
+			result[i].Creator = *creator
+		}
+		result[i].PostCount = postCounts[board.ID]
 	}
 
 	return result, total, nil
 }
 
-// UpdateBoardPreferences updates a user's preferences for a board (favorite/archived status)
-func (s *BoardService) UpdateBoardPreferences(boardID, userID uint, isFavorite, isArchived *bool) error {
-	// Find the contributor record
-	var contributor models.BoardContributor
-	result := s.db.Where("board_id = ? AND user_id = ?", boardID, userID).First(&contributor)
-	if result.Error != nil {
-		// If the board-user relationship doesn't exist
-		return utils.NewNotFoundError("Board not found or you don't have access to it").
-			WithField("board_id", boardID).
+// countApprovedPosts counts the approved posts of several boards at once
+func (s *BoardService) countApprovedPosts(boardIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(boardIDs))
+	if len(boardIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		BoardID uint
+		Count   int64
+	}
+	if err := s.db.Model(&models.Post{}).
+		Select("board_id, COUNT(*) AS count").
+		Where("board_id IN ? AND status = ?", boardIDs, models.PostStatusApproved).
+		Group("board_id").
+		Scan(&rows).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to count posts", err)
+	}
+	for _, row := range rows {
+		counts[row.BoardID] = row.Count
+	}
+
+	return counts, nil
+}
+
+// UpdateBoardPreferences updates a user's preferences for a board (favorite/archived status and folder)
+func (s *BoardService) UpdateBoardPreferences(boardID, userID uint, input requests.UpdateBoardPreferencesRequest) error {
+	_, err := s.BulkUpdateBoardPreferences([]uint{boardID}, userID, input)
+	return err
+}
+
+// BulkUpdateBoardPreferences updates a user's preferences for several boards at once
+func (s *BoardService) BulkUpdateBoardPreferences(boardIDs []uint, userID uint, input requests.UpdateBoardPreferencesRequest) (int64, error) {
+	// Find the contributor records
+	var contributors []models.BoardContributor
+	if err := s.db.Where("board_id IN ? AND user_id = ?", boardIDs, userID).Find(&contributors).Error; err != nil {
+		return 0, utils.NewInternalError("Failed to fetch board contributors", err).
 			WithField("user_id", userID)
 	}
 
+	// Every board must be accessible to the user
+	found := make(map[uint]bool, len(contributors))
+	for _, contributor := range contributors {
+		found[contributor.BoardID] = true
+	}
+	for _, boardID := range boardIDs {
+		if !found[boardID] {
+			return 0, utils.NewNotFoundError("Board not found or you don't have access to it").
+				WithField("board_id", boardID).
+				WithField("user_id", userID)
+		}
+	}
+
 	// Update only the fields that are provided
 	updates := make(map[string]interface{})
 
-	if isFavorite != nil {
-		updates["is_favorite"] = *isFavorite
+	if input.IsFavorite != nil {
+		updates["is_favorite"] = *input.IsFavorite
 	}
 
-	if isArchived != nil {
-		updates["is_archived"] = *isArchived
+	if input.IsArchived != nil {
+		updates["is_archived"] = *input.IsArchived
 	}
 
-	// Only update if there are changes
-	if len(updates) > 0 {
-		result = s.db.Model(&contributor).Updates(updates)
-		if result.Error != nil {
-			return utils.NewInternalError("Failed to update board preferences", result.Error).
-				WithField("board_id", boardID).
-				WithField("user_id", userID)
+	if input.RemoveFromFolder {
+		updates["folder_id"] = nil
+	} else if input.FolderID != nil {
+		// The folder must belong to the user
+		var folder models.BoardFolder
+		if result := s.db.Where("id = ? AND user_id = ?", *input.FolderID, userID).First(&folder); result.Error != nil {
+			return 0, utils.NewNotFoundError("Folder not found").
+				WithField("folder_id", *input.FolderID)
 		}
+		updates["folder_id"] = folder.ID
 	}
 
-	return nil
+	// Only update if there are changes
+	if len(updates) == 0 {
+		return 0, nil
+	}
+
+	result := s.db.Model(&models.BoardContributor{}).
+		Where("board_id IN ? AND user_id = ?", boardIDs, userID).
+		Updates(updates)
+	if result.Error != nil {
+		return 0, utils.NewInternalError("Failed to update board preferences", result.Error).
+			WithField("user_id", userID)
+	}
+
+	return result.RowsAffected, nil
 }
 
 // AddContributor adds a contributor to a board
@@ -581,19 +850,815 @@ func (s *BoardService) ListBoardContributors(boardID, userID uint) ([]models.Boa
 			WithField("board_id", boardID)
 	}
 
-	// Get users for each contributor
+	// Get the users of all contributors at once
+	userIDs := make([]uint, len(contributors))
+	for i, contributor := range contributors {
+		userIDs[i] = contributor.UserID
+	}
+	usersByID, err := loadUsersByID(s.db, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var users []models.User
 	for _, contributor := range contributors {
-		var user models.User
-		if err := s.db.First(&user, contributor.UserID).Error; err != nil {
-			continue // Skip if user not found
+		if user, exists := usersByID[contributor.UserID]; exists {
+			users = append(users, *user)
 		}
-		users = append(users, user)
 	}
 
 	return contributors, users, nil
 }
 
+// BulkAddContributors adds many contributors to a board in a single transaction.
+// Unknown emails receive an invitation that is redeemed when they sign up.
+func (s *BoardService) BulkAddContributors(boardID, userID uint, entries []requests.BulkContributorEntry, dryRun bool) ([]responses.BulkContributorResult, error) {
+	// Find board
+	var board models.Board
+	if result := s.db.First(&board, boardID); result.Error != nil {
+		return nil, utils.NewNotFoundError("Board not found").
+			WithField("board_id", boardID)
+	}
+
+	// Check if user is the creator
+	if board.CreatorID != userID {
+		return nil, utils.NewForbiddenError("You don't have permission to add contributors to this board").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	if len(entries) == 0 {
+		return nil, utils.NewBadRequestError("No contributors provided")
+	}
+	if len(entries) > maxBulkContributorRows {
+		return nil, utils.NewBadRequestError(fmt.Sprintf("A bulk import is limited to %d rows", maxBulkContributorRows)).
+			WithField("rows", len(entries))
+	}
+
+	// Normalize emails so lookups are case-insensitive
+	emails := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		emails = append(emails, strings.ToLower(strings.TrimSpace(entry.Email)))
+	}
+
+	// Load existing users, contributors and invitations in batches
+	var users []models.User
+	if err := s.db.Where("LOWER(email) IN ?", emails).Find(&users).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to look up users", err).
+			WithField("board_id", boardID)
+	}
+	usersByEmail := make(map[string]models.User, len(users))
+	for _, u := range users {
+		usersByEmail[strings.ToLower(u.Email)] = u
+	}
+
+	var contributors []models.BoardContributor
+	if err := s.db.Where("board_id = ?", boardID).Find(&contributors).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch contributors", err).
+			WithField("board_id", boardID)
+	}
+	contributorIDs := make(map[uint]bool, len(contributors))
+	for _, c := range contributors {
+		contributorIDs[c.UserID] = true
+	}
+
+	var invitations []models.BoardInvitation
+	if err := s.db.Where("board_id = ?", boardID).Find(&invitations).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch invitations", err).
+			WithField("board_id", boardID)
+	}
+	invitedEmails := make(map[string]bool, len(invitations))
+	for _, inv := range invitations {
+		invitedEmails[inv.Email] = true
+	}
+
+	results := make([]responses.BulkContributorResult, len(entries))
+	var newInvitations []string
+	seen := make(map[string]bool, len(entries))
+
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		for i, entry := range entries {
+			email := emails[i]
+			role := entry.Role
+			if role == "" {
+				role = models.RoleContributor
+			}
+
+			result := responses.BulkContributorResult{
+				Row:   i + 1,
+				Email: email,
+				Role:  string(role),
+			}
+
+			switch {
+			case !isValidEmail(email):
+				result.Status = BulkStatusInvalid
+				result.Message = "Invalid email address"
+			case !isValidRole(role):
+				result.Status = BulkStatusInvalid
+				result.Message = "Invalid role. Allowed roles: viewer, contributor, moderator, admin"
+			case seen[email]:
+				result.Status = BulkStatusInvalid
+				result.Message = "Duplicate email in import"
+			default:
+				if contributorUser, exists := usersByEmail[email]; exists {
+					result.UserID = contributorUser.ID
+					if contributorIDs[contributorUser.ID] || contributorUser.ID == board.CreatorID {
+						result.Status = BulkStatusAlreadyPresent
+						break
+					}
+
+					contributor := models.BoardContributor{
+						BoardID: boardID,
+						UserID:  contributorUser.ID,
+						Role:    role,
+					}
+					if err := tx.Create(&contributor).Error; err != nil {
+						return utils.NewInternalError("Failed to add contributor", err).
+							WithField("board_id", boardID).
+							WithField("row", i+1)
+					}
+					result.Status = BulkStatusAdded
+				} else {
+					if invitedEmails[email] {
+						result.Status = BulkStatusAlreadyPresent
+						result.Message = "Invitation already pending"
+						break
+					}
+
+					invitation := models.BoardInvitation{
+						BoardID:     boardID,
+						Email:       email,
+						Role:        role,
+						InvitedByID: userID,
+					}
+					if err := tx.Create(&invitation).Error; err != nil {
+						return utils.NewInternalError("Failed to create invitation", err).
+							WithField("board_id", boardID).
+							WithField("row", i+1)
+					}
+					result.Status = BulkStatusInvited
+					newInvitations = append(newInvitations, email)
+				}
+			}
+
+			if isValidEmail(email) {
+				seen[email] = true
+			}
+			results[i] = result
+		}
+
+		// Roll back everything when only previewing the import
+		if dryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	if !dryRun {
+		for _, email := range newInvitations {
+			s.sendInvitationEmail(&board, email)
+		}
+	}
+
+	return results, nil
+}
+
+// BulkUpdateContributors updates roles of or removes many contributors in a single transaction
+func (s *BoardService) BulkUpdateContributors(boardID, userID uint, changes []requests.BulkContributorChange, dryRun bool) ([]responses.BulkContributorResult, error) {
+	// Find board
+	var board models.Board
+	if result := s.db.First(&board, boardID); result.Error != nil {
+		return nil, utils.NewNotFoundError("Board not found").
+			WithField("board_id", boardID)
+	}
+
+	// Check if user is the creator
+	if board.CreatorID != userID {
+		return nil, utils.NewForbiddenError("You don't have permission to update contributors for this board").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	if len(changes) == 0 {
+		return nil, utils.NewBadRequestError("No changes provided")
+	}
+	if len(changes) > maxBulkContributorRows {
+		return nil, utils.NewBadRequestError(fmt.Sprintf("A bulk update is limited to %d rows", maxBulkContributorRows)).
+			WithField("rows", len(changes))
+	}
+
+	// Load current contributors with their users
+	var contributors []models.BoardContributor
+	if err := s.db.Where("board_id = ?", boardID).Find(&contributors).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch contributors", err).
+			WithField("board_id", boardID)
+	}
+	contributorsByUser := make(map[uint]models.BoardContributor, len(contributors))
+	userIDs := make([]uint, 0, len(contributors))
+	for _, c := range contributors {
+		contributorsByUser[c.UserID] = c
+		userIDs = append(userIDs, c.UserID)
+	}
+
+	var users []models.User
+	if err := s.db.Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch contributor users", err).
+			WithField("board_id", boardID)
+	}
+	emailsByUser := make(map[uint]string, len(users))
+	for _, u := range users {
+		emailsByUser[u.ID] = u.Email
+	}
+
+	results := make([]responses.BulkContributorResult, len(changes))
+	seen := make(map[uint]bool, len(changes))
+
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		for i, change := range changes {
+			result := responses.BulkContributorResult{
+				Row:    i + 1,
+				UserID: change.UserID,
+				Email:  emailsByUser[change.UserID],
+				Role:   string(change.Role),
+			}
+
+			contributor, exists := contributorsByUser[change.UserID]
+
+			switch {
+			case change.UserID == 0:
+				result.Status = BulkStatusInvalid
+				result.Message = "User ID is required"
+			case change.UserID == board.CreatorID:
+				result.Status = BulkStatusInvalid
+				result.Message = "The board creator cannot be changed or removed"
+			case seen[change.UserID]:
+				result.Status = BulkStatusInvalid
+				result.Message = "Duplicate user in request"
+			case !exists:
+				result.Status = BulkStatusNotFound
+				result.Message = "Contributor not found"
+			case change.Remove:
+				if err := tx.Where("board_id = ? AND user_id = ?", boardID, change.UserID).
+					Delete(&models.BoardContributor{}).Error; err != nil {
+					return utils.NewInternalError("Failed to remove contributor", err).
+						WithField("board_id", boardID).
+						WithField("contributor_id", change.UserID)
+				}
+				result.Role = string(contributor.Role)
+				result.Status = BulkStatusRemoved
+			case !isValidRole(change.Role):
+				result.Status = BulkStatusInvalid
+				result.Message = "Invalid role. Allowed roles: viewer, contributor, moderator, admin"
+			case contributor.Role == change.Role:
+				result.Status = BulkStatusUnchanged
+			default:
+				if err := tx.Model(&models.BoardContributor{}).
+					Where("board_id = ? AND user_id = ?", boardID, change.UserID).
+					Update("role", change.Role).Error; err != nil {
+					return utils.NewInternalError("Failed to update contributor", err).
+						WithField("board_id", boardID).
+						WithField("contributor_id", change.UserID)
+				}
+				result.Status = BulkStatusUpdated
+			}
+
+			if change.UserID != 0 {
+				seen[change.UserID] = true
+			}
+			results[i] = result
+		}
+
+		// Roll back everything when only previewing the changes
+		if dryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// sendInvitationEmail notifies an email address that it has been invited to a board
+func (s *BoardService) sendInvitationEmail(board *models.Board, email string) {
+	subject := fmt.Sprintf("You're invited to sign \"%s\"", board.Title)
+	body := fmt.Sprintf(
+		"You have been invited to contribute to the board \"%s\" for %s.\n\n"+
+			"Create an account with this email address and confirm it to get access:\n%s/register?email=%s\n\n"+
+			"Board link: %s/boards/%s\n",
+		board.Title, board.ReceiverName, s.cfg.ClientURL, url.QueryEscape(email), s.cfg.ClientURL, board.Slug,
+	)
+	s.emailService.SendAsync(email, subject, body)
+}
+
+// isValidEmail checks if a string is a bare email address
+func isValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// isValidRole checks if a role is one of the known board roles
+func isValidRole(role models.Role) bool {
+	switch role {
+	case models.RoleViewer, models.RoleContributor, models.RoleModerator, models.RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// DuplicateBoard creates a copy of a board's settings, contributors and optionally its posts.
+// Internal media is copied so the two boards don't share files.
+func (s *BoardService) DuplicateBoard(boardID, userID uint, input requests.DuplicateBoardRequest) (*models.Board, error) {
+	// Find source board
+	source, err := s.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user can manage the source board
+	if !s.IsBoardAdmin(source, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to duplicate this board").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	board := models.Board{
+		Title:                source.Title + " (copy)",
+		ReceiverName:         source.ReceiverName,
+		MaxPost:              source.MaxPost,
+		CreatorID:            userID,
+		FontName:             source.FontName,
+		FontSize:             source.FontSize,
+		HeaderColor:          source.HeaderColor,
+		ShowHeaderColor:      source.ShowHeaderColor,
+		ThemeID:              source.ThemeID,
+		Effect:               source.Effect,
+		Style:                source.Style,
+		EnableIntroAnimation: source.EnableIntroAnimation,
+		IsPrivate:            source.IsPrivate,
+		AllowAnonymous:       source.AllowAnonymous,
+		EnableReminders:      source.EnableReminders,
+		RequireApproval:      source.RequireApproval,
+		AllowEmbed:           source.AllowEmbed,
+		LayoutMode:           source.LayoutMode,
+	}
+
+	// Check new names against the content filter
+	if err := s.filterBoardText(0, userID, input.Title, input.ReceiverName); err != nil {
+		return nil, err
+	}
+	if input.Title != nil {
+		board.Title = *input.Title
+	}
+	if input.ReceiverName != nil {
+		board.ReceiverName = *input.ReceiverName
+	}
+
+	var contributors []models.BoardContributor
+	if input.IncludeContributors == nil || *input.IncludeContributors {
+		if err := s.db.Where("board_id = ? AND user_id <> ?", boardID, userID).Find(&contributors).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to fetch board contributors", err).
+				WithField("board_id", boardID)
+		}
+	}
+
+	var sections []models.BoardSection
+	if err := s.db.Where("board_id = ?", boardID).Order("position asc, id asc").Find(&sections).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch board sections", err).
+			WithField("board_id", boardID)
+	}
+
+	var posts []models.Post
+	var copiedMedia []string
+	if input.IncludePosts {
+		// Only approved posts are copied
+		if err := s.db.Where("board_id = ? AND status = ?", boardID, models.PostStatusApproved).
+			Order("rank asc, id asc").Find(&posts).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to fetch board posts", err).
+				WithField("board_id", boardID)
+		}
+
+		// Copy internal media before touching the database
+		for i := range posts {
+			if posts[i].MediaPath == "" || posts[i].MediaSource != "internal" {
+				continue
+			}
+			fileInfo, err := storage.CopyFile(s.storage, posts[i].MediaPath)
+			if err != nil {
+				storage.DeleteFiles(s.storage, copiedMedia)
+				return nil, utils.NewInternalError("Failed to copy post media", err).
+					WithField("post_id", posts[i].ID)
+			}
+			posts[i].MediaPath = fileInfo.URL
+			copiedMedia = append(copiedMedia, fileInfo.URL)
+		}
+	}
+
+	err = utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := createBoardWithSettings(tx, &board); err != nil {
+			return utils.NewInternalError("Failed to create board", err)
+		}
+
+		// Add requesting user as admin contributor
+		owner := models.BoardContributor{
+			BoardID: board.ID,
+			UserID:  userID,
+			Role:    models.RoleAdmin,
+		}
+		if err := tx.Create(&owner).Error; err != nil {
+			return utils.NewInternalError("Failed to add creator as admin", err)
+		}
+
+		for _, contributor := range contributors {
+			copied := models.BoardContributor{
+				BoardID: board.ID,
+				UserID:  contributor.UserID,
+				Role:    contributor.Role,
+			}
+			if err := tx.Create(&copied).Error; err != nil {
+				return utils.NewInternalError("Failed to copy contributors", err).
+					WithField("board_id", board.ID)
+			}
+		}
+
+		// Copy sections, remembering where each one's posts go
+		copiedSections := make(map[uint]uint, len(sections))
+		for _, section := range sections {
+			copied := models.BoardSection{
+				BoardID:   board.ID,
+				Title:     section.Title,
+				Color:     section.Color,
+				Position:  section.Position,
+				IsDefault: section.IsDefault,
+			}
+			if err := tx.Create(&copied).Error; err != nil {
+				return utils.NewInternalError("Failed to copy sections", err).
+					WithField("board_id", board.ID)
+			}
+			copiedSections[section.ID] = copied.ID
+		}
+
+		for _, post := range posts {
+			var sectionID *uint
+			if post.SectionID != nil {
+				if copiedID, exists := copiedSections[*post.SectionID]; exists {
+					sectionID = &copiedID
+				}
+			}
+
+			copied := models.Post{
+				BoardID:         board.ID,
+				SectionID:       sectionID,
+				AuthorID:        post.AuthorID,
+				AuthorName:      post.AuthorName,
+				Content:         post.Content,
+				ContentFormat:   post.ContentFormat,
+				ContentHTML:     post.ContentHTML,
+				MediaPath:       post.MediaPath,
+				MediaType:       post.MediaType,
+				MediaSource:     post.MediaSource,
+				BackgroundColor: post.BackgroundColor,
+				TextColor:       post.TextColor,
+				Position:        post.Position,
+				Rank:            post.Rank,
+				Layout:          post.Layout,
+			}
+			if err := tx.Create(&copied).Error; err != nil {
+				return utils.NewInternalError("Failed to copy posts", err).
+					WithField("board_id", board.ID)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		storage.DeleteFiles(s.storage, copiedMedia)
+		return nil, err
+	}
+
+	return &board, nil
+}
+
+// filterBoardText checks a board's title and receiver name against the content filter,
+// masking words in place. Names can't be held for review, so moderate matches are rejected.
+func (s *BoardService) filterBoardText(boardID, userID uint, title, receiverName *string) error {
+	_, err := s.contentFilter.FilterText(boardID, userID, false,
+		FilterField{Name: "title", Value: title},
+		FilterField{Name: "receiver_name", Value: receiverName},
+	)
+	return err
+}
+
+// createBoardWithSettings creates a board keeping boolean settings that column defaults would otherwise override
+func createBoardWithSettings(tx *gorm.DB, board *models.Board) error {
+	flags := map[string]interface{}{
+		"show_header_color": board.ShowHeaderColor,
+		"allow_anonymous":   board.AllowAnonymous,
+		"enable_reminders":  board.EnableReminders,
+		"allow_embed":       board.AllowEmbed,
+	}
+
+	if err := tx.Create(board).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(board).Updates(flags).Error
+}
+
+// boardSettingFields lists the board settings tracked in the change history, in display order
+var boardSettingFields = []string{
+	"title",
+	"receiver_name",
+	"font_name",
+	"font_size",
+	"header_color",
+	"show_header_color",
+	"theme_id",
+	"effect",
+	"style",
+	"enable_intro_animation",
+	"is_private",
+	"allow_anonymous",
+	"delivery_at",
+	"enable_reminders",
+	"require_approval",
+	"allow_embed",
+	"layout_mode",
+}
+
+// BoardVersion groups the settings changed by a single board update
+type BoardVersion struct {
+	Version   int
+	Actor     *models.User
+	ChangedAt time.Time
+	Changes   []models.BoardSettingChange
+}
+
+// boardSettings returns the JSON-encoded value of every tracked setting, keyed by its request field name
+func boardSettings(board *models.Board) map[string]string {
+	var deliveryAt *time.Time
+	if board.DeliveryAt != nil {
+		utc := board.DeliveryAt.UTC()
+		deliveryAt = &utc
+	}
+
+	// A board without an effect records null, so reverting to it clears the effect
+	var effect *models.BoardEffect
+	if !board.Effect.IsZero() {
+		effect = &board.Effect
+	}
+	var style *models.BoardStyle
+	if !board.Style.IsZero() {
+		style = &board.Style
+	}
+
+	values := map[string]interface{}{
+		"title":                  board.Title,
+		"receiver_name":          board.ReceiverName,
+		"font_name":              board.FontName,
+		"font_size":              board.FontSize,
+		"header_color":           board.HeaderColor,
+		"show_header_color":      board.ShowHeaderColor,
+		"theme_id":               board.ThemeID,
+		"effect":                 effect,
+		"style":                  style,
+		"enable_intro_animation": board.EnableIntroAnimation,
+		"is_private":             board.IsPrivate,
+		"allow_anonymous":        board.AllowAnonymous,
+		"delivery_at":            deliveryAt,
+		"enable_reminders":       board.EnableReminders,
+		"require_approval":       board.RequireApproval,
+		"allow_embed":            board.AllowEmbed,
+		"layout_mode":            board.LayoutMode,
+	}
+
+	settings := make(map[string]string, len(values))
+	for field, value := range values {
+		encoded, _ := json.Marshal(value)
+		settings[field] = string(encoded)
+	}
+	return settings
+}
+
+// recordSettingChanges stores the settings that differ between two snapshots as the board's next version
+func (s *BoardService) recordSettingChanges(tx *gorm.DB, boardID, userID uint, before, after map[string]string) error {
+	var changes []models.BoardSettingChange
+	for _, field := range boardSettingFields {
+		if before[field] != after[field] {
+			changes = append(changes, models.BoardSettingChange{
+				BoardID:  boardID,
+				Field:    field,
+				OldValue: before[field],
+				NewValue: after[field],
+				ActorID:  userID,
+			})
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Lock the board row so concurrent updates can't claim the same version
+	var board models.Board
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&board, boardID).Error; err != nil {
+		return utils.NewInternalError("Failed to lock board", err).
+			WithField("board_id", boardID)
+	}
+
+	var latest int
+	if err := tx.Model(&models.BoardSettingChange{}).
+		Where("board_id = ?", boardID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error; err != nil {
+		return utils.NewInternalError("Failed to fetch board version", err).
+			WithField("board_id", boardID)
+	}
+
+	for i := range changes {
+		changes[i].Version = latest + 1
+	}
+
+	if err := tx.Create(&changes).Error; err != nil {
+		return utils.NewInternalError("Failed to record board changes", err).
+			WithField("board_id", boardID)
+	}
+
+	return nil
+}
+
+// GetBoardHistory lists the versions of a board's settings, newest first
+func (s *BoardService) GetBoardHistory(boardID, userID uint) ([]BoardVersion, error) {
+	board, err := s.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user can manage the board
+	if !s.IsBoardAdmin(board, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to view this board's history").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	var changes []models.BoardSettingChange
+	if err := s.db.Where("board_id = ?", boardID).
+		Order("version desc, id asc").
+		Find(&changes).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch board history", err).
+			WithField("board_id", boardID)
+	}
+
+	// Load everyone who changed the board
+	actorIDs := make([]uint, 0, len(changes))
+	for _, change := range changes {
+		actorIDs = append(actorIDs, change.ActorID)
+	}
+
+	actors := make(map[uint]*models.User)
+	if len(actorIDs) > 0 {
+		var users []models.User
+		if err := s.db.Where("id IN ?", actorIDs).Find(&users).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to fetch users", err).
+				WithField("board_id", boardID)
+		}
+		for i := range users {
+			actors[users[i].ID] = &users[i]
+		}
+	}
+
+	// Group changes by version
+	var versions []BoardVersion
+	for _, change := range changes {
+		if len(versions) == 0 || versions[len(versions)-1].Version != change.Version {
+			versions = append(versions, BoardVersion{
+				Version:   change.Version,
+				Actor:     actors[change.ActorID],
+				ChangedAt: change.CreatedAt,
+			})
+		}
+		current := &versions[len(versions)-1]
+		current.Changes = append(current.Changes, change)
+	}
+
+	return versions, nil
+}
+
+// RevertBoard restores a board's settings to how they were right after the given version.
+// Version 0 is the board as it was created. The revert is applied as a regular update,
+// so it goes through the same checks and is recorded as a new version.
+func (s *BoardService) RevertBoard(boardID, userID uint, version int) (*models.Board, error) {
+	if _, err := s.GetBoardByID(boardID); err != nil {
+		return nil, err
+	}
+
+	var latest int
+	if err := s.db.Model(&models.BoardSettingChange{}).
+		Where("board_id = ?", boardID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch board version", err).
+			WithField("board_id", boardID)
+	}
+
+	if version < 0 || version > latest {
+		return nil, utils.NewNotFoundError("Board version not found").
+			WithField("board_id", boardID).
+			WithField("version", version)
+	}
+
+	if version == latest {
+		return nil, utils.NewBadRequestError("Board is already at this version").
+			WithField("board_id", boardID).
+			WithField("version", version)
+	}
+
+	// The value of each field at the target version is the old value of its first later change
+	var changes []models.BoardSettingChange
+	if err := s.db.Where("board_id = ? AND version > ?", boardID, version).
+		Order("version asc, id asc").
+		Find(&changes).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch board history", err).
+			WithField("board_id", boardID)
+	}
+
+	values := make(map[string]json.RawMessage)
+	clearFields := make(map[string]bool)
+	for _, change := range changes {
+		if _, seen := values[change.Field]; seen {
+			continue
+		}
+		values[change.Field] = json.RawMessage(change.OldValue)
+		if change.OldValue == "null" {
+			clearFields[change.Field] = true
+		}
+	}
+
+	// Turn the target values into an update request
+	var input requests.UpdateBoardRequest
+	encoded, err := json.Marshal(values)
+	if err == nil {
+		err = json.Unmarshal(encoded, &input)
+	}
+	if err != nil {
+		return nil, utils.NewInternalError("Failed to read board history", err).
+			WithField("board_id", boardID).
+			WithField("version", version)
+	}
+
+	return s.updateBoard(boardID, userID, input, clearFields)
+}
+
+// IsBoardAdmin checks if a user is the creator or an admin contributor of a board
+func (s *BoardService) IsBoardAdmin(board *models.Board, userID uint) bool {
+	if userID == 0 {
+		return false
+	}
+	if board.CreatorID == userID {
+		return true
+	}
+
+	var contributor models.BoardContributor
+	result := s.db.Where("board_id = ? AND user_id = ? AND role = ?",
+		board.ID, userID, models.RoleAdmin).First(&contributor)
+	return result.Error == nil
+}
+
+// CanModerateBoard checks if a user is the creator, an admin or a moderator of a board
+func (s *BoardService) CanModerateBoard(board *models.Board, userID uint) bool {
+	if userID == 0 {
+		return false
+	}
+	if board.CreatorID == userID {
+		return true
+	}
+
+	var contributor models.BoardContributor
+	result := s.db.Where("board_id = ? AND user_id = ? AND role IN ?",
+		board.ID, userID, []models.Role{models.RoleAdmin, models.RoleModerator}).First(&contributor)
+	return result.Error == nil
+}
+
+// isSiteAdmin checks if a user is a site administrator
+func (s *BoardService) isSiteAdmin(userID uint) bool {
+	var user models.User
+	if result := s.db.Select("id", "is_admin").First(&user, userID); result.Error != nil {
+		return false
+	}
+	return user.IsAdmin
+}
+
 // CanAccessBoard checks if a user has access to a board
 func (s *BoardService) CanAccessBoard(boardID, userID uint) (bool, error) {
 	// Find board
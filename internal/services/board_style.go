@@ -0,0 +1,301 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Limits and defaults of a board style
+const (
+	maxBackgroundBlur      = 40
+	maxGradientColors      = 5
+	maxCardCornerRadius    = 32
+	defaultCardRadius      = 12
+	defaultCardShadow      = "small"
+	defaultGradientAngle   = 180
+	defaultOverlayColor    = "#000000"
+	defaultCardBackground  = "#ffffff"
+	defaultCardTextColor   = "#000000"
+	backgroundUploadFolder = CategoryBackground + "/"
+)
+
+// cardShadows lists the shadows a post card can have
+var cardShadows = []string{"none", "small", "medium", "large"}
+
+// unsplashIDPattern matches the IDs of Unsplash photos
+var unsplashIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// boardStyleInput is a style as sent by clients
+type boardStyleInput struct {
+	Background *backgroundInput `json:"background"`
+	Card       *cardStyleInput  `json:"card"`
+}
+
+// backgroundInput is a background as sent by clients
+type backgroundInput struct {
+	Type           string                `json:"type"`
+	Color          string                `json:"color"`
+	Gradient       *gradientInput        `json:"gradient"`
+	Image          *backgroundImageInput `json:"image"`
+	Blur           *int                  `json:"blur"`
+	OverlayColor   string                `json:"overlay_color"`
+	OverlayOpacity *float64              `json:"overlay_opacity"`
+}
+
+// gradientInput is a gradient as sent by clients
+type gradientInput struct {
+	Kind   string        `json:"kind"`
+	Angle  *int          `json:"angle"`
+	Colors []interface{} `json:"colors"`
+}
+
+// backgroundImageInput is a background image as sent by clients. The attribution fields are
+// filled in from Unsplash, but are accepted so a style read from the API can be sent back.
+type backgroundImageInput struct {
+	Source     string `json:"source"`
+	URL        string `json:"url"`
+	UnsplashID string `json:"unsplash_id"`
+	Color      string `json:"color"`
+	AuthorName string `json:"author_name"`
+	AuthorURL  string `json:"author_url"`
+	PhotoURL   string `json:"photo_url"`
+}
+
+// cardStyleInput is a card style as sent by clients
+type cardStyleInput struct {
+	CornerRadius    *int   `json:"corner_radius"`
+	Shadow          string `json:"shadow"`
+	BackgroundColor string `json:"background_color"`
+	TextColor       string `json:"text_color"`
+}
+
+// parseBoardStyle validates a style sent by a client and fills in the defaults of the fields
+// left out. Uploaded background images must have been uploaded as backgrounds, and Unsplash
+// photos are looked up unless the board already shows the same photo. null or {} remove
+// the style. Every invalid field is reported in the returned validation error.
+func (s *BoardService) parseBoardStyle(raw json.RawMessage, current models.BoardStyle) (models.BoardStyle, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return models.BoardStyle{}, nil
+	}
+
+	var input boardStyleInput
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&input); err != nil {
+		return models.BoardStyle{}, invalidStyleError(utils.FieldError{
+			Field:   "style",
+			Message: "must be an object with a background and card",
+		})
+	}
+
+	var style models.BoardStyle
+	var fieldErrors []utils.FieldError
+	add := func(field, message string) {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: "style." + field, Message: message})
+	}
+
+	if input.Background != nil {
+		style.Background = s.parseBackground(input.Background, current, add)
+	}
+
+	if input.Card != nil {
+		card := &models.CardStyle{
+			CornerRadius:    defaultCardRadius,
+			Shadow:          defaultCardShadow,
+			BackgroundColor: defaultCardBackground,
+			TextColor:       defaultCardTextColor,
+		}
+		if input.Card.CornerRadius != nil {
+			card.CornerRadius = *input.Card.CornerRadius
+			if card.CornerRadius < 0 || card.CornerRadius > maxCardCornerRadius {
+				add("card.corner_radius", fmt.Sprintf("must be a whole number from 0 to %d", maxCardCornerRadius))
+			}
+		}
+		if input.Card.Shadow != "" {
+			card.Shadow = input.Card.Shadow
+			if !slices.Contains(cardShadows, card.Shadow) {
+				add("card.shadow", "must be one of "+strings.Join(cardShadows, ", "))
+			}
+		}
+		parseStyleColor(input.Card.BackgroundColor, &card.BackgroundColor, "card.background_color", add)
+		parseStyleColor(input.Card.TextColor, &card.TextColor, "card.text_color", add)
+		style.Card = card
+	}
+
+	if len(fieldErrors) > 0 {
+		return models.BoardStyle{}, invalidStyleError(fieldErrors...)
+	}
+
+	return style, nil
+}
+
+// parseBackground validates the background of a style, reporting invalid fields with add
+func (s *BoardService) parseBackground(input *backgroundInput, current models.BoardStyle, add func(field, message string)) *models.BoardBackground {
+	background := &models.BoardBackground{
+		Type:         input.Type,
+		OverlayColor: defaultOverlayColor,
+	}
+
+	switch input.Type {
+	case models.BackgroundTypeColor:
+		if input.Color == "" {
+			add("background.color", "is required for color backgrounds")
+		}
+		parseStyleColor(input.Color, &background.Color, "background.color", add)
+	case models.BackgroundTypeGradient:
+		background.Gradient = parseGradient(input.Gradient, add)
+	case models.BackgroundTypeImage:
+		background.Image = s.parseBackgroundImage(input.Image, current, add)
+	default:
+		add("background.type", "must be one of color, gradient, image")
+	}
+
+	if input.Blur != nil {
+		background.Blur = *input.Blur
+		if background.Blur < 0 || background.Blur > maxBackgroundBlur {
+			add("background.blur", fmt.Sprintf("must be a whole number from 0 to %d", maxBackgroundBlur))
+		}
+	}
+	parseStyleColor(input.OverlayColor, &background.OverlayColor, "background.overlay_color", add)
+	if input.OverlayOpacity != nil {
+		background.OverlayOpacity = *input.OverlayOpacity
+		if background.OverlayOpacity < 0 || background.OverlayOpacity > 1 {
+			add("background.overlay_opacity", "must be a number from 0 to 1")
+		}
+	}
+
+	return background
+}
+
+// parseGradient validates the gradient of a background, reporting invalid fields with add
+func parseGradient(input *gradientInput, add func(field, message string)) *models.BackgroundGradient {
+	if input == nil {
+		add("background.gradient", "is required for gradient backgrounds")
+		return nil
+	}
+
+	gradient := &models.BackgroundGradient{
+		Kind:  input.Kind,
+		Angle: defaultGradientAngle,
+	}
+	if gradient.Kind == "" {
+		gradient.Kind = "linear"
+	}
+	if gradient.Kind != "linear" && gradient.Kind != "radial" {
+		add("background.gradient.kind", "must be one of linear, radial")
+	}
+
+	if input.Angle != nil {
+		gradient.Angle = *input.Angle
+		if gradient.Angle < 0 || gradient.Angle > 360 {
+			add("background.gradient.angle", "must be a whole number from 0 to 360")
+		}
+	}
+
+	colorsError := fmt.Sprintf("must be a list of 2 to %d hex colors", maxGradientColors)
+	if len(input.Colors) < 2 || len(input.Colors) > maxGradientColors {
+		add("background.gradient.colors", colorsError)
+		return gradient
+	}
+	for _, value := range input.Colors {
+		color, ok := models.NormalizeHexColor(value)
+		if !ok {
+			add("background.gradient.colors", colorsError)
+			return gradient
+		}
+		gradient.Colors = append(gradient.Colors, color)
+	}
+
+	return gradient
+}
+
+// parseBackgroundImage validates the image of a background, reporting invalid fields with add
+func (s *BoardService) parseBackgroundImage(input *backgroundImageInput, current models.BoardStyle, add func(field, message string)) *models.BackgroundImage {
+	if input == nil {
+		add("background.image", "is required for image backgrounds")
+		return nil
+	}
+
+	switch input.Source {
+	case models.BackgroundSourceUpload:
+		// Only images uploaded as backgrounds, so they can be told apart from other uploads
+		prefix := s.storage.GetURL(backgroundUploadFolder)
+		if !strings.HasPrefix(input.URL, prefix) || len(input.URL) == len(prefix) || strings.Contains(input.URL, "..") {
+			add("background.image.url", "must be an image uploaded with the background category")
+			return nil
+		}
+		return &models.BackgroundImage{
+			Source: models.BackgroundSourceUpload,
+			URL:    input.URL,
+		}
+
+	case models.BackgroundSourceUnsplash:
+		if !unsplashIDPattern.MatchString(input.UnsplashID) {
+			add("background.image.unsplash_id", "must be the ID of an Unsplash photo")
+			return nil
+		}
+
+		// Keep the photo the board already shows instead of looking it up again
+		if background := current.Background; background != nil && background.Image != nil &&
+			background.Image.Source == models.BackgroundSourceUnsplash && background.Image.UnsplashID == input.UnsplashID {
+			image := *background.Image
+			return &image
+		}
+
+		photo, err := s.unsplashService.GetPhoto(input.UnsplashID)
+		if err != nil {
+			add("background.image.unsplash_id", "must be the ID of an Unsplash photo")
+			return nil
+		}
+		go func() {
+			if err := s.unsplashService.TrackDownload(photo); err != nil {
+				log.Warn("Failed to track Unsplash download",
+					zap.String("photo_id", photo.ID),
+					zap.Error(err))
+			}
+		}()
+
+		return &models.BackgroundImage{
+			Source:     models.BackgroundSourceUnsplash,
+			URL:        photo.URL,
+			Color:      photo.Color,
+			UnsplashID: photo.ID,
+			AuthorName: photo.AuthorName,
+			AuthorURL:  photo.AuthorURL,
+			PhotoURL:   photo.PhotoURL,
+		}
+
+	default:
+		add("background.image.source", "must be one of upload, unsplash")
+		return nil
+	}
+}
+
+// parseStyleColor stores a hex color sent by a client in target, leaving the default when
+// it's empty
+func parseStyleColor(value string, target *string, field string, add func(field, message string)) {
+	if value == "" {
+		return
+	}
+	color, ok := models.NormalizeHexColor(value)
+	if !ok {
+		add(field, "must be a hex color like #ff0000")
+		return
+	}
+	*target = color
+}
+
+// invalidStyleError creates the validation error for an invalid style
+func invalidStyleError(fieldErrors ...utils.FieldError) error {
+	return utils.NewValidationError("The style is invalid").
+		WithFieldErrors(fieldErrors...)
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxFilterExcerptLength limits how much of the filtered text is kept in an event
+const maxFilterExcerptLength = 200
+
+// FilterField is a piece of user-supplied text checked by the content filter.
+// Masked words are replaced in Value.
+type FilterField struct {
+	Name  string
+	Value *string
+}
+
+// cachedMatcher is a compiled word list, valid until the list is updated
+type cachedMatcher struct {
+	updatedAt time.Time
+	matcher   *utils.WordMatcher
+}
+
+// ContentFilterService checks posts and board settings against site-wide and per-board word lists
+type ContentFilterService struct {
+	db  *gorm.DB
+	cfg *config.Config
+
+	mu       sync.Mutex
+	matchers map[uint]cachedMatcher
+}
+
+// NewContentFilterService creates a new ContentFilterService
+func NewContentFilterService(db *gorm.DB, cfg *config.Config) *ContentFilterService {
+	return &ContentFilterService{
+		db:       db,
+		cfg:      cfg,
+		matchers: make(map[uint]cachedMatcher),
+	}
+}
+
+// FilterText checks the given fields against the site-wide lists and, when boardID is set,
+// the board's own lists. Words matched by a mask list are replaced in place. A match on a
+// reject list returns an error, and a match on a moderate list is reported so the caller can
+// hold the content for review; when allowModeration is false such matches are rejected instead.
+func (s *ContentFilterService) FilterText(boardID, userID uint, allowModeration bool, fields ...FilterField) (bool, error) {
+	query := s.db.Where("is_enabled = ?", true)
+	if boardID != 0 {
+		query = query.Where("board_id IS NULL OR board_id = ?", boardID)
+	} else {
+		query = query.Where("board_id IS NULL")
+	}
+
+	var lists []models.ContentFilterList
+	if err := query.Find(&lists).Error; err != nil {
+		return false, utils.NewInternalError("Failed to load content filters", err).
+			WithField("board_id", boardID)
+	}
+	if len(lists) == 0 {
+		return false, nil
+	}
+
+	var events []models.ContentFilterEvent
+	moderate := false
+	var rejected *FilterField
+
+	for i := range fields {
+		field := fields[i]
+		if field.Value == nil || *field.Value == "" {
+			continue
+		}
+		original := *field.Value
+
+		var masks []utils.WordMatch
+		for j := range lists {
+			list := &lists[j]
+			matches := s.matcher(list).Find(original)
+			if len(matches) == 0 {
+				continue
+			}
+
+			action := list.Action
+			if action == models.ContentFilterActionModerate && !allowModeration {
+				action = models.ContentFilterActionReject
+			}
+
+			switch action {
+			case models.ContentFilterActionReject:
+				if rejected == nil {
+					rejected = &fields[i]
+				}
+			case models.ContentFilterActionModerate:
+				moderate = true
+			case models.ContentFilterActionMask:
+				masks = append(masks, matches...)
+			}
+
+			events = append(events, newFilterEvent(list, boardID, userID, field.Name, action, original, matches))
+		}
+
+		if len(masks) > 0 {
+			*field.Value = utils.MaskMatches(original, sortMatches(masks))
+		}
+	}
+
+	if len(events) > 0 {
+		if err := s.db.Create(&events).Error; err != nil {
+			log.Warn("Failed to record content filter events",
+				zap.Uint("board_id", boardID),
+				zap.Error(err))
+		}
+	}
+
+	if rejected != nil {
+		return false, utils.NewBadRequestError("The text contains words that aren't allowed").
+			WithField("field", rejected.Name)
+	}
+
+	return moderate, nil
+}
+
+// matcher returns the compiled matcher for a list, rebuilding it when the list has changed
+func (s *ContentFilterService) matcher(list *models.ContentFilterList) *utils.WordMatcher {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cached, ok := s.matchers[list.ID]; ok && cached.updatedAt.Equal(list.UpdatedAt) {
+		return cached.matcher
+	}
+
+	matcher := utils.NewWordMatcher(strings.Split(list.Words, "\n"))
+	s.matchers[list.ID] = cachedMatcher{updatedAt: list.UpdatedAt, matcher: matcher}
+	return matcher
+}
+
+// newFilterEvent builds the event recorded for a list matching a field
+func newFilterEvent(list *models.ContentFilterList, boardID, userID uint, field string, action models.ContentFilterAction, text string, matches []utils.WordMatch) models.ContentFilterEvent {
+	terms := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		if !seen[match.Term] {
+			seen[match.Term] = true
+			terms = append(terms, match.Term)
+		}
+	}
+
+	excerpt := []rune(text)
+	if len(excerpt) > maxFilterExcerptLength {
+		excerpt = excerpt[:maxFilterExcerptLength]
+	}
+
+	event := models.ContentFilterEvent{
+		ListID:       list.ID,
+		Field:        field,
+		Action:       action,
+		MatchedWords: strings.Join(terms, "\n"),
+		Excerpt:      string(excerpt),
+	}
+	if boardID != 0 {
+		event.BoardID = &boardID
+	}
+	if userID != 0 {
+		event.UserID = &userID
+	}
+	return event
+}
+
+// sortMatches orders matches from several lists by position so they can be masked in one pass
+func sortMatches(matches []utils.WordMatch) []utils.WordMatch {
+	for i := 1; i < len(matches); i++ {
+		for j := i; j > 0 && matches[j].Start < matches[j-1].Start; j-- {
+			matches[j], matches[j-1] = matches[j-1], matches[j]
+		}
+	}
+	return matches
+}
+
+// ListFilterLists lists the site-wide lists, or a board's lists when boardID is set
+func (s *ContentFilterService) ListFilterLists(boardID *uint) ([]models.ContentFilterList, error) {
+	query := s.db.Order("created_at asc")
+	if boardID != nil {
+		query = query.Where("board_id = ?", *boardID)
+	} else {
+		query = query.Where("board_id IS NULL")
+	}
+
+	var lists []models.ContentFilterList
+	if err := query.Find(&lists).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch content filters", err)
+	}
+	return lists, nil
+}
+
+// CreateFilterList creates a site-wide list, or a board list when boardID is set
+func (s *ContentFilterService) CreateFilterList(boardID *uint, userID uint, input requests.CreateContentFilterListRequest) (*models.ContentFilterList, error) {
+	list := models.ContentFilterList{
+		BoardID:     boardID,
+		Name:        input.Name,
+		Action:      input.Action,
+		Words:       joinFilterWords(input.Words),
+		IsEnabled:   true,
+		CreatedByID: userID,
+	}
+	if list.Words == "" {
+		return nil, utils.NewValidationError("The list must contain at least one word")
+	}
+
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Create(&list).Error; err != nil {
+			return utils.NewInternalError("Failed to create content filter", err)
+		}
+
+		// Zero values are replaced by column defaults on create, so disable the list explicitly
+		if input.IsEnabled != nil && !*input.IsEnabled {
+			if err := tx.Model(&list).Update("is_enabled", false).Error; err != nil {
+				return utils.NewInternalError("Failed to create content filter", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// UpdateFilterList updates a site-wide list, or a board list when boardID is set
+func (s *ContentFilterService) UpdateFilterList(listID uint, boardID *uint, input requests.UpdateContentFilterListRequest) (*models.ContentFilterList, error) {
+	list, err := s.getFilterList(listID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		list.Name = *input.Name
+	}
+	if input.Action != nil {
+		list.Action = *input.Action
+	}
+	if input.Words != nil {
+		list.Words = joinFilterWords(input.Words)
+		if list.Words == "" {
+			return nil, utils.NewValidationError("The list must contain at least one word")
+		}
+	}
+	if input.IsEnabled != nil {
+		list.IsEnabled = *input.IsEnabled
+	}
+
+	if result := s.db.Save(list); result.Error != nil {
+		return nil, utils.NewInternalError("Failed to update content filter", result.Error).
+			WithField("list_id", listID)
+	}
+
+	return list, nil
+}
+
+// DeleteFilterList deletes a list. Its events are kept.
+func (s *ContentFilterService) DeleteFilterList(listID uint, boardID *uint) error {
+	list, err := s.getFilterList(listID, boardID)
+	if err != nil {
+		return err
+	}
+
+	if result := s.db.Delete(list); result.Error != nil {
+		return utils.NewInternalError("Failed to delete content filter", result.Error).
+			WithField("list_id", listID)
+	}
+
+	s.mu.Lock()
+	delete(s.matchers, listID)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ListFilterEvents lists recorded filter events, newest first
+func (s *ContentFilterService) ListFilterEvents(query requests.ContentFilterEventQuery) ([]models.ContentFilterEvent, int64, error) {
+	dbQuery := s.db.Model(&models.ContentFilterEvent{})
+	if query.BoardID != 0 {
+		dbQuery = dbQuery.Where("board_id = ?", query.BoardID)
+	}
+	if query.ListID != 0 {
+		dbQuery = dbQuery.Where("list_id = ?", query.ListID)
+	}
+	if query.Action != "" {
+		dbQuery = dbQuery.Where("action = ?", query.Action)
+	}
+
+	var total int64
+	if err := dbQuery.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to count content filter events", err)
+	}
+
+	var events []models.ContentFilterEvent
+	if err := dbQuery.Order("created_at desc, id desc").
+		Offset((query.Page - 1) * query.PerPage).
+		Limit(query.PerPage).
+		Find(&events).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to fetch content filter events", err)
+	}
+
+	return events, total, nil
+}
+
+// getFilterList finds a list within the given scope
+func (s *ContentFilterService) getFilterList(listID uint, boardID *uint) (*models.ContentFilterList, error) {
+	query := s.db.Where("id = ?", listID)
+	if boardID != nil {
+		query = query.Where("board_id = ?", *boardID)
+	} else {
+		query = query.Where("board_id IS NULL")
+	}
+
+	var list models.ContentFilterList
+	if result := query.First(&list); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Content filter not found").
+				WithField("list_id", listID)
+		}
+		return nil, utils.NewInternalError("Failed to query content filter", result.Error).
+			WithField("list_id", listID)
+	}
+	return &list, nil
+}
+
+// joinFilterWords trims and de-duplicates words and joins them one per line
+func joinFilterWords(words []string) string {
+	seen := make(map[string]bool, len(words))
+	cleaned := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.Join(strings.Fields(word), " ")
+		key := strings.ToLower(word)
+		if word == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		cleaned = append(cleaned, word)
+	}
+	return strings.Join(cleaned, "\n")
+}
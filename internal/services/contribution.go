@@ -0,0 +1,368 @@
+package services
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"strings"
+	"time"
+)
+
+// ExpectedContributorStatus combines an expected contributor with their derived completion status
+type ExpectedContributorStatus struct {
+	models.ExpectedContributor
+	User          *models.User
+	PostCount     int64
+	FirstPostedAt *time.Time
+}
+
+// HasPosted reports whether the expected contributor has signed the board
+func (s ExpectedContributorStatus) HasPosted() bool {
+	return s.PostCount > 0
+}
+
+// CompletionSummary summarizes how many expected contributors have signed a board
+type CompletionSummary struct {
+	Board         *models.Board
+	Total         int
+	Completed     int
+	Pending       int
+	OptedOut      int
+	RemindersSent int
+}
+
+// ContributionService tracks who is expected to sign a board and reminds those who haven't
+type ContributionService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	boardService *BoardService
+	emailService *EmailService
+}
+
+// NewContributionService creates a new ContributionService
+func NewContributionService(db *gorm.DB, cfg *config.Config, boardService *BoardService, emailService *EmailService) *ContributionService {
+	return &ContributionService{
+		db:           db,
+		cfg:          cfg,
+		boardService: boardService,
+		emailService: emailService,
+	}
+}
+
+// AddExpectedContributors adds people expected to sign a board, optionally including its current contributors
+func (s *ContributionService) AddExpectedContributors(boardID, userID uint, input requests.AddExpectedContributorsRequest) ([]ExpectedContributorStatus, error) {
+	board, err := s.getManagedBoard(boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := input.Contributors
+
+	// Add everyone who already has access to the board except the creator
+	if input.IncludeBoardContributors {
+		var users []models.User
+		if err := s.db.Joins("JOIN board_contributors ON board_contributors.user_id = users.id").
+			Where("board_contributors.board_id = ? AND users.id <> ?", boardID, board.CreatorID).
+			Find(&users).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to fetch board contributors", err).
+				WithField("board_id", boardID)
+		}
+		for _, u := range users {
+			entries = append(entries, requests.ExpectedContributorEntry{Email: u.Email, Name: u.Name})
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, utils.NewBadRequestError("No contributors provided").
+			WithField("board_id", boardID)
+	}
+
+	expected := make([]models.ExpectedContributor, 0, len(entries))
+	for _, entry := range entries {
+		expected = append(expected, models.ExpectedContributor{
+			BoardID:     boardID,
+			Email:       strings.ToLower(strings.TrimSpace(entry.Email)),
+			Name:        strings.TrimSpace(entry.Name),
+			OptOutToken: uuid.New().String(),
+		})
+	}
+
+	// Ignore people who are already on the list
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "board_id"}, {Name: "email"}},
+		DoNothing: true,
+	}).Create(&expected).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to add expected contributors", err).
+			WithField("board_id", boardID)
+	}
+
+	return s.loadStatuses(boardID)
+}
+
+// ListExpectedContributors lists the people expected to sign a board with their completion status
+func (s *ContributionService) ListExpectedContributors(boardID, userID uint) ([]ExpectedContributorStatus, error) {
+	if _, err := s.getManagedBoard(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.loadStatuses(boardID)
+}
+
+// RemoveExpectedContributor removes a person from a board's expected contributor list
+func (s *ContributionService) RemoveExpectedContributor(boardID, userID, expectedID uint) error {
+	if _, err := s.getManagedBoard(boardID, userID); err != nil {
+		return err
+	}
+
+	result := s.db.Where("id = ? AND board_id = ?", expectedID, boardID).Delete(&models.ExpectedContributor{})
+	if result.Error != nil {
+		return utils.NewInternalError("Failed to remove expected contributor", result.Error).
+			WithField("board_id", boardID).
+			WithField("expected_id", expectedID)
+	}
+
+	if result.RowsAffected == 0 {
+		return utils.NewNotFoundError("Expected contributor not found").
+			WithField("board_id", boardID).
+			WithField("expected_id", expectedID)
+	}
+
+	return nil
+}
+
+// GetCompletionSummary summarizes how many expected contributors have signed a board
+func (s *ContributionService) GetCompletionSummary(boardID, userID uint) (*CompletionSummary, []ExpectedContributorStatus, error) {
+	board, err := s.getManagedBoard(boardID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statuses, err := s.loadStatuses(boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary := &CompletionSummary{
+		Board: board,
+		Total: len(statuses),
+	}
+
+	var pending []ExpectedContributorStatus
+	for _, status := range statuses {
+		summary.RemindersSent += status.ReminderCount
+		if status.OptedOut {
+			summary.OptedOut++
+		}
+		if status.HasPosted() {
+			summary.Completed++
+		} else {
+			summary.Pending++
+			pending = append(pending, status)
+		}
+	}
+
+	return summary, pending, nil
+}
+
+// OptOut stops contribution reminders for the expected contributor owning the token
+func (s *ContributionService) OptOut(token string, allBoards bool) error {
+	var expected models.ExpectedContributor
+	if result := s.db.Where("opt_out_token = ?", token).First(&expected); result.Error != nil {
+		return utils.NewNotFoundError("Invalid opt-out token")
+	}
+
+	query := s.db.Model(&models.ExpectedContributor{})
+	if allBoards {
+		query = query.Where("email = ?", expected.Email)
+	} else {
+		query = query.Where("id = ?", expected.ID)
+	}
+
+	if err := query.Update("opted_out", true).Error; err != nil {
+		return utils.NewInternalError("Failed to opt out of reminders", err).
+			WithField("expected_id", expected.ID)
+	}
+
+	return nil
+}
+
+// SendDueReminders emails expected contributors who haven't posted on boards nearing their delivery date
+func (s *ContributionService) SendDueReminders() error {
+	now := time.Now()
+
+	// Find boards whose delivery falls within the reminder window
+	var boards []models.Board
+	if err := s.db.Where("delivery_at > ? AND delivery_at <= ? AND enable_reminders = ? AND is_locked = ?",
+		now, now.Add(s.cfg.ReminderLeadTime), true, false).
+		Find(&boards).Error; err != nil {
+		return fmt.Errorf("failed to fetch boards due for reminders: %w", err)
+	}
+
+	var totalSent int
+	for i := range boards {
+		sent, err := s.sendBoardReminders(&boards[i], now)
+		if err != nil {
+			log.Error("Failed to send board reminders",
+				zap.Uint("board_id", boards[i].ID),
+				zap.Error(err))
+			continue
+		}
+		totalSent += sent
+	}
+
+	log.Info("Contribution reminder job completed",
+		zap.Int("boards", len(boards)),
+		zap.Int("reminders_sent", totalSent))
+
+	return nil
+}
+
+// sendBoardReminders sends reminders for a single board and returns how many were sent
+func (s *ContributionService) sendBoardReminders(board *models.Board, now time.Time) (int, error) {
+	statuses, err := s.loadStatuses(board.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := now.Add(-s.cfg.ReminderInterval)
+	var sent int
+	for _, status := range statuses {
+		if status.HasPosted() || status.OptedOut || status.ReminderCount >= s.cfg.ReminderMaxPerContributor {
+			continue
+		}
+		if status.LastRemindedAt != nil && status.LastRemindedAt.After(cutoff) {
+			continue
+		}
+
+		// Claim the reminder atomically so concurrent runs never nudge the same person twice
+		result := s.db.Model(&models.ExpectedContributor{}).
+			Where("id = ? AND reminder_count = ?", status.ID, status.ReminderCount).
+			Updates(map[string]interface{}{
+				"reminder_count":   gorm.Expr("reminder_count + 1"),
+				"last_reminded_at": now,
+			})
+		if result.Error != nil {
+			return sent, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		s.sendReminderEmail(board, &status.ExpectedContributor)
+		sent++
+	}
+
+	return sent, nil
+}
+
+// sendReminderEmail nudges an expected contributor to sign a board
+func (s *ContributionService) sendReminderEmail(board *models.Board, expected *models.ExpectedContributor) {
+	name := expected.Name
+	if name == "" {
+		name = "there"
+	}
+
+	subject := fmt.Sprintf("Reminder: sign \"%s\" before it's delivered", board.Title)
+	body := fmt.Sprintf(
+		"Hi %s,\n\n"+
+			"The board \"%s\" for %s will be delivered on %s and you haven't added a message yet.\n\n"+
+			"Add yours here: %s/boards/%s\n\n"+
+			"Don't want these reminders? %s/reminders/opt-out?token=%s\n",
+		name, board.Title, board.ReceiverName, board.DeliveryAt.Format("January 2, 2006"),
+		s.cfg.ClientURL, board.Slug, s.cfg.ClientURL, expected.OptOutToken,
+	)
+	s.emailService.SendAsync(expected.Email, subject, body)
+}
+
+// loadStatuses loads a board's expected contributors and derives whether each has posted
+func (s *ContributionService) loadStatuses(boardID uint) ([]ExpectedContributorStatus, error) {
+	var expected []models.ExpectedContributor
+	if err := s.db.Where("board_id = ?", boardID).Order("created_at asc, id asc").Find(&expected).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch expected contributors", err).
+			WithField("board_id", boardID)
+	}
+
+	if len(expected) == 0 {
+		return []ExpectedContributorStatus{}, nil
+	}
+
+	// Resolve accounts by email
+	emails := make([]string, len(expected))
+	for i, e := range expected {
+		emails[i] = e.Email
+	}
+
+	var users []models.User
+	if err := s.db.Where("LOWER(email) IN ?", emails).Find(&users).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch users", err).
+			WithField("board_id", boardID)
+	}
+
+	usersByEmail := make(map[string]*models.User, len(users))
+	userIDs := make([]uint, 0, len(users))
+	for i := range users {
+		usersByEmail[strings.ToLower(users[i].Email)] = &users[i]
+		userIDs = append(userIDs, users[i].ID)
+	}
+
+	// Count posts per author on this board
+	type authorStats struct {
+		AuthorID    uint
+		PostCount   int64
+		FirstPostAt time.Time
+	}
+	var stats []authorStats
+	if len(userIDs) > 0 {
+		if err := s.db.Model(&models.Post{}).
+			Select("author_id, COUNT(*) AS post_count, MIN(created_at) AS first_post_at").
+			Where("board_id = ? AND author_id IN ? AND status <> ?", boardID, userIDs, models.PostStatusRejected).
+			Group("author_id").
+			Scan(&stats).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to count posts", err).
+				WithField("board_id", boardID)
+		}
+	}
+
+	statsByAuthor := make(map[uint]authorStats, len(stats))
+	for _, st := range stats {
+		statsByAuthor[st.AuthorID] = st
+	}
+
+	statuses := make([]ExpectedContributorStatus, len(expected))
+	for i, e := range expected {
+		statuses[i].ExpectedContributor = e
+		if user, exists := usersByEmail[e.Email]; exists {
+			statuses[i].User = user
+			if st, posted := statsByAuthor[user.ID]; posted {
+				firstPostAt := st.FirstPostAt
+				statuses[i].PostCount = st.PostCount
+				statuses[i].FirstPostedAt = &firstPostAt
+			}
+		}
+	}
+
+	return statuses, nil
+}
+
+// getManagedBoard loads a board and checks that the user can manage it
+func (s *ContributionService) getManagedBoard(boardID, userID uint) (*models.Board, error) {
+	board, err := s.boardService.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.boardService.IsBoardAdmin(board, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to manage contributors for this board").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	return board, nil
+}
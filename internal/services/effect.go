@@ -0,0 +1,125 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"sort"
+	"strings"
+)
+
+// effectInput is an effect as sent by clients
+type effectInput struct {
+	Version *int                   `json:"version"`
+	Type    string                 `json:"type"`
+	Params  map[string]interface{} `json:"params"`
+}
+
+// ParseBoardEffect validates an effect sent by a client and fills in the default of every
+// parameter left out. The effect is an object with a type and parameters. A bare effect
+// name selects the effect with default parameters, and null, "" or "none" remove the effect.
+// Every invalid field is reported in the returned validation error.
+func ParseBoardEffect(raw json.RawMessage) (models.BoardEffect, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return models.BoardEffect{}, nil
+	}
+
+	// Older clients send the effect name, or the effect encoded as a JSON string
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "" || name == "none":
+			return models.BoardEffect{}, nil
+		case strings.HasPrefix(name, "{"):
+			return ParseBoardEffect(json.RawMessage(name))
+		}
+		raw, _ = json.Marshal(effectInput{Type: name})
+	}
+
+	var input effectInput
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&input); err != nil {
+		return models.BoardEffect{}, invalidEffectError(utils.FieldError{
+			Field:   "effect",
+			Message: "must be an object with a type and params",
+		})
+	}
+
+	if input.Version != nil && (*input.Version < 1 || *input.Version > models.EffectSchemaVersion) {
+		return models.BoardEffect{}, invalidEffectError(utils.FieldError{
+			Field:   "effect.version",
+			Message: fmt.Sprintf("must be %d, the current effect schema version", models.EffectSchemaVersion),
+		})
+	}
+
+	definition, exists := models.FindEffectDefinition(input.Type)
+	if !exists {
+		types := make([]string, len(models.EffectDefinitions))
+		for i, definition := range models.EffectDefinitions {
+			types[i] = definition.Type
+		}
+		return models.BoardEffect{}, invalidEffectError(utils.FieldError{
+			Field:   "effect.type",
+			Message: "must be one of " + strings.Join(types, ", "),
+		})
+	}
+
+	effect := models.BoardEffect{
+		Version: models.EffectSchemaVersion,
+		Type:    definition.Type,
+		Params:  make(map[string]interface{}, len(definition.Params)),
+	}
+
+	var fieldErrors []utils.FieldError
+	known := make(map[string]bool, len(definition.Params))
+	for _, param := range definition.Params {
+		known[param.Name] = true
+		value, provided := input.Params[param.Name]
+		if !provided || value == nil {
+			effect.Params[param.Name] = param.Default
+			continue
+		}
+
+		normalized, err := param.Normalize(value)
+		if err != nil {
+			fieldErrors = append(fieldErrors, utils.FieldError{
+				Field:   "effect.params." + param.Name,
+				Message: err.Error(),
+			})
+			continue
+		}
+		effect.Params[param.Name] = normalized
+	}
+
+	// Report unknown parameters in a stable order
+	var unknown []string
+	for name := range input.Params {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		fieldErrors = append(fieldErrors, utils.FieldError{
+			Field:   "effect.params." + name,
+			Message: "is not a parameter of the " + definition.Type + " effect",
+		})
+	}
+
+	if len(fieldErrors) > 0 {
+		return models.BoardEffect{}, invalidEffectError(fieldErrors...)
+	}
+
+	return effect, nil
+}
+
+// invalidEffectError creates the validation error for an invalid effect
+func invalidEffectError(fieldErrors ...utils.FieldError) error {
+	return utils.NewValidationError("The effect is invalid").
+		WithFieldErrors(fieldErrors...)
+}
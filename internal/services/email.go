@@ -0,0 +1,87 @@
+package services
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/log"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"strings"
+)
+
+// headerSanitizer strips line breaks so user-controlled values can't inject extra headers
+var headerSanitizer = strings.NewReplacer("\r", "", "\n", "")
+
+// EmailService handles sending transactional emails
+type EmailService struct {
+	cfg *config.Config
+}
+
+// NewEmailService creates a new EmailService
+func NewEmailService(cfg *config.Config) *EmailService {
+	return &EmailService{
+		cfg: cfg,
+	}
+}
+
+// IsEnabled reports whether an SMTP server is configured
+func (s *EmailService) IsEnabled() bool {
+	return s.cfg.SMTPHost != ""
+}
+
+// Send sends a plain text email. When no SMTP server is configured the email is logged instead.
+func (s *EmailService) Send(to, subject, body string) error {
+	// Header values may contain user input such as board titles
+	to = headerSanitizer.Replace(to)
+	subject = headerSanitizer.Replace(subject)
+
+	addr, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address %q: %w", to, err)
+	}
+	to = addr.Address
+
+	if !s.IsEnabled() {
+		log.Info("Email delivery disabled, skipping email",
+			zap.String("to", to),
+			zap.String("subject", subject))
+		return nil
+	}
+
+	// Build message with minimal headers
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", s.cfg.EmailFrom))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", to))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	// Authenticate only when credentials are provided
+	var auth smtp.Auth
+	if s.cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", s.cfg.SMTPUsername, s.cfg.SMTPPassword, s.cfg.SMTPHost)
+	}
+
+	server := s.cfg.SMTPHost + ":" + s.cfg.SMTPPort
+	if err := smtp.SendMail(server, auth, s.cfg.EmailFrom, []string{to}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// SendAsync sends an email in the background, logging any failure
+func (s *EmailService) SendAsync(to, subject, body string) {
+	go func() {
+		if err := s.Send(to, subject, body); err != nil {
+			log.Warn("Failed to send email",
+				zap.String("to", to),
+				zap.String("subject", subject),
+				zap.Error(err))
+		}
+	}()
+}
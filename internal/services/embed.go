@@ -0,0 +1,181 @@
+package services
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"html"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"net/url"
+	"strings"
+)
+
+// Default size of the iframe returned by the oEmbed endpoint
+const (
+	defaultEmbedWidth  = 800
+	defaultEmbedHeight = 600
+)
+
+// EmbedService handles embedding boards in other sites
+type EmbedService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	boardService *BoardService
+}
+
+// NewEmbedService creates a new EmbedService
+func NewEmbedService(db *gorm.DB, cfg *config.Config, boardService *BoardService) *EmbedService {
+	return &EmbedService{
+		db:           db,
+		cfg:          cfg,
+		boardService: boardService,
+	}
+}
+
+// OEmbed is the oEmbed description of a board
+type OEmbed struct {
+	Board   *models.Board
+	Creator *models.User
+	HTML    string
+	Width   int
+	Height  int
+}
+
+// GetEmbeddableBoard gets a board by slug for an embed. Private boards can only be embedded
+// for viewers with access, and boards with embedding disabled can't be embedded at all.
+func (s *EmbedService) GetEmbeddableBoard(slug string, viewerID uint) (*models.Board, *models.User, error) {
+	board, creator, _, err := s.boardService.GetBoardBySlug(slug, viewerID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !board.AllowEmbed {
+		return nil, nil, utils.NewForbiddenError("Embedding is disabled for this board").
+			WithField("board_id", board.ID)
+	}
+
+	if board.IsPrivate {
+		canAccess, err := s.boardService.CanAccessBoard(board.ID, viewerID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !canAccess {
+			return nil, nil, utils.NewForbiddenError("You don't have access to this board").
+				WithField("board_id", board.ID)
+		}
+	}
+
+	return board, creator, nil
+}
+
+// GetOEmbed describes the board at a board URL of the client app as an embeddable iframe.
+// The iframe is loaded without credentials, so private boards can't be embedded.
+func (s *EmbedService) GetOEmbed(boardURL string, maxWidth, maxHeight int) (*OEmbed, error) {
+	slug, ok := s.boardSlugFromURL(boardURL)
+	if !ok {
+		return nil, utils.NewNotFoundError("No board found at this URL").
+			WithField("url", boardURL)
+	}
+
+	board, creator, _, err := s.boardService.GetBoardBySlug(slug, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.IsPrivate {
+		return nil, utils.NewUnauthorizedError("Private boards can't be embedded").
+			WithField("board_id", board.ID)
+	}
+	if !board.AllowEmbed {
+		return nil, utils.NewForbiddenError("Embedding is disabled for this board").
+			WithField("board_id", board.ID)
+	}
+
+	width, height := fitEmbedSize(maxWidth, maxHeight)
+	return &OEmbed{
+		Board:   board,
+		Creator: creator,
+		HTML:    s.iframeHTML(board, width, height),
+		Width:   width,
+		Height:  height,
+	}, nil
+}
+
+// FrameAncestors returns the Content-Security-Policy frame-ancestors sources allowed to frame
+// a board. Only public boards with embedding enabled can be framed, by the client app and
+// the configured origins.
+func (s *EmbedService) FrameAncestors(board *models.Board) string {
+	if board == nil || board.IsPrivate || !board.AllowEmbed {
+		return "'none'"
+	}
+
+	sources := []string{"'self'"}
+	if origin, ok := urlOrigin(s.cfg.ClientURL); ok {
+		sources = append(sources, origin)
+	}
+	sources = append(sources, s.cfg.EmbedFrameAncestors...)
+	return strings.Join(sources, " ")
+}
+
+// BoardURL returns the URL of a board in the client app
+func (s *EmbedService) BoardURL(board *models.Board) string {
+	return strings.TrimRight(s.cfg.ClientURL, "/") + "/boards/" + url.PathEscape(board.Slug)
+}
+
+// WidgetURL returns the URL of the read-only widget of a board
+func (s *EmbedService) WidgetURL(board *models.Board) string {
+	return strings.TrimRight(s.cfg.APIURL, "/") + "/api/v1/embed/boards/" + url.PathEscape(board.Slug) + "/widget"
+}
+
+// boardSlugFromURL extracts the board slug from a board URL of the client app
+func (s *EmbedService) boardSlugFromURL(rawURL string) (string, bool) {
+	clientURL, err := url.Parse(s.cfg.ClientURL)
+	if err != nil {
+		return "", false
+	}
+	boardURL, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || !strings.EqualFold(boardURL.Host, clientURL.Host) {
+		return "", false
+	}
+	if boardURL.Scheme != "http" && boardURL.Scheme != "https" {
+		return "", false
+	}
+
+	prefix := strings.TrimRight(clientURL.Path, "/") + "/boards/"
+	slug, found := strings.CutPrefix(strings.TrimRight(boardURL.Path, "/"), prefix)
+	if !found || slug == "" || strings.Contains(slug, "/") {
+		return "", false
+	}
+	return slug, true
+}
+
+// iframeHTML returns the iframe that shows the widget of a board
+func (s *EmbedService) iframeHTML(board *models.Board, width, height int) string {
+	return fmt.Sprintf(
+		`<iframe src="%s" width="%d" height="%d" title="%s" frameborder="0" loading="lazy" `+
+			`sandbox="allow-popups allow-popups-to-escape-sandbox"></iframe>`,
+		html.EscapeString(s.WidgetURL(board)), width, height, html.EscapeString(board.Title),
+	)
+}
+
+// fitEmbedSize returns the default iframe size shrunk to the consumer's limits
+func fitEmbedSize(maxWidth, maxHeight int) (int, int) {
+	width, height := defaultEmbedWidth, defaultEmbedHeight
+	if maxWidth > 0 {
+		width = min(width, maxWidth)
+	}
+	if maxHeight > 0 {
+		height = min(height, maxHeight)
+	}
+	return width, height
+}
+
+// urlOrigin returns the scheme and host of a URL
+func urlOrigin(rawURL string) (string, bool) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", false
+	}
+	return parsed.Scheme + "://" + parsed.Host, true
+}
@@ -14,13 +14,15 @@ import (
 
 // File categories for organization
 const (
-	CategoryImage   = "image"
-	CategoryGif     = "gif"
-	CategoryVideo   = "video"
-	CategoryTheme   = "theme"
-	CategoryIcon    = "icon"
-	CategoryAvatar  = "avatar"
-	CategoryDefault = "general"
+	CategoryImage      = "image"
+	CategoryGif        = "gif"
+	CategoryVideo      = "video"
+	CategoryTheme      = "theme"
+	CategoryIcon       = "icon"
+	CategoryAvatar     = "avatar"
+	CategoryBackground = "background"
+	CategoryEmoji      = "emoji"
+	CategoryDefault    = "general"
 )
 
 // FileService handles file uploads independently of posts or themes
@@ -49,7 +51,7 @@ func (s *FileService) UploadFile(file *multipart.FileHeader, userID uint, catego
 	if category == "" {
 		category = CategoryDefault
 	} else if !isValidCategory(category) {
-		return nil, utils.NewBadRequestError("Invalid category. Allowed categories: image, gif, video, theme, icon, avatar, general").
+		return nil, utils.NewBadRequestError("Invalid category. Allowed categories: image, gif, video, theme, icon, avatar, background, emoji, general").
 			WithField("file_category", category)
 	}
 
@@ -68,9 +70,21 @@ func (s *FileService) UploadFile(file *multipart.FileHeader, userID uint, catego
 			WithField("file_ext", fileExt)
 	}
 
+	// Board backgrounds are still images
+	if category == CategoryBackground && fileType != "image" {
+		return nil, utils.NewBadRequestError("Unsupported background type. Allowed types: jpg, jpeg, png, webp").
+			WithField("file_ext", fileExt)
+	}
+
+	// Custom emoji are images, and may be animated
+	if category == CategoryEmoji && fileType == "video" {
+		return nil, utils.NewBadRequestError("Unsupported emoji type. Allowed types: jpg, jpeg, png, webp, gif").
+			WithField("file_ext", fileExt)
+	}
+
 	// Generate a unique directory path based on category and user
 	var dirPath string
-	if category == CategoryDefault || category == CategoryTheme || category == CategoryIcon {
+	if category == CategoryDefault || category == CategoryTheme || category == CategoryIcon || category == CategoryEmoji {
 		// Shared resources don't include user ID in the path
 		dirPath = fmt.Sprintf("%s", category)
 	} else if userID > 0 {
@@ -125,13 +139,15 @@ func (s *FileService) DeleteFile(filePath string) error {
 // isValidCategory check if category is valid
 func isValidCategory(category string) bool {
 	validCategories := map[string]bool{
-		CategoryImage:   true,
-		CategoryGif:     true,
-		CategoryVideo:   true,
-		CategoryTheme:   true,
-		CategoryIcon:    true,
-		CategoryAvatar:  true,
-		CategoryDefault: true,
+		CategoryImage:      true,
+		CategoryGif:        true,
+		CategoryVideo:      true,
+		CategoryTheme:      true,
+		CategoryIcon:       true,
+		CategoryAvatar:     true,
+		CategoryBackground: true,
+		CategoryEmoji:      true,
+		CategoryDefault:    true,
 	}
 
 	return validCategories[category]
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+)
+
+// maxFolderDepth limits how deeply board folders can be nested
+const maxFolderDepth = 5
+
+// FolderWithCount is a board folder together with the number of boards directly in it
+type FolderWithCount struct {
+	models.BoardFolder
+	BoardCount int64
+}
+
+// FolderService handles the folders users organize their boards in
+type FolderService struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+// NewFolderService creates a new FolderService
+func NewFolderService(db *gorm.DB, cfg *config.Config) *FolderService {
+	return &FolderService{
+		db:  db,
+		cfg: cfg,
+	}
+}
+
+// ListFolders lists all of a user's folders with their board counts
+func (s *FolderService) ListFolders(userID uint) ([]FolderWithCount, error) {
+	var folders []models.BoardFolder
+	if err := s.db.Where("user_id = ?", userID).Order("name asc").Find(&folders).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch folders", err).
+			WithField("user_id", userID)
+	}
+
+	// Count the user's active boards in each folder
+	type folderCount struct {
+		FolderID   uint
+		BoardCount int64
+	}
+	var counts []folderCount
+	if err := s.db.Model(&models.BoardContributor{}).
+		Select("board_contributors.folder_id, COUNT(*) AS board_count").
+		Joins("JOIN boards ON boards.id = board_contributors.board_id AND boards.deleted_at IS NULL").
+		Where("board_contributors.user_id = ? AND board_contributors.folder_id IS NOT NULL", userID).
+		Group("board_contributors.folder_id").
+		Scan(&counts).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to count folder boards", err).
+			WithField("user_id", userID)
+	}
+
+	countsByFolder := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		countsByFolder[count.FolderID] = count.BoardCount
+	}
+
+	result := make([]FolderWithCount, len(folders))
+	for i, folder := range folders {
+		result[i].BoardFolder = folder
+		result[i].BoardCount = countsByFolder[folder.ID]
+	}
+
+	return result, nil
+}
+
+// CountBoardsInFolder counts the user's active boards directly in a folder
+func (s *FolderService) CountBoardsInFolder(folderID, userID uint) int64 {
+	var count int64
+	s.db.Model(&models.BoardContributor{}).
+		Joins("JOIN boards ON boards.id = board_contributors.board_id AND boards.deleted_at IS NULL").
+		Where("board_contributors.user_id = ? AND board_contributors.folder_id = ?", userID, folderID).
+		Count(&count)
+	return count
+}
+
+// CreateFolder creates a folder, optionally inside another folder
+func (s *FolderService) CreateFolder(userID uint, input requests.CreateFolderRequest) (*models.BoardFolder, error) {
+	folder := models.BoardFolder{
+		UserID:   userID,
+		ParentID: input.ParentID,
+		Name:     input.Name,
+		Color:    input.Color,
+	}
+
+	if input.ParentID != nil {
+		folders, err := loadUserFolders(s.db, userID)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := folders[*input.ParentID]; !exists {
+			return nil, utils.NewNotFoundError("Parent folder not found").
+				WithField("parent_id", *input.ParentID)
+		}
+		if folderDepth(folders, *input.ParentID) >= maxFolderDepth {
+			return nil, utils.NewBadRequestError("Folders can't be nested this deeply").
+				WithField("parent_id", *input.ParentID)
+		}
+	}
+
+	if result := s.db.Create(&folder); result.Error != nil {
+		return nil, utils.NewInternalError("Failed to create folder", result.Error).
+			WithField("user_id", userID)
+	}
+
+	return &folder, nil
+}
+
+// UpdateFolder renames, recolors or moves a folder
+func (s *FolderService) UpdateFolder(folderID, userID uint, input requests.UpdateFolderRequest) (*models.BoardFolder, error) {
+	folders, err := loadUserFolders(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, exists := folders[folderID]
+	if !exists {
+		return nil, utils.NewNotFoundError("Folder not found").
+			WithField("folder_id", folderID)
+	}
+
+	if input.Name != nil {
+		folder.Name = *input.Name
+	}
+	if input.Color != nil {
+		folder.Color = *input.Color
+	}
+
+	if input.MoveToRoot {
+		folder.ParentID = nil
+	} else if input.ParentID != nil {
+		parentID := *input.ParentID
+		if _, exists := folders[parentID]; !exists {
+			return nil, utils.NewNotFoundError("Parent folder not found").
+				WithField("parent_id", parentID)
+		}
+
+		// A folder can't be moved into itself or one of its subfolders
+		for ancestor := folders[parentID]; ancestor != nil; {
+			if ancestor.ID == folderID {
+				return nil, utils.NewBadRequestError("A folder can't be moved into itself or one of its subfolders").
+					WithField("folder_id", folderID).
+					WithField("parent_id", parentID)
+			}
+			if ancestor.ParentID == nil {
+				break
+			}
+			ancestor = folders[*ancestor.ParentID]
+		}
+
+		if folderDepth(folders, parentID)+subtreeHeight(folders, folderID) > maxFolderDepth {
+			return nil, utils.NewBadRequestError("Folders can't be nested this deeply").
+				WithField("folder_id", folderID).
+				WithField("parent_id", parentID)
+		}
+
+		folder.ParentID = &parentID
+	}
+
+	if result := s.db.Save(folder); result.Error != nil {
+		return nil, utils.NewInternalError("Failed to update folder", result.Error).
+			WithField("folder_id", folderID)
+	}
+
+	return folder, nil
+}
+
+// DeleteFolder deletes a folder, moving its boards and subfolders to its parent
+func (s *FolderService) DeleteFolder(folderID, userID uint) error {
+	var folder models.BoardFolder
+	if result := s.db.Where("id = ? AND user_id = ?", folderID, userID).First(&folder); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return utils.NewNotFoundError("Folder not found").
+				WithField("folder_id", folderID)
+		}
+		return utils.NewInternalError("Failed to query folder", result.Error).
+			WithField("folder_id", folderID)
+	}
+
+	return utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Model(&models.BoardContributor{}).
+			Where("user_id = ? AND folder_id = ?", userID, folderID).
+			Update("folder_id", folder.ParentID).Error; err != nil {
+			return utils.NewInternalError("Failed to move folder boards", err).
+				WithField("folder_id", folderID)
+		}
+
+		if err := tx.Model(&models.BoardFolder{}).
+			Where("user_id = ? AND parent_id = ?", userID, folderID).
+			Update("parent_id", folder.ParentID).Error; err != nil {
+			return utils.NewInternalError("Failed to move subfolders", err).
+				WithField("folder_id", folderID)
+		}
+
+		if err := tx.Delete(&folder).Error; err != nil {
+			return utils.NewInternalError("Failed to delete folder", err).
+				WithField("folder_id", folderID)
+		}
+
+		return nil
+	})
+}
+
+// loadUserFolders loads all of a user's folders keyed by ID
+func loadUserFolders(db *gorm.DB, userID uint) (map[uint]*models.BoardFolder, error) {
+	var folders []models.BoardFolder
+	if err := db.Where("user_id = ?", userID).Find(&folders).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch folders", err).
+			WithField("user_id", userID)
+	}
+
+	byID := make(map[uint]*models.BoardFolder, len(folders))
+	for i := range folders {
+		byID[folders[i].ID] = &folders[i]
+	}
+	return byID, nil
+}
+
+// folderDepth returns how many levels deep a folder is, counting root folders as 1
+func folderDepth(folders map[uint]*models.BoardFolder, folderID uint) int {
+	depth := 0
+	for folder := folders[folderID]; folder != nil && depth <= maxFolderDepth; depth++ {
+		if folder.ParentID == nil {
+			return depth + 1
+		}
+		folder = folders[*folder.ParentID]
+	}
+	return depth
+}
+
+// subtreeHeight returns how many levels a folder and its subfolders span
+func subtreeHeight(folders map[uint]*models.BoardFolder, folderID uint) int {
+	height := 1
+	for _, folder := range folders {
+		if folder.ParentID != nil && *folder.ParentID == folderID {
+			if h := subtreeHeight(folders, folder.ID) + 1; h > height {
+				height = h
+			}
+		}
+	}
+	return height
+}
+
+// descendantFolderIDs returns a folder's ID together with the IDs of all its subfolders
+func descendantFolderIDs(folders map[uint]*models.BoardFolder, folderID uint) []uint {
+	ids := []uint{folderID}
+	for _, folder := range folders {
+		if folder.ParentID != nil && *folder.ParentID == folderID {
+			ids = append(ids, descendantFolderIDs(folders, folder.ID)...)
+		}
+	}
+	return ids
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
@@ -12,23 +14,107 @@ import (
 	"kudoboard-api/internal/services/storage"
 	"kudoboard-api/internal/utils"
 	"regexp"
+	"slices"
+	"time"
 )
 
+// contentFilterReviewReason is recorded on posts the content filter holds for review
+const contentFilterReviewReason = "Held for review by the content filter"
+
+// postReactionsCountSQL counts the reactions to each post in a query of posts
+const postReactionsCountSQL = "(SELECT COUNT(*) FROM post_reactions WHERE post_reactions.post_id = posts.id)"
+
+// postSortColumns maps the sort options of a board's post list to SQL
+var postSortColumns = map[string]string{
+	"position":   "posts.rank",
+	"created_at": "posts.created_at",
+	"likes":      postReactionsCountSQL,
+}
+
+// BoardPost is a post on a board with its author, reactions and comment count. LikesCount
+// counts every reaction and LikedByMe is whether the viewer reacted with a heart, as all
+// reactions used to be likes.
+type BoardPost struct {
+	models.Post
+	Author        *models.User `gorm:"-"`
+	LikesCount    int64
+	LikedByMe     bool                   `gorm:"-"`
+	Reactions     []models.ReactionCount `gorm:"-"`
+	MyReactions   []string               `gorm:"-"`
+	CommentsCount int64                  `gorm:"-"`
+}
+
+// PostPage is one page of a board's posts
+type PostPage struct {
+	Posts      []BoardPost
+	NextCursor string
+	HasMore    bool
+}
+
+// postCursor marks where a page of posts ended. It holds the sort order so a cursor
+// can't be used to continue a list sorted differently.
+type postCursor struct {
+	SortBy     string    `json:"s"`
+	Order      string    `json:"o"`
+	Rank       string    `json:"r,omitempty"`
+	CreatedAt  time.Time `json:"c,omitempty"`
+	LikesCount int64     `json:"l,omitempty"`
+	ID         uint      `json:"id"`
+}
+
+// value returns the value of the cursor's sort column
+func (c postCursor) value() interface{} {
+	switch c.SortBy {
+	case "created_at":
+		return c.CreatedAt
+	case "likes":
+		return c.LikesCount
+	default:
+		return c.Rank
+	}
+}
+
+// encodePostCursor encodes a cursor as an opaque URL-safe string
+func encodePostCursor(cursor postCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePostCursor decodes a cursor created by encodePostCursor
+func decodePostCursor(encoded string) (postCursor, error) {
+	var cursor postCursor
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, err
+	}
+	if cursor.ID == 0 {
+		return cursor, errors.New("cursor has no post ID")
+	}
+	return cursor, nil
+}
+
 // PostService handles post-related business logic
 type PostService struct {
-	db           *gorm.DB
-	storage      storage.StorageService
-	cfg          *config.Config
-	boardService *BoardService
+	db            *gorm.DB
+	storage       storage.StorageService
+	cfg           *config.Config
+	boardService  *BoardService
+	emailService  *EmailService
+	contentFilter *ContentFilterService
 }
 
 // NewPostService creates a new PostService
-func NewPostService(db *gorm.DB, storage storage.StorageService, cfg *config.Config, boardService *BoardService) *PostService {
+func NewPostService(db *gorm.DB, storage storage.StorageService, cfg *config.Config, boardService *BoardService, emailService *EmailService, contentFilter *ContentFilterService) *PostService {
 	return &PostService{
-		db:           db,
-		storage:      storage,
-		cfg:          cfg,
-		boardService: boardService,
+		db:            db,
+		storage:       storage,
+		cfg:           cfg,
+		boardService:  boardService,
+		emailService:  emailService,
+		contentFilter: contentFilter,
 	}
 }
 
@@ -77,16 +163,48 @@ func (s *PostService) CreatePost(boardID, userID uint, input requests.CreatePost
 		mediaPath = fmt.Sprintf("https://www.youtube.com/embed/%s", videoID)
 	}
 
+	// Place the post in the requested section, or in the default section
+	var sectionID *uint
+	if input.SectionID != nil {
+		var err error
+		sectionID, err = resolvePostSection(s.db, boardID, *input.SectionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create post
 	post := models.Post{
 		BoardID:         boardID,
+		SectionID:       sectionID,
 		Content:         input.Content,
+		ContentFormat:   models.PostContentPlain,
 		MediaPath:       mediaPath,
 		MediaType:       input.MediaType,
 		MediaSource:     input.MediaSource,
 		BackgroundColor: input.BackgroundColor,
 		TextColor:       input.TextColor,
 		Position:        0, // Will be updated in the transaction
+		Status:          models.PostStatusApproved,
+	}
+
+	if input.ContentFormat != "" {
+		post.ContentFormat = models.PostContentFormat(input.ContentFormat)
+	}
+
+	// Posts without their own colors take the board's card defaults
+	if card := board.Style.Card; card != nil {
+		if post.BackgroundColor == "" {
+			post.BackgroundColor = card.BackgroundColor
+		}
+		if post.TextColor == "" {
+			post.TextColor = card.TextColor
+		}
+	}
+
+	// Hold the post for review if the board requires approval
+	if board.RequireApproval && !s.boardService.CanModerateBoard(&board, userID) {
+		post.Status = models.PostStatusPending
 	}
 
 	// Set author details based on authentication status
@@ -102,8 +220,32 @@ func (s *PostService) CreatePost(boardID, userID uint, input requests.CreatePost
 		post.AuthorName = user.Name
 	}
 
+	// Check the message and anonymous author name against the content filter
+	filtered := []FilterField{{Name: "content", Value: &post.Content}}
+	if isAnonymous {
+		filtered = append(filtered, FilterField{Name: "author_name", Value: &post.AuthorName})
+	}
+	holdForReview, err := s.contentFilter.FilterText(boardID, userID, true, filtered...)
+	if err != nil {
+		return nil, err
+	}
+	if holdForReview {
+		post.Status = models.PostStatusPending
+		post.ModerationReason = contentFilterReviewReason
+	}
+
+	// Render the filtered content, so masked words stay masked in the HTML
+	post.ContentHTML = renderPostContent(post.Content, post.ContentFormat)
+
 	// Save post and update position in a transaction
-	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+	err = utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		// Rank the post after the board's last post
+		rank, err := nextPostRank(tx, boardID)
+		if err != nil {
+			return utils.NewInternalError("Failed to rank post", err)
+		}
+		post.Rank = rank
+
 		// Save the post first to get an ID
 		if result := tx.Create(&post).Error; result != nil {
 			return utils.NewInternalError("Failed to create post", result)
@@ -171,7 +313,8 @@ func (s *PostService) GetPostByID(postID uint) (*models.Post, error) {
 	return &post, nil
 }
 
-// UpdatePost updates a post
+// UpdatePost updates a post. When the content or media changes, the previous version is
+// kept as a revision and the post is marked as edited.
 func (s *PostService) UpdatePost(postID, userID uint, input requests.UpdatePostRequest) (*models.Post, error) {
 	// Find post
 	var post models.Post
@@ -211,13 +354,31 @@ func (s *PostService) UpdatePost(postID, userID uint, input requests.UpdatePostR
 		}
 	}
 
-	oldMediaPath := post.MediaPath
-	oldMediaSource := post.MediaSource
+	// Check the new message and author name against the content filter
+	holdForReview, err := s.contentFilter.FilterText(board.ID, userID, true,
+		FilterField{Name: "content", Value: input.Content},
+		FilterField{Name: "author_name", Value: input.AuthorName},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if holdForReview && post.Status == models.PostStatusApproved {
+		post.Status = models.PostStatusPending
+		post.ModerationReason = contentFilterReviewReason
+	}
+
+	previous := post
 
 	// Update fields if provided
 	if input.Content != nil {
 		post.Content = *input.Content
 	}
+	if input.ContentFormat != nil {
+		post.ContentFormat = models.PostContentFormat(*input.ContentFormat)
+	}
+	if input.Content != nil || input.ContentFormat != nil {
+		post.ContentHTML = renderPostContent(post.Content, post.ContentFormat)
+	}
 	if input.AuthorName != nil {
 		post.AuthorName = *input.AuthorName
 	}
@@ -244,25 +405,59 @@ func (s *PostService) UpdatePost(postID, userID uint, input requests.UpdatePostR
 		post.TextColor = *input.TextColor
 	}
 
-	// Save changes
-	if result := s.db.Save(&post); result.Error != nil {
-		return nil, utils.NewInternalError("Failed to update post", result.Error).
-			WithField("post_id", post.ID)
+	// Keep the previous version if the content or media changed. Replaced media files are
+	// kept with it so it can be restored, and deleted when the post is purged.
+	var revision *models.PostRevision
+	if post.Content != previous.Content || post.ContentFormat != previous.ContentFormat ||
+		post.MediaPath != previous.MediaPath || post.MediaType != previous.MediaType ||
+		post.MediaSource != previous.MediaSource {
+		revision = &models.PostRevision{
+			PostID:        post.ID,
+			EditorID:      userID,
+			Content:       previous.Content,
+			ContentFormat: previous.ContentFormat,
+			ContentHTML:   previous.ContentHTML,
+			MediaPath:     previous.MediaPath,
+			MediaType:     previous.MediaType,
+			MediaSource:   previous.MediaSource,
+		}
+		now := time.Now()
+		post.EditedAt = &now
+		post.EditedByID = &userID
+
+		// On boards that require approval, edited posts are reviewed again like new ones
+		if board.RequireApproval && post.Status == models.PostStatusApproved &&
+			!s.boardService.CanModerateBoard(&board, userID) {
+			post.Status = models.PostStatusPending
+			post.ModerationReason = ""
+		}
 	}
 
-	if oldMediaPath != "" && oldMediaPath != post.MediaPath && oldMediaSource == "internal" {
-		if err := s.storage.Delete(oldMediaPath); err != nil {
-			log.Warn("Failed to delete old media",
-				zap.Uint("post_id", postID),
-				zap.String("file_path", oldMediaPath),
-				zap.Error(err))
+	// Save changes
+	err = utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if revision != nil {
+			if err := tx.Create(revision).Error; err != nil {
+				return utils.NewInternalError("Failed to save post revision", err).
+					WithField("post_id", post.ID)
+			}
 		}
+
+		if err := tx.Save(&post).Error; err != nil {
+			return utils.NewInternalError("Failed to update post", err).
+				WithField("post_id", post.ID)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return &post, nil
 }
 
-// DeletePost deletes a post
+// DeletePost moves a post to the trash
 func (s *PostService) DeletePost(postID, userID uint) error {
 	// Find post
 	var post models.Post
@@ -309,124 +504,18 @@ func (s *PostService) DeletePost(postID, userID uint) error {
 		}
 	}
 
-	// Store media path for deletion after transaction
-	mediaPath := post.MediaPath
-	mediaSource := post.MediaSource
-
-	// Delete the post and its likes in a transaction
-	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
-		// Delete likes
-		if err := tx.Where("post_id = ?", postID).Delete(&models.PostLike{}).Error; err != nil {
-			return utils.NewInternalError("Failed to delete post likes", err).
-				WithField("post_id", postID)
-		}
-
-		// Delete post
-		if err := tx.Delete(&post).Error; err != nil {
-			return utils.NewInternalError("Failed to delete post", err).
-				WithField("post_id", postID)
-		}
-
-		return nil
-	})
-
-	if err != nil {
-		return err
-	}
-
-	if mediaPath != "" && mediaSource == "internal" {
-		if err := s.storage.Delete(mediaPath); err != nil {
-			log.Warn("Failed to delete media",
-				zap.Uint("post_id", postID),
-				zap.String("file_path", mediaPath),
-				zap.Error(err))
-		}
-	}
-
-	return nil
-}
-
-// LikePost adds a like to a post
-func (s *PostService) LikePost(postID, userID uint) (int64, error) {
-	// Find post
-	var post models.Post
-	if result := s.db.First(&post, postID); result.Error != nil {
-		return 0, utils.NewNotFoundError("Post not found").
-			WithField("post_id", postID)
-	}
-
-	// Get the board to check if it's locked
-	var board models.Board
-	if result := s.db.First(&board, post.BoardID); result.Error != nil {
-		return 0, utils.NewNotFoundError("Board not found").
-			WithField("post_id", postID)
-	}
-
-	// Check if board is locked
-	if board.IsLocked {
-		return 0, utils.NewForbiddenError("This board is locked and doesn't allow new likes").
-			WithField("post_id", postID)
-	}
-
-	// Check if user already liked the post
-	var existingLike models.PostLike
-	result := s.db.Where("post_id = ? AND user_id = ?", postID, userID).First(&existingLike)
-	if result.Error == nil {
-		return 0, utils.NewBadRequestError("You have already liked this post").
-			WithField("post_id", postID)
-	}
-
-	// Create like
-	like := models.PostLike{
-		PostID: postID,
-		UserID: userID,
-	}
-
-	// Save like
-	if result := s.db.Create(&like); result.Error != nil {
-		return 0, utils.NewInternalError("Failed to like post", result.Error).
-			WithField("post_id", postID)
-	}
-
-	// Count total likes
-	var likesCount int64
-	s.db.Model(&models.PostLike{}).Where("post_id = ?", postID).Count(&likesCount)
-
-	return likesCount, nil
-}
-
-// UnlikePost removes a like from a post
-func (s *PostService) UnlikePost(postID, userID uint) (int64, error) {
-	// Find post
-	var post models.Post
-	if result := s.db.First(&post, postID); result.Error != nil {
-		return 0, utils.NewNotFoundError("Post not found").
-			WithField("post_id", postID)
-	}
-
-	// Check if user has liked the post
-	var like models.PostLike
-	result := s.db.Where("post_id = ? AND user_id = ?", postID, userID).First(&like)
-	if result.Error != nil {
-		return 0, utils.NewBadRequestError("You have not liked this post").
-			WithField("post_id", postID)
-	}
-
-	// Delete like
-	if result := s.db.Delete(&like); result.Error != nil {
-		return 0, utils.NewInternalError("Failed to unlike post", result.Error).
+	// Move the post to the trash, keeping its media, reactions and comments until it is purged
+	if err := s.db.Delete(&post).Error; err != nil {
+		return utils.NewInternalError("Failed to delete post", err).
 			WithField("post_id", postID)
 	}
 
-	// Count total likes
-	var likesCount int64
-	s.db.Model(&models.PostLike{}).Where("post_id = ?", postID).Count(&likesCount)
-
-	return likesCount, nil
+	return nil
 }
 
-// ReorderPosts updates the order of posts on a board
-func (s *PostService) ReorderPosts(boardID, userID uint, postOrders []requests.PostPosition) error {
+// ReorderPosts updates the order of posts on a board. With a section, the posts are also
+// moved into that section.
+func (s *PostService) ReorderPosts(boardID, userID uint, sectionID *uint, postOrders []requests.PostPosition) error {
 	// Find board
 	var board models.Board
 	if result := s.db.First(&board, boardID); result.Error != nil {
@@ -454,9 +543,25 @@ func (s *PostService) ReorderPosts(boardID, userID uint, postOrders []requests.P
 		}
 	}
 
+	// Check the section before changing anything
+	updates := map[string]interface{}{}
+	if sectionID != nil {
+		storedSectionID, err := resolvePostSection(s.db, boardID, *sectionID)
+		if err != nil {
+			return err
+		}
+		updates["section_id"] = storedSectionID
+	}
+
 	// Start a transaction
 	tx := s.db.Begin()
 
+	// Bring positions in line with the current order first, as moves only change ranks
+	if err := renumberBoardPosts(tx, boardID, "rank asc, id asc"); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Update each post's position
 	for _, order := range postOrders {
 		// Verify post belongs to this board
@@ -468,14 +573,21 @@ func (s *PostService) ReorderPosts(boardID, userID uint, postOrders []requests.P
 				WithField("post_id", order.ID)
 		}
 
-		// Update position
-		if err := tx.Model(&post).Update("position", order.Position).Error; err != nil {
+		// Update position, and section if moving
+		updates["position"] = order.Position
+		if err := tx.Model(&post).Updates(updates).Error; err != nil {
 			tx.Rollback()
 			return utils.NewInternalError("Failed to reorder posts", err).
 				WithField("board_id", boardID)
 		}
 	}
 
+	// Rank the posts in their new order
+	if err := renumberBoardPosts(tx, boardID, "position asc, id asc"); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return utils.NewInternalError("Failed to reorder posts", err).
@@ -485,39 +597,156 @@ func (s *PostService) ReorderPosts(boardID, userID uint, postOrders []requests.P
 	return nil
 }
 
-// GetPostsForBoard gets all posts for a board
-func (s *PostService) GetPostsForBoard(boardID uint, page, perPage int, sortBy, order string) ([]models.Post, error) {
-	// Build query
-	query := s.db.Model(&models.Post{}).Where("board_id = ?", boardID)
+// ListBoardPosts gets a page of the posts on a board the viewer is allowed to see.
+// Pages are continued with the cursor of the previous page, so posts added meanwhile
+// don't shift the pages the way an offset would.
+func (s *PostService) ListBoardPosts(board *models.Board, viewerID uint, params requests.BoardPostsQuery) (*PostPage, error) {
+	sortColumn, exists := postSortColumns[params.SortBy]
+	if !exists {
+		sortColumn = postSortColumns["position"]
+		params.SortBy = "position"
+	}
+	if params.Order == "" {
+		params.Order = "asc"
+		if params.SortBy != "position" {
+			params.Order = "desc"
+		}
+	}
+	if params.Limit < 1 {
+		params.Limit = 20
+	}
 
-	// Add pagination
-	offset := (page - 1) * perPage
-	query = query.Offset(offset).Limit(perPage)
+	query := s.db.Model(&models.Post{}).Where("posts.board_id = ?", board.ID)
+	query = s.boardService.scopeVisiblePosts(query, board, viewerID)
+	query = filterPosts(query, params.AuthorID, params.MediaType)
 
-	// Add ordering
-	if sortBy == "" {
-		sortBy = "position"
+	// Limit the page to one section
+	if params.SectionID != 0 {
+		sectionID, err := resolvePostSection(s.db, board.ID, params.SectionID)
+		if err != nil {
+			return nil, err
+		}
+		if sectionID == nil {
+			query = query.Where("posts.section_id IS NULL")
+		} else {
+			query = query.Where("posts.section_id = ?", *sectionID)
+		}
 	}
-	if order == "" {
-		order = "asc"
+
+	// Continue after the last post of the previous page
+	if params.Cursor != "" {
+		cursor, err := decodePostCursor(params.Cursor)
+		if err != nil || cursor.SortBy != params.SortBy || cursor.Order != params.Order {
+			return nil, utils.NewBadRequestError("Invalid cursor").
+				WithField("cursor", params.Cursor)
+		}
+
+		comparison := ">"
+		if params.Order == "desc" {
+			comparison = "<"
+		}
+		query = query.Where("("+sortColumn+", posts.id) "+comparison+" (?, ?)", cursor.value(), cursor.ID)
+	}
+
+	// Load one post more than requested to know if there is another page
+	var posts []BoardPost
+	if err := query.
+		Select("posts.*, " + postReactionsCountSQL + " AS likes_count").
+		Order(sortColumn + " " + params.Order + ", posts.id " + params.Order).
+		Limit(params.Limit + 1).
+		Scan(&posts).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch posts", err).
+			WithField("board_id", board.ID)
+	}
+
+	page := &PostPage{}
+	if len(posts) > params.Limit {
+		posts = posts[:params.Limit]
+		last := posts[len(posts)-1]
+		page.HasMore = true
+		page.NextCursor = encodePostCursor(postCursor{
+			SortBy:     params.SortBy,
+			Order:      params.Order,
+			Rank:       last.Rank,
+			CreatedAt:  last.CreatedAt,
+			LikesCount: last.LikesCount,
+			ID:         last.ID,
+		})
+	}
+
+	if err := s.attachPostDetails(posts, viewerID); err != nil {
+		return nil, err
 	}
-	orderClause := sortBy + " " + order
-	query = query.Order(orderClause)
 
-	// Execute query
-	var posts []models.Post
-	if result := query.Find(&posts); result.Error != nil {
-		return nil, utils.NewInternalError("Failed to fetch posts", result.Error).
-			WithField("board_id", boardID)
+	page.Posts = posts
+	return page, nil
+}
+
+// LoadPostDetails loads the authors, reactions and comment counts of posts and which
+// reactions are the viewer's. It takes the same few queries however many posts there are.
+func (s *PostService) LoadPostDetails(posts []models.Post, viewerID uint) ([]BoardPost, error) {
+	boardPosts := make([]BoardPost, len(posts))
+	for i, post := range posts {
+		boardPosts[i].Post = post
 	}
 
-	return posts, nil
+	if err := s.attachPostDetails(boardPosts, viewerID); err != nil {
+		return nil, err
+	}
+
+	return boardPosts, nil
+}
+
+// attachPostDetails loads the authors, reactions and comment counts of posts and which
+// reactions are the viewer's
+func (s *PostService) attachPostDetails(posts []BoardPost, viewerID uint) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]uint, len(posts))
+	var authorIDs []uint
+	for i, post := range posts {
+		postIDs[i] = post.ID
+		if post.AuthorID != nil {
+			authorIDs = append(authorIDs, *post.AuthorID)
+		}
+	}
+
+	authors, err := loadUsersByID(s.db, authorIDs)
+	if err != nil {
+		return err
+	}
+
+	reactions, err := loadPostReactions(s.db, postIDs, viewerID)
+	if err != nil {
+		return err
+	}
+
+	commentCounts, err := loadCommentCounts(s.db, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		if posts[i].AuthorID != nil {
+			posts[i].Author = authors[*posts[i].AuthorID]
+		}
+		postReactions := reactions[posts[i].ID]
+		posts[i].LikesCount = postReactions.Total
+		posts[i].Reactions = postReactions.Counts
+		posts[i].MyReactions = postReactions.MyReactions
+		posts[i].LikedByMe = slices.Contains(postReactions.MyReactions, models.ReactionHeart)
+		posts[i].CommentsCount = commentCounts[posts[i].ID]
+	}
+
+	return nil
 }
 
-// CountPostsInBoard count all posts for a board
+// CountPostsInBoard count all approved posts for a board
 func (s *PostService) CountPostsInBoard(boardID uint) int64 {
 	// Build query
-	query := s.db.Model(&models.Post{}).Where("board_id = ?", boardID)
+	query := s.db.Model(&models.Post{}).Where("board_id = ? AND status = ?", boardID, models.PostStatusApproved)
 
 	// Count total posts
 	var total int64
@@ -526,21 +755,187 @@ func (s *PostService) CountPostsInBoard(boardID uint) int64 {
 	return total
 }
 
-// CountPostLikes counts the number of likes for a post
+// CountPendingPosts counts the posts on a board awaiting moderation
+func (s *PostService) CountPendingPosts(boardID uint) int64 {
+	var total int64
+	s.db.Model(&models.Post{}).Where("board_id = ? AND status = ?", boardID, models.PostStatusPending).Count(&total)
+	return total
+}
+
+// ListModerationQueue lists a board's posts with the given moderation status, oldest first
+func (s *PostService) ListModerationQueue(boardID, userID uint, status models.PostStatus) ([]models.Post, error) {
+	board, err := s.boardService.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user can moderate the board
+	if !s.boardService.CanModerateBoard(board, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to moderate this board").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	var posts []models.Post
+	if err := s.db.Where("board_id = ? AND status = ?", boardID, status).
+		Order("created_at asc").
+		Find(&posts).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch moderation queue", err).
+			WithField("board_id", boardID)
+	}
+
+	return posts, nil
+}
+
+// ModeratePost approves or rejects a post and notifies its author
+func (s *PostService) ModeratePost(postID, userID uint, status models.PostStatus, reason string) (*models.Post, error) {
+	// Find post
+	post, err := s.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := s.boardService.GetBoardByID(post.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user can moderate the board
+	if !s.boardService.CanModerateBoard(board, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to moderate this board").
+			WithField("board_id", board.ID).
+			WithField("user_id", userID)
+	}
+
+	// Posts hidden after abuse reports are reviewed by site admins
+	if post.Status == models.PostStatusHidden {
+		return nil, utils.NewForbiddenError("This post is hidden while it is reviewed by site administrators").
+			WithField("post_id", postID)
+	}
+
+	if post.Status == status {
+		return nil, utils.NewBadRequestError(fmt.Sprintf("Post is already %s", status)).
+			WithField("post_id", postID)
+	}
+
+	now := time.Now()
+	post.Status = status
+	post.ModerationReason = reason
+	post.ModeratedByID = &userID
+	post.ModeratedAt = &now
+
+	if err := s.db.Model(post).Updates(map[string]interface{}{
+		"status":            post.Status,
+		"moderation_reason": post.ModerationReason,
+		"moderated_by_id":   post.ModeratedByID,
+		"moderated_at":      post.ModeratedAt,
+	}).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to moderate post", err).
+			WithField("post_id", postID)
+	}
+
+	s.sendModerationEmail(board, post)
+
+	return post, nil
+}
+
+// sendModerationEmail tells a post's author whether their post was approved or rejected
+func (s *PostService) sendModerationEmail(board *models.Board, post *models.Post) {
+	// Anonymous authors can't be notified
+	if post.AuthorID == nil {
+		return
+	}
+
+	var author models.User
+	if result := s.db.First(&author, *post.AuthorID); result.Error != nil {
+		log.Warn("Failed to load post author for moderation email",
+			zap.Uint("post_id", post.ID),
+			zap.Error(result.Error))
+		return
+	}
+
+	var subject, body string
+	if post.Status == models.PostStatusApproved {
+		subject = fmt.Sprintf("Your message on \"%s\" was approved", board.Title)
+		body = fmt.Sprintf(
+			"Hi %s,\n\nYour message on \"%s\" has been approved and is now visible on the board.\n\n"+
+				"See it here: %s/boards/%s\n",
+			author.Name, board.Title, s.cfg.ClientURL, board.Slug,
+		)
+	} else {
+		subject = fmt.Sprintf("Your message on \"%s\" was not approved", board.Title)
+		body = fmt.Sprintf("Hi %s,\n\nYour message on \"%s\" was not approved by the board's organizers.\n",
+			author.Name, board.Title)
+		if post.ModerationReason != "" {
+			body += fmt.Sprintf("\nReason: %s\n", post.ModerationReason)
+		}
+	}
+
+	s.emailService.SendAsync(author.Email, subject, body)
+}
+
+// CountPostLikes counts the reactions to a post, which used to be likes
 func (s *PostService) CountPostLikes(postID uint) (int64, error) {
 	var count int64
-	if result := s.db.Model(&models.PostLike{}).Where("post_id = ?", postID).Count(&count); result.Error != nil {
-		return 0, utils.NewInternalError("Failed to count likes", result.Error).
+	if result := s.db.Model(&models.PostReaction{}).Where("post_id = ?", postID).Count(&count); result.Error != nil {
+		return 0, utils.NewInternalError("Failed to count reactions", result.Error).
 			WithField("post_id", postID)
 	}
 	return count, nil
 }
 
-// HasUserLikedPost checks if a user has liked a post
+// HasUserLikedPost checks if a user has reacted to a post with a heart
 func (s *PostService) HasUserLikedPost(postID, userID uint) (bool, error) {
-	var like models.PostLike
-	result := s.db.Where("post_id = ? AND user_id = ?", postID, userID).First(&like)
-	return result.Error == nil, nil
+	var count int64
+	if result := s.db.Model(&models.PostReaction{}).
+		Where("post_id = ? AND user_id = ? AND emoji = ?", postID, userID, models.ReactionHeart).
+		Count(&count); result.Error != nil {
+		return false, utils.NewInternalError("Failed to check reactions", result.Error).
+			WithField("post_id", postID)
+	}
+	return count > 0, nil
+}
+
+// filterPosts limits a query of posts to those by an author or with a media type.
+// The media type "none" matches posts without media.
+func filterPosts(query *gorm.DB, authorID uint, mediaType string) *gorm.DB {
+	if authorID != 0 {
+		query = query.Where("posts.author_id = ?", authorID)
+	}
+	switch mediaType {
+	case "":
+	case "none":
+		query = query.Where("COALESCE(posts.media_path, '') = ''")
+	default:
+		query = query.Where("posts.media_type = ?", mediaType)
+	}
+	return query
+}
+
+// loadUsersByID loads users by their IDs, keyed by ID
+func loadUsersByID(db *gorm.DB, userIDs []uint) (map[uint]*models.User, error) {
+	users := make(map[uint]*models.User, len(userIDs))
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	var found []models.User
+	if err := db.Where("id IN ?", userIDs).Find(&found).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to load users", err)
+	}
+	for i := range found {
+		users[found[i].ID] = &found[i]
+	}
+
+	return users, nil
+}
+
+// renderPostContent renders the content of a post as sanitized HTML in its format
+func renderPostContent(content string, format models.PostContentFormat) string {
+	if format == models.PostContentMarkdown {
+		return utils.RenderMarkdown(content)
+	}
+	return utils.RenderPlainText(content)
 }
 
 // Helper function to extract YouTube video ID from various URL formats
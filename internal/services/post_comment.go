@@ -0,0 +1,336 @@
+package services
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"time"
+)
+
+// Comment is a comment on a post with its author
+type Comment struct {
+	models.PostComment
+	Author *models.User
+}
+
+// CommentThread is a top-level comment with its replies, oldest first
+type CommentThread struct {
+	Comment
+	Replies []Comment
+}
+
+// ListComments gets a page of the top-level comments on a post, oldest first, each with
+// all of its replies
+func (s *PostService) ListComments(postID, viewerID uint, query requests.CommentsQuery) ([]CommentThread, int64, error) {
+	post, board, err := s.getCommentablePost(postID, viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if post.Status != models.PostStatusApproved &&
+		(post.AuthorID == nil || *post.AuthorID != viewerID) &&
+		!s.boardService.CanModerateBoard(board, viewerID) {
+		return nil, 0, utils.NewNotFoundError("Post not found").
+			WithField("post_id", postID)
+	}
+
+	dbQuery := s.db.Model(&models.PostComment{}).Where("post_id = ? AND parent_id IS NULL", postID)
+
+	var total int64
+	if err := dbQuery.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to count comments", err).
+			WithField("post_id", postID)
+	}
+
+	var parents []models.PostComment
+	if err := dbQuery.Order("created_at asc, id asc").
+		Offset((query.Page - 1) * query.PerPage).
+		Limit(query.PerPage).
+		Find(&parents).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to fetch comments", err).
+			WithField("post_id", postID)
+	}
+
+	parentIDs := make([]uint, len(parents))
+	for i, parent := range parents {
+		parentIDs[i] = parent.ID
+	}
+
+	var replies []models.PostComment
+	if len(parentIDs) > 0 {
+		if err := s.db.Where("parent_id IN ?", parentIDs).
+			Order("created_at asc, id asc").
+			Find(&replies).Error; err != nil {
+			return nil, 0, utils.NewInternalError("Failed to fetch replies", err).
+				WithField("post_id", postID)
+		}
+	}
+
+	// Load the authors of the comments and their replies together
+	comments, err := s.loadCommentAuthors(append(parents, replies...))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	threads := make([]CommentThread, len(parents))
+	threadIndex := make(map[uint]int, len(parents))
+	for i := range parents {
+		threads[i] = CommentThread{Comment: comments[i], Replies: []Comment{}}
+		threadIndex[parents[i].ID] = i
+	}
+	for _, reply := range comments[len(parents):] {
+		thread := &threads[threadIndex[*reply.ParentID]]
+		thread.Replies = append(thread.Replies, reply)
+	}
+
+	return threads, total, nil
+}
+
+// CreateComment comments on a post, or replies to a top-level comment when a parent is
+// given. Like posts, comments can't be added to locked boards and anonymous comments
+// need a board that allows them.
+func (s *PostService) CreateComment(postID, userID uint, input requests.CreateCommentRequest) (*Comment, error) {
+	post, board, err := s.getCommentablePost(postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only approved posts can be commented on
+	if post.Status != models.PostStatusApproved {
+		return nil, utils.NewBadRequestError("This post is awaiting moderation").
+			WithField("post_id", postID)
+	}
+
+	// Check if board is locked
+	if board.IsLocked {
+		return nil, utils.NewForbiddenError("This board is locked and doesn't allow new comments").
+			WithField("board_id", board.ID)
+	}
+
+	// Determine if this is an anonymous comment based on authentication
+	isAnonymous := userID == 0
+	if isAnonymous && !board.AllowAnonymous {
+		return nil, utils.NewForbiddenError("This board does not allow anonymous comments")
+	}
+
+	comment := models.PostComment{
+		PostID:  post.ID,
+		BoardID: board.ID,
+		Content: input.Content,
+	}
+
+	// Replies are only allowed to top-level comments on the same post
+	if input.ParentID != nil {
+		var parent models.PostComment
+		if result := s.db.First(&parent, *input.ParentID); result.Error != nil || parent.PostID != post.ID {
+			return nil, utils.NewNotFoundError("Comment not found").
+				WithField("comment_id", *input.ParentID)
+		}
+		if parent.ParentID != nil {
+			return nil, utils.NewBadRequestError("Replies can't be replied to").
+				WithField("comment_id", parent.ID)
+		}
+		comment.ParentID = &parent.ID
+	}
+
+	// Set author details based on authentication status
+	var author *models.User
+	if isAnonymous {
+		if input.AuthorName == "" {
+			return nil, utils.NewBadRequestError("Author name is required for anonymous comments")
+		}
+		comment.AuthorName = input.AuthorName
+	} else {
+		var user models.User
+		if result := s.db.First(&user, userID); result.Error != nil {
+			return nil, utils.NewInternalError("Failed to get user", result.Error)
+		}
+		comment.AuthorID = &userID
+		comment.AuthorName = user.Name
+		author = &user
+	}
+
+	// Comments aren't moderated, so content the filter would hold for review is rejected
+	filtered := []FilterField{{Name: "content", Value: &comment.Content}}
+	if isAnonymous {
+		filtered = append(filtered, FilterField{Name: "author_name", Value: &comment.AuthorName})
+	}
+	if _, err := s.contentFilter.FilterText(board.ID, userID, false, filtered...); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&comment).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to create comment", err).
+			WithField("post_id", postID)
+	}
+
+	return &Comment{PostComment: comment, Author: author}, nil
+}
+
+// UpdateComment changes the content of a comment. Only its author can edit it.
+func (s *PostService) UpdateComment(commentID, userID uint, input requests.UpdateCommentRequest) (*Comment, error) {
+	comment, board, err := s.getComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if board is locked
+	if board.IsLocked {
+		return nil, utils.NewForbiddenError("This board is locked and doesn't allow modifications").
+			WithField("board_id", board.ID)
+	}
+
+	if comment.AuthorID == nil || *comment.AuthorID != userID {
+		return nil, utils.NewForbiddenError("You don't have permission to update this comment").
+			WithField("comment_id", commentID).
+			WithField("user_id", userID)
+	}
+
+	content := input.Content
+	if _, err := s.contentFilter.FilterText(board.ID, userID, false,
+		FilterField{Name: "content", Value: &content},
+	); err != nil {
+		return nil, err
+	}
+
+	if content != comment.Content {
+		now := time.Now()
+		comment.Content = content
+		comment.EditedAt = &now
+		if err := s.db.Model(comment).Updates(map[string]interface{}{
+			"content":   comment.Content,
+			"edited_at": comment.EditedAt,
+		}).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to update comment", err).
+				WithField("comment_id", commentID)
+		}
+	}
+
+	authors, err := s.loadCommentAuthors([]models.PostComment{*comment})
+	if err != nil {
+		return nil, err
+	}
+
+	return &authors[0], nil
+}
+
+// DeleteComment deletes a comment together with its replies. Comments can be deleted by
+// their author and by the board's moderators.
+func (s *PostService) DeleteComment(commentID, userID uint) error {
+	comment, board, err := s.getComment(commentID)
+	if err != nil {
+		return err
+	}
+
+	// Check if board is locked
+	if board.IsLocked {
+		return utils.NewForbiddenError("This board is locked and doesn't allow modifications").
+			WithField("board_id", board.ID)
+	}
+
+	isAuthor := comment.AuthorID != nil && *comment.AuthorID == userID
+	if !isAuthor && !s.boardService.CanModerateBoard(board, userID) {
+		return utils.NewForbiddenError("You don't have permission to delete this comment").
+			WithField("comment_id", commentID).
+			WithField("user_id", userID)
+	}
+
+	if err := s.db.Where("id = ? OR parent_id = ?", comment.ID, comment.ID).
+		Delete(&models.PostComment{}).Error; err != nil {
+		return utils.NewInternalError("Failed to delete comment", err).
+			WithField("comment_id", commentID)
+	}
+
+	return nil
+}
+
+// getCommentablePost gets a post and its board, checking the viewer can open the board
+func (s *PostService) getCommentablePost(postID, viewerID uint) (*models.Post, *models.Board, error) {
+	post, err := s.GetPostByID(postID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	board, err := s.boardService.GetViewableBoard(post.BoardID, viewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return post, board, nil
+}
+
+// getComment gets a comment and its board. Comments on deleted posts can't be found.
+func (s *PostService) getComment(commentID uint) (*models.PostComment, *models.Board, error) {
+	var comment models.PostComment
+	if result := s.db.First(&comment, commentID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil, utils.NewNotFoundError("Comment not found").
+				WithField("comment_id", commentID)
+		}
+		return nil, nil, utils.NewInternalError("Failed to query comment", result.Error).
+			WithField("comment_id", commentID)
+	}
+
+	if _, err := s.GetPostByID(comment.PostID); err != nil {
+		return nil, nil, utils.NewNotFoundError("Comment not found").
+			WithField("comment_id", commentID)
+	}
+
+	board, err := s.boardService.GetBoardByID(comment.BoardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &comment, board, nil
+}
+
+// loadCommentAuthors loads the authors of comments, keeping their order
+func (s *PostService) loadCommentAuthors(postComments []models.PostComment) ([]Comment, error) {
+	var authorIDs []uint
+	for _, comment := range postComments {
+		if comment.AuthorID != nil {
+			authorIDs = append(authorIDs, *comment.AuthorID)
+		}
+	}
+
+	authors, err := loadUsersByID(s.db, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]Comment, len(postComments))
+	for i, comment := range postComments {
+		comments[i].PostComment = comment
+		if comment.AuthorID != nil {
+			comments[i].Author = authors[*comment.AuthorID]
+		}
+	}
+
+	return comments, nil
+}
+
+// loadCommentCounts counts the comments on posts, replies included, keyed by post ID
+func loadCommentCounts(db *gorm.DB, postIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		PostID uint
+		Count  int64
+	}
+	if err := db.Model(&models.PostComment{}).
+		Select("post_id, COUNT(*) AS count").
+		Where("post_id IN ?", postIDs).
+		Group("post_id").
+		Scan(&rows).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to count comments", err)
+	}
+	for _, row := range rows {
+		counts[row.PostID] = row.Count
+	}
+
+	return counts, nil
+}
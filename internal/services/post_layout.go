@@ -0,0 +1,305 @@
+package services
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"time"
+)
+
+// Bounds of the canvas and of the posts placed on it, in canvas units
+const (
+	canvasWidth       = 10000
+	canvasHeight      = 10000
+	minPostSize       = 50
+	maxPostSize       = 2000
+	maxPostRotation   = 180
+	maxPostZIndex     = 1000000
+	defaultPostWidth  = 300
+	defaultPostHeight = 300
+)
+
+// maxLayoutVersionSkew is how far ahead of the server's clock a layout version stamp may be.
+// Stamps further ahead are rejected, otherwise one change could win over all later ones.
+const maxLayoutVersionSkew = time.Minute
+
+// PostLayoutResult is the outcome of a layout change. When a newer change has already been
+// stored, Applied is false and Layout is the stored layout.
+type PostLayoutResult struct {
+	PostID  uint
+	Applied bool
+	Layout  models.PostLayout
+}
+
+// UpdatePostLayout moves, resizes or rotates a post on a canvas board
+func (s *PostService) UpdatePostLayout(postID, userID uint, input requests.UpdatePostLayoutRequest) (*PostLayoutResult, error) {
+	post, err := s.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := s.getCanvasBoard(post.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.canArrangePost(board, post, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to move this post").
+			WithField("post_id", postID).
+			WithField("user_id", userID)
+	}
+
+	// Check the new layout before storing it
+	if fieldErrors := validateLayoutVersion(input.Version, "", time.Now()); len(fieldErrors) > 0 {
+		return nil, utils.NewValidationError("The layout version is ahead of the server time").
+			WithFieldErrors(fieldErrors...)
+	}
+	layout := mergePostLayout(post.Layout, input)
+	if fieldErrors := validatePostLayout(layout, ""); len(fieldErrors) > 0 {
+		return nil, utils.NewValidationError("The layout is outside the canvas").
+			WithFieldErrors(fieldErrors...)
+	}
+
+	result, err := applyPostLayout(s.db, post.ID, layout, input.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// BatchUpdatePostLayouts applies the layout changes of several posts moved together, such as
+// a multi-select drag. Either every change is checked and stored or none is; changes that
+// lose to a newer stored layout are reported as not applied.
+func (s *PostService) BatchUpdatePostLayouts(boardID, userID uint, input requests.BatchUpdatePostLayoutsRequest) ([]PostLayoutResult, error) {
+	board, err := s.getCanvasBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the posts, which must all be on this board
+	postIDs := make([]uint, 0, len(input.Layouts))
+	seen := make(map[uint]bool, len(input.Layouts))
+	for _, update := range input.Layouts {
+		if seen[update.PostID] {
+			return nil, utils.NewBadRequestError("A post can only be moved once per batch").
+				WithField("post_id", update.PostID)
+		}
+		seen[update.PostID] = true
+		postIDs = append(postIDs, update.PostID)
+	}
+
+	var posts []models.Post
+	if err := s.db.Where("id IN ? AND board_id = ?", postIDs, boardID).Find(&posts).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch posts", err).
+			WithField("board_id", boardID)
+	}
+	postsByID := make(map[uint]*models.Post, len(posts))
+	for i := range posts {
+		postsByID[posts[i].ID] = &posts[i]
+	}
+
+	// Check every change before storing any of them
+	now := time.Now()
+	var versionErrors []utils.FieldError
+	for i, update := range input.Layouts {
+		versionErrors = append(versionErrors, validateLayoutVersion(update.Version, fmt.Sprintf("layouts[%d].", i), now)...)
+	}
+	if len(versionErrors) > 0 {
+		return nil, utils.NewValidationError("A layout version is ahead of the server time").
+			WithFieldErrors(versionErrors...)
+	}
+
+	layouts := make([]models.PostLayout, len(input.Layouts))
+	var fieldErrors []utils.FieldError
+	for i, update := range input.Layouts {
+		post, exists := postsByID[update.PostID]
+		if !exists {
+			return nil, utils.NewBadRequestError("Post does not belong to this board").
+				WithField("board_id", boardID).
+				WithField("post_id", update.PostID)
+		}
+		if !s.canArrangePost(board, post, userID) {
+			return nil, utils.NewForbiddenError("You don't have permission to move this post").
+				WithField("post_id", post.ID).
+				WithField("user_id", userID)
+		}
+
+		layouts[i] = mergePostLayout(post.Layout, update.UpdatePostLayoutRequest)
+		fieldErrors = append(fieldErrors, validatePostLayout(layouts[i], fmt.Sprintf("layouts[%d].", i))...)
+	}
+	if len(fieldErrors) > 0 {
+		return nil, utils.NewValidationError("A layout is outside the canvas").
+			WithFieldErrors(fieldErrors...)
+	}
+
+	results := make([]PostLayoutResult, len(input.Layouts))
+	err = utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		for i, update := range input.Layouts {
+			result, err := applyPostLayout(tx, update.PostID, layouts[i], update.Version)
+			if err != nil {
+				return err
+			}
+			results[i] = result
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// getCanvasBoard finds a board whose posts can be placed on its canvas
+func (s *PostService) getCanvasBoard(boardID uint) (*models.Board, error) {
+	board, err := s.boardService.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.LayoutMode != models.BoardLayoutCanvas {
+		return nil, utils.NewBadRequestError("Posts can only be placed on boards in canvas layout mode").
+			WithField("board_id", boardID)
+	}
+
+	if board.IsLocked {
+		return nil, utils.NewForbiddenError("This board is locked and doesn't allow moving posts").
+			WithField("board_id", boardID)
+	}
+
+	return board, nil
+}
+
+// canArrangePost reports whether a user may move a post: board admins can move any post
+// and signed in authors their own
+func (s *PostService) canArrangePost(board *models.Board, post *models.Post, userID uint) bool {
+	if post.AuthorID != nil && *post.AuthorID == userID {
+		return true
+	}
+	return s.boardService.IsBoardAdmin(board, userID)
+}
+
+// mergePostLayout applies the fields of a layout change to a post's current layout.
+// Posts that haven't been placed yet start at the default size.
+func mergePostLayout(current models.PostLayout, input requests.UpdatePostLayoutRequest) models.PostLayout {
+	layout := current
+	if layout.Version == 0 {
+		layout.Width = defaultPostWidth
+		layout.Height = defaultPostHeight
+	}
+
+	if input.X != nil {
+		layout.X = *input.X
+	}
+	if input.Y != nil {
+		layout.Y = *input.Y
+	}
+	if input.Width != nil {
+		layout.Width = *input.Width
+	}
+	if input.Height != nil {
+		layout.Height = *input.Height
+	}
+	if input.Rotation != nil {
+		layout.Rotation = *input.Rotation
+	}
+	if input.ZIndex != nil {
+		layout.ZIndex = *input.ZIndex
+	}
+
+	return layout
+}
+
+// validatePostLayout checks that a layout fits on the canvas. Field names are prefixed with
+// prefix so errors in a batch point at the change they belong to.
+func validatePostLayout(layout models.PostLayout, prefix string) []utils.FieldError {
+	var fieldErrors []utils.FieldError
+	add := func(field, message string) {
+		fieldErrors = append(fieldErrors, utils.FieldError{Field: prefix + field, Message: message})
+	}
+
+	if layout.Width < minPostSize || layout.Width > maxPostSize {
+		add("width", fmt.Sprintf("must be between %d and %d", minPostSize, maxPostSize))
+	}
+	if layout.Height < minPostSize || layout.Height > maxPostSize {
+		add("height", fmt.Sprintf("must be between %d and %d", minPostSize, maxPostSize))
+	}
+	if layout.X < 0 || layout.X+layout.Width > canvasWidth {
+		add("x", fmt.Sprintf("must keep the post within the canvas width of %d", canvasWidth))
+	}
+	if layout.Y < 0 || layout.Y+layout.Height > canvasHeight {
+		add("y", fmt.Sprintf("must keep the post within the canvas height of %d", canvasHeight))
+	}
+	if layout.Rotation < -maxPostRotation || layout.Rotation > maxPostRotation {
+		add("rotation", fmt.Sprintf("must be between %d and %d degrees", -maxPostRotation, maxPostRotation))
+	}
+	if layout.ZIndex < 0 || layout.ZIndex > maxPostZIndex {
+		add("z_index", fmt.Sprintf("must be between 0 and %d", maxPostZIndex))
+	}
+
+	return fieldErrors
+}
+
+// maxLayoutVersion is the newest layout version stamp accepted at the given time
+func maxLayoutVersion(now time.Time) int64 {
+	return now.Add(maxLayoutVersionSkew).UnixMilli()
+}
+
+// validateLayoutVersion checks that a layout version stamp isn't ahead of the server's clock
+// by more than maxLayoutVersionSkew
+func validateLayoutVersion(version *int64, prefix string, now time.Time) []utils.FieldError {
+	if version == nil || *version <= maxLayoutVersion(now) {
+		return nil
+	}
+	return []utils.FieldError{{
+		Field:   prefix + "version",
+		Message: fmt.Sprintf("must not be more than %s ahead of the server time", maxLayoutVersionSkew),
+	}}
+}
+
+// applyPostLayout stores a post's layout if it's the latest change. A change with a version
+// stamp only wins over older stamps; a change without one always wins and takes the next
+// version. Stored stamps ahead of the allowed skew are treated as stale, so a post can't stay
+// stuck behind one. The stored layout is returned either way.
+func applyPostLayout(db *gorm.DB, postID uint, layout models.PostLayout, version *int64) (PostLayoutResult, error) {
+	now := time.Now()
+	limit := maxLayoutVersion(now)
+
+	updates := map[string]interface{}{
+		"layout_x":        layout.X,
+		"layout_y":        layout.Y,
+		"layout_width":    layout.Width,
+		"layout_height":   layout.Height,
+		"layout_rotation": layout.Rotation,
+		"layout_z_index":  layout.ZIndex,
+	}
+
+	query := db.Model(&models.Post{}).Where("id = ?", postID)
+	if version != nil {
+		updates["layout_version"] = *version
+		query = query.Where("(layout_version < ? OR layout_version > ?)", *version, limit)
+	} else {
+		updates["layout_version"] = gorm.Expr("CASE WHEN layout_version > ? THEN ? ELSE layout_version + 1 END", limit, now.UnixMilli())
+	}
+
+	result := query.Updates(updates)
+	if result.Error != nil {
+		return PostLayoutResult{}, utils.NewInternalError("Failed to update post layout", result.Error).
+			WithField("post_id", postID)
+	}
+
+	var stored models.Post
+	if err := db.First(&stored, postID).Error; err != nil {
+		return PostLayoutResult{}, utils.NewInternalError("Failed to fetch post layout", err).
+			WithField("post_id", postID)
+	}
+
+	return PostLayoutResult{
+		PostID:  postID,
+		Applied: result.RowsAffected > 0,
+		Layout:  stored.Layout,
+	}, nil
+}
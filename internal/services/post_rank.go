@@ -0,0 +1,270 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"strings"
+)
+
+// Rank keys grow when posts are repeatedly moved into the same gap. Boards with keys longer
+// than postRankRebalanceLength are respaced by the rebalance job, and a move that would need
+// a key longer than maxPostRankLength respaces its board first.
+const (
+	postRankRebalanceLength = 12
+	maxPostRankLength       = 48
+)
+
+// postRenumberBatchSize is the number of posts updated per statement when respacing a board
+const postRenumberBatchSize = 500
+
+// MovePost moves a post next to other posts of its board. The post gets a rank between
+// those of its new neighbours, so no other post is changed. It joins the section of the
+// post it's placed next to.
+func (s *PostService) MovePost(postID, userID uint, input requests.MovePostRequest) (*models.Post, error) {
+	post, err := s.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := s.boardService.GetBoardByID(post.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.IsLocked {
+		return nil, utils.NewForbiddenError("This board is locked and doesn't allow reordering posts").
+			WithField("board_id", board.ID)
+	}
+
+	if !s.boardService.IsBoardAdmin(board, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to reorder posts on this board").
+			WithField("board_id", board.ID).
+			WithField("user_id", userID)
+	}
+
+	if (input.AfterID != nil && *input.AfterID == postID) || (input.BeforeID != nil && *input.BeforeID == postID) {
+		return nil, utils.NewBadRequestError("A post can't be moved next to itself").
+			WithField("post_id", postID)
+	}
+
+	err = utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		for respaced := false; ; respaced = true {
+			after, before, err := findMoveNeighbours(tx, post, input)
+			if err != nil {
+				return err
+			}
+
+			rank, ok := rankBetweenPosts(after, before)
+			if !ok {
+				if respaced {
+					return utils.NewInternalError("Failed to move post", errors.New("no rank between neighbours")).
+						WithField("post_id", postID)
+				}
+				// Respace the board's ranks to make room, then look for the neighbours again
+				if err := renumberBoardPosts(tx, post.BoardID, "rank asc, id asc"); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// Join the section of the post it was placed next to
+			neighbour := before
+			if input.AfterID != nil {
+				neighbour = after
+			}
+
+			if err := tx.Model(post).Updates(map[string]interface{}{
+				"rank":       rank,
+				"section_id": neighbour.SectionID,
+			}).Error; err != nil {
+				return utils.NewInternalError("Failed to move post", err).
+					WithField("post_id", postID)
+			}
+			return nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Reload the post to get its new rank and section
+	if err := s.db.First(post, post.ID).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to reload post", err)
+	}
+
+	return post, nil
+}
+
+// RebalancePostRanks respaces the ranks of boards whose keys have grown long, so later
+// moves get short keys again
+func (s *PostService) RebalancePostRanks() error {
+	var boardIDs []uint
+	if err := s.db.Unscoped().Model(&models.Post{}).
+		Where("length(rank) > ?", postRankRebalanceLength).
+		Distinct().
+		Pluck("board_id", &boardIDs).Error; err != nil {
+		return fmt.Errorf("failed to fetch boards to rebalance: %w", err)
+	}
+
+	var rebalanced int
+	for _, boardID := range boardIDs {
+		err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+			return renumberBoardPosts(tx, boardID, "rank asc, id asc")
+		})
+		if err != nil {
+			log.Error("Failed to rebalance post ranks",
+				zap.Uint("board_id", boardID),
+				zap.Error(err))
+			continue
+		}
+		rebalanced++
+	}
+
+	log.Info("Post rank rebalance job completed",
+		zap.Int("boards_rebalanced", rebalanced))
+
+	return nil
+}
+
+// findMoveNeighbours finds the posts a post is moved between. When only one of them is
+// given, the other is the post next to it in the board's current order.
+func findMoveNeighbours(tx *gorm.DB, post *models.Post, input requests.MovePostRequest) (after, before *models.Post, err error) {
+	if input.AfterID != nil {
+		if after, err = findBoardPost(tx, post.BoardID, *input.AfterID); err != nil {
+			return nil, nil, err
+		}
+	}
+	if input.BeforeID != nil {
+		if before, err = findBoardPost(tx, post.BoardID, *input.BeforeID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	switch {
+	case after != nil && before != nil:
+		if after.Rank > before.Rank || (after.Rank == before.Rank && after.ID > before.ID) {
+			return nil, nil, utils.NewBadRequestError("after_id must come before before_id").
+				WithField("after_id", after.ID).
+				WithField("before_id", before.ID)
+		}
+	case after != nil:
+		before, err = adjacentPost(tx.Where("(rank, id) > (?, ?)", after.Rank, after.ID).Order("rank asc, id asc"), post)
+	default:
+		after, err = adjacentPost(tx.Where("(rank, id) < (?, ?)", before.Rank, before.ID).Order("rank desc, id desc"), post)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return after, before, nil
+}
+
+// findBoardPost gets a post that must be on the given board
+func findBoardPost(tx *gorm.DB, boardID, postID uint) (*models.Post, error) {
+	var post models.Post
+	if err := tx.Where("id = ? AND board_id = ?", postID, boardID).First(&post).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewBadRequestError("Post does not belong to this board").
+				WithField("board_id", boardID).
+				WithField("post_id", postID)
+		}
+		return nil, utils.NewInternalError("Failed to fetch post", err).
+			WithField("post_id", postID)
+	}
+	return &post, nil
+}
+
+// adjacentPost gets the first post of an ordered query of a board's posts other than the
+// moved post, or nil at the end of the board
+func adjacentPost(query *gorm.DB, post *models.Post) (*models.Post, error) {
+	var adjacent models.Post
+	err := query.Where("board_id = ? AND id <> ?", post.BoardID, post.ID).First(&adjacent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, utils.NewInternalError("Failed to fetch post", err).
+			WithField("board_id", post.BoardID)
+	}
+	return &adjacent, nil
+}
+
+// rankBetweenPosts returns a rank between two posts, either of which may be nil at the
+// ends of the board. It fails when the posts share a rank or the key would be too long.
+func rankBetweenPosts(after, before *models.Post) (string, bool) {
+	var lower string
+	if after != nil {
+		lower = after.Rank
+	}
+	if before == nil {
+		return utils.RankAfter(lower), true
+	}
+
+	rank, err := utils.RankBetween(lower, before.Rank)
+	if err != nil || len(rank) > maxPostRankLength {
+		return "", false
+	}
+	return rank, true
+}
+
+// nextPostRank returns the rank of a post added after every post of a board, including
+// those in the trash so restored posts keep their place
+func nextPostRank(tx *gorm.DB, boardID uint) (string, error) {
+	var last string
+	if err := tx.Unscoped().Model(&models.Post{}).
+		Where("board_id = ?", boardID).
+		Select("COALESCE(MAX(rank), '')").
+		Scan(&last).Error; err != nil {
+		return "", err
+	}
+	return utils.RankAfter(last), nil
+}
+
+// renumberBoardPosts gives the posts of a board, in the given order, evenly spaced ranks and
+// consecutive positions. Only posts whose rank or position changes are written.
+func renumberBoardPosts(tx *gorm.DB, boardID uint, order string) error {
+	var posts []struct {
+		ID       uint
+		Rank     string
+		Position int
+	}
+	if err := tx.Unscoped().Model(&models.Post{}).
+		Select("id, rank, position").
+		Where("board_id = ?", boardID).
+		Order(order).
+		Scan(&posts).Error; err != nil {
+		return utils.NewInternalError("Failed to fetch board posts", err).
+			WithField("board_id", boardID)
+	}
+
+	ranks := utils.RankKeys(len(posts))
+	var values []string
+	var args []interface{}
+	for i, post := range posts {
+		if post.Rank == ranks[i] && post.Position == i+1 {
+			continue
+		}
+		values = append(values, "(?::bigint, ?, ?::bigint)")
+		args = append(args, post.ID, ranks[i], i+1)
+	}
+
+	for start := 0; start < len(values); start += postRenumberBatchSize {
+		end := min(start+postRenumberBatchSize, len(values))
+		if err := tx.Exec(
+			"UPDATE posts SET rank = v.rank, position = v.position FROM (VALUES "+strings.Join(values[start:end], ", ")+
+				") AS v(id, rank, position) WHERE posts.id = v.id",
+			args[start*3:end*3]...,
+		).Error; err != nil {
+			return utils.NewInternalError("Failed to renumber board posts", err).
+				WithField("board_id", boardID)
+		}
+	}
+
+	return nil
+}
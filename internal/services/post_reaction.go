@@ -0,0 +1,361 @@
+package services
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// customEmojiNamePattern matches the shortcodes custom emoji can have
+var customEmojiNamePattern = regexp.MustCompile(`^[a-z0-9_+-]{1,32}$`)
+
+// ReactionEmoji is an emoji posts can be reacted with: a standard emoji or an image added
+// by site admins
+type ReactionEmoji struct {
+	ID       uint   // Only set for custom emoji
+	Name     string // Shortcode without the colons
+	Emoji    string // Only set for standard emoji
+	ImageURL string // Only set for custom emoji
+}
+
+// PostReactions are the reactions to a post and which of them are the viewer's
+type PostReactions struct {
+	PostID      uint
+	Counts      []models.ReactionCount
+	MyReactions []string
+	Total       int64
+}
+
+// Reactor is a user who reacted to a post with an emoji
+type Reactor struct {
+	models.PostReaction
+	User *models.User
+}
+
+// ListReactionEmojis lists the emoji posts can be reacted with: the configured standard
+// emoji first, then the custom emoji by name
+func (s *PostService) ListReactionEmojis() ([]ReactionEmoji, error) {
+	emojis := s.standardReactionEmojis()
+
+	var custom []models.CustomEmoji
+	if err := s.db.Order("name asc").Find(&custom).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch custom emoji", err)
+	}
+	for _, emoji := range custom {
+		emojis = append(emojis, ReactionEmoji{
+			ID:       emoji.ID,
+			Name:     emoji.Name,
+			ImageURL: emoji.ImageURL,
+		})
+	}
+
+	return emojis, nil
+}
+
+// AddReaction reacts to a post with an emoji. A user can react with several emoji, but
+// only once with each.
+func (s *PostService) AddReaction(postID, userID uint, emoji string) (*PostReactions, error) {
+	emoji = strings.Trim(emoji, ":")
+
+	post, err := s.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only approved posts can be reacted to
+	if post.Status != models.PostStatusApproved {
+		return nil, utils.NewBadRequestError("This post is awaiting moderation").
+			WithField("post_id", postID)
+	}
+
+	board, err := s.boardService.GetViewableBoard(post.BoardID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if board.IsLocked {
+		return nil, utils.NewForbiddenError("This board is locked and doesn't allow new reactions").
+			WithField("post_id", postID)
+	}
+
+	if err := s.checkReactionEmoji(emoji); err != nil {
+		return nil, err
+	}
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.PostReaction{
+		PostID: postID,
+		UserID: userID,
+		Emoji:  emoji,
+	})
+	if result.Error != nil {
+		return nil, utils.NewInternalError("Failed to react to post", result.Error).
+			WithField("post_id", postID)
+	}
+	if result.RowsAffected == 0 {
+		return nil, utils.NewBadRequestError("You have already reacted to this post with this emoji").
+			WithField("post_id", postID).
+			WithField("emoji", emoji)
+	}
+
+	return s.GetPostReactions(postID, userID)
+}
+
+// RemoveReaction removes a user's reaction with an emoji from a post
+func (s *PostService) RemoveReaction(postID, userID uint, emoji string) (*PostReactions, error) {
+	emoji = strings.Trim(emoji, ":")
+
+	if _, err := s.GetPostByID(postID); err != nil {
+		return nil, err
+	}
+
+	result := s.db.Where("post_id = ? AND user_id = ? AND emoji = ?", postID, userID, emoji).
+		Delete(&models.PostReaction{})
+	if result.Error != nil {
+		return nil, utils.NewInternalError("Failed to remove reaction", result.Error).
+			WithField("post_id", postID)
+	}
+	if result.RowsAffected == 0 {
+		return nil, utils.NewBadRequestError("You have not reacted to this post with this emoji").
+			WithField("post_id", postID).
+			WithField("emoji", emoji)
+	}
+
+	return s.GetPostReactions(postID, userID)
+}
+
+// GetPostReactions counts the reactions to a post and finds the viewer's
+func (s *PostService) GetPostReactions(postID, viewerID uint) (*PostReactions, error) {
+	reactions, err := loadPostReactions(s.db, []uint{postID}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return reactions[postID], nil
+}
+
+// LikePost reacts to a post with a heart, which is what liking a post used to be.
+// It returns the post's number of reactions.
+func (s *PostService) LikePost(postID, userID uint) (int64, error) {
+	reactions, err := s.AddReaction(postID, userID, models.ReactionHeart)
+	if err != nil {
+		return 0, err
+	}
+	return reactions.Total, nil
+}
+
+// UnlikePost removes a user's heart from a post. It returns the post's number of reactions.
+func (s *PostService) UnlikePost(postID, userID uint) (int64, error) {
+	reactions, err := s.RemoveReaction(postID, userID, models.ReactionHeart)
+	if err != nil {
+		return 0, err
+	}
+	return reactions.Total, nil
+}
+
+// ListReactors lists the users who reacted to a post, optionally only with one emoji,
+// in the order they reacted
+func (s *PostService) ListReactors(postID, viewerID uint, query requests.ReactionsQuery) ([]Reactor, int64, error) {
+	post, err := s.GetPostByID(postID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := s.boardService.GetViewableBoard(post.BoardID, viewerID); err != nil {
+		return nil, 0, err
+	}
+	if post.Status != models.PostStatusApproved {
+		return nil, 0, utils.NewNotFoundError("Post not found").
+			WithField("post_id", postID)
+	}
+
+	dbQuery := s.db.Model(&models.PostReaction{}).Where("post_id = ?", postID)
+	if emoji := strings.Trim(query.Emoji, ":"); emoji != "" {
+		dbQuery = dbQuery.Where("emoji = ?", emoji)
+	}
+
+	var total int64
+	if err := dbQuery.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to count reactions", err).
+			WithField("post_id", postID)
+	}
+
+	var reactions []models.PostReaction
+	if err := dbQuery.Order("created_at asc, user_id asc").
+		Offset((query.Page - 1) * query.PerPage).
+		Limit(query.PerPage).
+		Find(&reactions).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to fetch reactions", err).
+			WithField("post_id", postID)
+	}
+
+	userIDs := make([]uint, len(reactions))
+	for i, reaction := range reactions {
+		userIDs[i] = reaction.UserID
+	}
+	users, err := loadUsersByID(s.db, userIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reactors := make([]Reactor, len(reactions))
+	for i, reaction := range reactions {
+		reactors[i] = Reactor{PostReaction: reaction, User: users[reaction.UserID]}
+	}
+
+	return reactors, total, nil
+}
+
+// CreateCustomEmoji adds an emoji image posts can be reacted with. The image must have been
+// uploaded with the emoji category.
+func (s *PostService) CreateCustomEmoji(userID uint, input requests.CreateCustomEmojiRequest) (*models.CustomEmoji, error) {
+	name := strings.ToLower(strings.Trim(input.Name, ":"))
+	if !customEmojiNamePattern.MatchString(name) {
+		return nil, utils.NewValidationError("The emoji name is invalid").
+			WithFieldErrors(utils.FieldError{Field: "name", Message: "must be 1 to 32 lowercase letters, digits, _, + or -"})
+	}
+	if _, exists := utils.EmojiForShortcode(name); exists {
+		return nil, utils.NewBadRequestError("A standard emoji already has this name").
+			WithField("name", name)
+	}
+
+	prefix := s.storage.GetURL(CategoryEmoji + "/")
+	if !strings.HasPrefix(input.ImageURL, prefix) || len(input.ImageURL) == len(prefix) || strings.Contains(input.ImageURL, "..") {
+		return nil, utils.NewValidationError("The emoji image is invalid").
+			WithFieldErrors(utils.FieldError{Field: "image_url", Message: "must be an image uploaded with the emoji category"})
+	}
+
+	emoji := models.CustomEmoji{
+		Name:      name,
+		ImageURL:  input.ImageURL,
+		CreatorID: userID,
+	}
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&emoji)
+	if result.Error != nil {
+		return nil, utils.NewInternalError("Failed to create emoji", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, utils.NewBadRequestError("An emoji with this name already exists").
+			WithField("name", name)
+	}
+
+	return &emoji, nil
+}
+
+// DeleteCustomEmoji removes a custom emoji together with every reaction made with it
+func (s *PostService) DeleteCustomEmoji(emojiID uint) error {
+	var emoji models.CustomEmoji
+	if err := s.db.First(&emoji, emojiID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.NewNotFoundError("Emoji not found").
+				WithField("emoji_id", emojiID)
+		}
+		return utils.NewInternalError("Failed to fetch emoji", err).
+			WithField("emoji_id", emojiID)
+	}
+
+	return utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Where("emoji = ?", emoji.Name).Delete(&models.PostReaction{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete reactions", err).
+				WithField("emoji_id", emojiID)
+		}
+		if err := tx.Delete(&emoji).Error; err != nil {
+			return utils.NewInternalError("Failed to delete emoji", err).
+				WithField("emoji_id", emojiID)
+		}
+		return nil
+	})
+}
+
+// standardReactionEmojis returns the configured standard emoji. Unknown shortcodes are
+// skipped, and the heart is always included since likes are hearts.
+func (s *PostService) standardReactionEmojis() []ReactionEmoji {
+	names := s.cfg.ReactionEmojis
+	if !slices.Contains(names, models.ReactionHeart) {
+		names = append([]string{models.ReactionHeart}, names...)
+	}
+
+	emojis := make([]ReactionEmoji, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		character, exists := utils.EmojiForShortcode(name)
+		if !exists || seen[name] {
+			continue
+		}
+		seen[name] = true
+		emojis = append(emojis, ReactionEmoji{Name: name, Emoji: character})
+	}
+	return emojis
+}
+
+// checkReactionEmoji checks that posts can be reacted with an emoji
+func (s *PostService) checkReactionEmoji(name string) error {
+	for _, emoji := range s.standardReactionEmojis() {
+		if emoji.Name == name {
+			return nil
+		}
+	}
+
+	var count int64
+	if err := s.db.Model(&models.CustomEmoji{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return utils.NewInternalError("Failed to check emoji", err)
+	}
+	if count == 0 {
+		return utils.NewBadRequestError("Posts can't be reacted to with this emoji").
+			WithField("emoji", name)
+	}
+	return nil
+}
+
+// loadPostReactions counts the reactions to posts by emoji and finds the viewer's, keyed by
+// post ID. Emoji are ordered by count, then by which was used first. It takes two queries
+// however many posts there are.
+func loadPostReactions(db *gorm.DB, postIDs []uint, viewerID uint) (map[uint]*PostReactions, error) {
+	reactions := make(map[uint]*PostReactions, len(postIDs))
+	for _, postID := range postIDs {
+		reactions[postID] = &PostReactions{
+			PostID:      postID,
+			Counts:      []models.ReactionCount{},
+			MyReactions: []string{},
+		}
+	}
+	if len(postIDs) == 0 {
+		return reactions, nil
+	}
+
+	var counts []struct {
+		PostID uint
+		Emoji  string
+		Count  int64
+	}
+	if err := db.Model(&models.PostReaction{}).
+		Select("post_id, emoji, COUNT(*) AS count").
+		Where("post_id IN ?", postIDs).
+		Group("post_id, emoji").
+		Order("post_id, count desc, MIN(created_at) asc, emoji").
+		Scan(&counts).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to count reactions", err)
+	}
+	for _, count := range counts {
+		postReactions := reactions[count.PostID]
+		postReactions.Counts = append(postReactions.Counts, models.ReactionCount{Emoji: count.Emoji, Count: count.Count})
+		postReactions.Total += count.Count
+	}
+
+	if viewerID != 0 {
+		var mine []models.PostReaction
+		if err := db.Where("user_id = ? AND post_id IN ?", viewerID, postIDs).
+			Order("created_at asc").
+			Find(&mine).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to load reactions", err)
+		}
+		for _, reaction := range mine {
+			postReactions := reactions[reaction.PostID]
+			postReactions.MyReactions = append(postReactions.MyReactions, reaction.Emoji)
+		}
+	}
+
+	return reactions, nil
+}
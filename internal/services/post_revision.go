@@ -0,0 +1,98 @@
+package services
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+)
+
+// Revision is an earlier version of a post with the user whose edit replaced it
+type Revision struct {
+	models.PostRevision
+	Editor *models.User
+}
+
+// ListPostRevisions lists the earlier versions of a post, newest first. Only the post's
+// author and the board's admins can see them.
+func (s *PostService) ListPostRevisions(postID, userID uint) ([]Revision, error) {
+	if _, err := s.getRevisablePost(postID, userID); err != nil {
+		return nil, err
+	}
+
+	var postRevisions []models.PostRevision
+	if err := s.db.Where("post_id = ?", postID).
+		Order("created_at desc, id desc").
+		Find(&postRevisions).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch post revisions", err).
+			WithField("post_id", postID)
+	}
+
+	editorIDs := make([]uint, len(postRevisions))
+	for i, revision := range postRevisions {
+		editorIDs[i] = revision.EditorID
+	}
+	editors, err := loadUsersByID(s.db, editorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]Revision, len(postRevisions))
+	for i, revision := range postRevisions {
+		revisions[i] = Revision{PostRevision: revision, Editor: editors[revision.EditorID]}
+	}
+
+	return revisions, nil
+}
+
+// RestorePostRevision brings back an earlier version of a post's content and media. It is
+// an edit like any other, so the current version is kept as a revision in turn.
+func (s *PostService) RestorePostRevision(postID, revisionID, userID uint) (*models.Post, error) {
+	if _, err := s.getRevisablePost(postID, userID); err != nil {
+		return nil, err
+	}
+
+	var revision models.PostRevision
+	if result := s.db.Where("post_id = ?", postID).First(&revision, revisionID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Revision not found").
+				WithField("post_id", postID).
+				WithField("revision_id", revisionID)
+		}
+		return nil, utils.NewInternalError("Failed to query post revision", result.Error).
+			WithField("revision_id", revisionID)
+	}
+
+	contentFormat := string(revision.ContentFormat)
+	return s.UpdatePost(postID, userID, requests.UpdatePostRequest{
+		Content:       &revision.Content,
+		ContentFormat: &contentFormat,
+		MediaPath:     &revision.MediaPath,
+		MediaType:     &revision.MediaType,
+		MediaSource:   &revision.MediaSource,
+	})
+}
+
+// getRevisablePost gets a post whose revisions the user can see: the post's author or
+// an admin of its board
+func (s *PostService) getRevisablePost(postID, userID uint) (*models.Post, error) {
+	post, err := s.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.AuthorID == nil || *post.AuthorID != userID {
+		board, err := s.boardService.GetBoardByID(post.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		if !s.boardService.IsBoardAdmin(board, userID) {
+			return nil, utils.NewForbiddenError("You don't have permission to see this post's revisions").
+				WithField("post_id", postID).
+				WithField("user_id", userID)
+		}
+	}
+
+	return post, nil
+}
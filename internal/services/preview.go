@@ -0,0 +1,516 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"hash/fnv"
+	"image"
+	"image/color"
+	_ "image/gif" // Register decoders for theme backgrounds and avatars
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/services/storage"
+	"kudoboard-api/internal/utils"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Board preview images are drawn at the size social networks show link previews at
+const (
+	PreviewImageWidth  = 1200
+	PreviewImageHeight = 630
+)
+
+const (
+	// previewLayoutVersion is part of every preview fingerprint; change it when the card
+	// layout changes so cached images are drawn again
+	previewLayoutVersion = 1
+
+	previewDirectory       = "preview"
+	previewMaxAvatars      = 5
+	previewMaxSourceBytes  = 10 << 20
+	previewMaxSourcePixels = 4096 * 4096
+	previewMargin          = 80
+	previewAvatarRadius    = 36
+)
+
+// previewImageHosts are the remote hosts, besides the app's own storage, that source images are
+// fetched from: where Google and Facebook serve the profile pictures users sign in with.
+// Subdomains are allowed too.
+var previewImageHosts = []string{
+	"googleusercontent.com",
+	"fbcdn.net",
+	"fbsbx.com",
+}
+
+// defaultPreviewColor is the card background for boards without a usable header color
+var defaultPreviewColor = color.NRGBA{R: 0x6a, G: 0x4c, B: 0x93, A: 255}
+
+// avatarColors are the backgrounds of avatars drawn from a contributor's initial
+var avatarColors = []color.NRGBA{
+	{R: 0xe6, G: 0x39, B: 0x46, A: 255},
+	{R: 0xf4, G: 0xa2, B: 0x61, A: 255},
+	{R: 0x2a, G: 0x9d, B: 0x8f, A: 255},
+	{R: 0x45, G: 0x7b, B: 0x9d, A: 255},
+	{R: 0x8a, G: 0x5c, B: 0xf6, A: 255},
+	{R: 0xe7, G: 0x6f, B: 0x51, A: 255},
+}
+
+// PreviewService draws and caches the social preview images of boards
+type PreviewService struct {
+	db           *gorm.DB
+	storage      storage.StorageService
+	cfg          *config.Config
+	boardService *BoardService
+	themeService *ThemeService
+	httpClient   *http.Client
+}
+
+// NewPreviewService creates a new PreviewService
+func NewPreviewService(db *gorm.DB, storage storage.StorageService, cfg *config.Config, boardService *BoardService, themeService *ThemeService) *PreviewService {
+	return &PreviewService{
+		db:           db,
+		storage:      storage,
+		cfg:          cfg,
+		boardService: boardService,
+		themeService: themeService,
+		httpClient: &http.Client{
+			Timeout: cfg.HTTPClientTimeout,
+			// Redirects must stay on the allowed hosts as well
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 5 {
+					return errors.New("too many redirects")
+				}
+				if !isPreviewImageHostAllowed(req.URL) {
+					return fmt.Errorf("redirect to %s is not allowed", req.URL.Host)
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// BoardOpenGraph is the social metadata of a board, as shown in link previews
+type BoardOpenGraph struct {
+	Title       string
+	Description string
+	URL         string
+	SiteName    string
+	ImageURL    string
+	ImageAlt    string
+}
+
+// previewContent is everything a preview image is drawn from
+type previewContent struct {
+	Board            *models.Board
+	Theme            *models.Theme
+	PostCount        int64
+	ContributorCount int64
+	Contributors     []models.User
+}
+
+// GetOpenGraph gets the social metadata of a board. Private boards get generic metadata
+// that reveals nothing about them.
+func (s *PreviewService) GetOpenGraph(slug string) (*BoardOpenGraph, error) {
+	board, _, _, err := s.boardService.GetBoardBySlug(slug, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	openGraph := &BoardOpenGraph{
+		URL:      strings.TrimRight(s.cfg.ClientURL, "/") + "/boards/" + url.PathEscape(board.Slug),
+		SiteName: "Kudoboard",
+	}
+
+	if board.IsPrivate {
+		openGraph.Title = "Kudoboard"
+		openGraph.Description = "This board is private. Sign in to view it."
+		return openGraph, nil
+	}
+
+	content, err := s.loadPreviewContent(board)
+	if err != nil {
+		return nil, err
+	}
+
+	openGraph.Title = board.Title
+	openGraph.Description = fmt.Sprintf("%s for %s. Add your message on Kudoboard.",
+		pluralize(content.PostCount, "message", "messages"), board.ReceiverName)
+	openGraph.ImageAlt = fmt.Sprintf("%s, a board for %s", board.Title, board.ReceiverName)
+
+	// The fingerprint in the image URL makes link previews fetch the new image after a change
+	openGraph.ImageURL = fmt.Sprintf("%s/api/v1/boards/slug/%s/preview.png?v=%s",
+		strings.TrimRight(s.cfg.APIURL, "/"), url.PathEscape(board.Slug), previewFingerprint(content)[:12])
+
+	return openGraph, nil
+}
+
+// GetPreviewImage gets the PNG preview image of a public board, drawing it again
+// if the board changed since it was cached
+func (s *PreviewService) GetPreviewImage(slug string) (io.ReadCloser, error) {
+	board, _, _, err := s.boardService.GetBoardBySlug(slug, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.IsPrivate {
+		return nil, utils.NewForbiddenError("Private boards have no preview image").
+			WithField("board_id", board.ID)
+	}
+
+	imageURL, err := s.ensurePreview(board)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := s.storage.Get(imageURL)
+	if err != nil {
+		return nil, utils.NewInternalError("Failed to read preview image", err).
+			WithField("board_id", board.ID)
+	}
+	return reader, nil
+}
+
+// ensurePreview returns the URL of an up-to-date preview image of a board
+func (s *PreviewService) ensurePreview(board *models.Board) (string, error) {
+	content, err := s.loadPreviewContent(board)
+	if err != nil {
+		return "", err
+	}
+	fingerprint := previewFingerprint(content)
+
+	var cached models.BoardPreview
+	result := s.db.Where("board_id = ?", board.ID).First(&cached)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return "", utils.NewInternalError("Failed to fetch preview image", result.Error).
+			WithField("board_id", board.ID)
+	}
+	if result.Error == nil && cached.Fingerprint == fingerprint {
+		return cached.ImageURL, nil
+	}
+
+	// Draw and store the new image
+	data, err := s.renderPreview(content)
+	if err != nil {
+		return "", utils.NewInternalError("Failed to draw preview image", err).
+			WithField("board_id", board.ID)
+	}
+
+	fileInfo, err := s.storage.SaveFromReader(bytes.NewReader(data), fmt.Sprintf("board-%d.png", board.ID), "image/png", previewDirectory)
+	if err != nil {
+		return "", utils.NewInternalError("Failed to store preview image", err).
+			WithField("board_id", board.ID)
+	}
+
+	preview := models.BoardPreview{
+		BoardID:     board.ID,
+		ImageURL:    fileInfo.URL,
+		Fingerprint: fingerprint,
+	}
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "board_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"image_url", "fingerprint", "updated_at"}),
+	}).Create(&preview).Error; err != nil {
+		_ = s.storage.Delete(fileInfo.URL)
+		return "", utils.NewInternalError("Failed to save preview image", err).
+			WithField("board_id", board.ID)
+	}
+
+	// Remove the image drawn before the board changed
+	if result.Error == nil && cached.ImageURL != fileInfo.URL {
+		if err := s.storage.Delete(cached.ImageURL); err != nil {
+			log.Warn("Failed to delete outdated preview image",
+				zap.Uint("board_id", board.ID),
+				zap.String("file_path", cached.ImageURL),
+				zap.Error(err))
+		}
+	}
+
+	return fileInfo.URL, nil
+}
+
+// loadPreviewContent loads the theme, post count and most recent contributors of a board
+func (s *PreviewService) loadPreviewContent(board *models.Board) (*previewContent, error) {
+	content := &previewContent{Board: board}
+
+	if board.ThemeID != nil {
+		if theme, err := s.themeService.GetThemeByID(*board.ThemeID); err == nil {
+			content.Theme = theme
+		}
+	}
+
+	if err := s.db.Model(&models.Post{}).
+		Where("board_id = ? AND status = ?", board.ID, models.PostStatusApproved).
+		Count(&content.PostCount).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to count board posts", err).
+			WithField("board_id", board.ID)
+	}
+
+	authors := s.db.Model(&models.Post{}).
+		Select("author_id, MAX(created_at) AS last_posted_at").
+		Where("board_id = ? AND status = ? AND author_id IS NOT NULL", board.ID, models.PostStatusApproved).
+		Group("author_id")
+
+	if err := s.db.Table("(?) AS authors", authors).Count(&content.ContributorCount).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to count board contributors", err).
+			WithField("board_id", board.ID)
+	}
+
+	if err := s.db.Model(&models.User{}).
+		Joins("JOIN (?) AS authors ON authors.author_id = users.id", authors).
+		Order("authors.last_posted_at DESC, users.id").
+		Limit(previewMaxAvatars).
+		Find(&content.Contributors).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch board contributors", err).
+			WithField("board_id", board.ID)
+	}
+
+	return content, nil
+}
+
+// previewFingerprint identifies everything shown on a board's preview image
+func previewFingerprint(content *previewContent) string {
+	type contributor struct {
+		ID      uint
+		Name    string
+		Picture string
+	}
+	fields := struct {
+		Version          int
+		Title            string
+		ReceiverName     string
+		HeaderColor      string
+		ShowHeaderColor  bool
+		Background       string
+		PostCount        int64
+		ContributorCount int64
+		Contributors     []contributor
+	}{
+		Version:          previewLayoutVersion,
+		Title:            content.Board.Title,
+		ReceiverName:     content.Board.ReceiverName,
+		HeaderColor:      content.Board.HeaderColor,
+		ShowHeaderColor:  content.Board.ShowHeaderColor,
+		PostCount:        content.PostCount,
+		ContributorCount: content.ContributorCount,
+	}
+	if content.Theme != nil {
+		fields.Background = content.Theme.BackgroundImageURL
+	}
+	for _, user := range content.Contributors {
+		fields.Contributors = append(fields.Contributors, contributor{ID: user.ID, Name: user.Name, Picture: user.ProfilePicture})
+	}
+
+	encoded, _ := json.Marshal(fields)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// renderPreview draws the preview card of a board as a PNG
+func (s *PreviewService) renderPreview(content *previewContent) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, PreviewImageWidth, PreviewImageHeight))
+	board := content.Board
+
+	// Background: the theme image under a dark overlay, or a gradient of the header color
+	var background image.Image
+	if content.Theme != nil {
+		background = s.loadImage(content.Theme.BackgroundImageURL)
+	}
+	if background != nil {
+		drawCover(img, img.Bounds(), background)
+		shadeVertical(img, 110, 210)
+	} else {
+		base := defaultPreviewColor
+		if headerColor, ok := parseHexColor(board.HeaderColor); ok && board.ShowHeaderColor {
+			base = headerColor
+		}
+		fillVerticalGradient(img, darken(base, 0.2), darken(base, 0.6))
+	}
+
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	faded := color.NRGBA{R: 255, G: 255, B: 255, A: 200}
+	textWidthLimit := PreviewImageWidth - 2*previewMargin
+
+	// Site name
+	drawTextShadowed(img, "KUDOBOARD", previewMargin, 64, 3, faded)
+
+	// Title, up to two lines
+	y := 140
+	titleScale := 8
+	titleLines := wrapText(toGlyphText(board.Title), textWidthLimit, titleScale, 2)
+	for _, line := range titleLines {
+		drawTextShadowed(img, line, previewMargin, y, titleScale, white)
+		y += (glyphHeight + 2) * titleScale
+	}
+
+	// Receiver name
+	receiverLines := wrapText("For "+toGlyphText(board.ReceiverName), textWidthLimit, 5, 1)
+	if len(receiverLines) > 0 {
+		drawTextShadowed(img, receiverLines[0], previewMargin, y+16, 5, faded)
+	}
+
+	// Contributor avatars and post count along the bottom
+	avatarY := PreviewImageHeight - previewMargin - previewAvatarRadius
+	x := previewMargin + previewAvatarRadius
+	for _, user := range content.Contributors {
+		s.drawAvatar(img, user, x, avatarY)
+		x += previewAvatarRadius + previewAvatarRadius/2
+	}
+	if extra := content.ContributorCount - int64(len(content.Contributors)); extra > 0 {
+		label := fmt.Sprintf("+%d", extra)
+		drawCircle(img, x, avatarY, previewAvatarRadius, nil, color.NRGBA{R: 40, G: 40, B: 40, A: 255}, white, 4)
+		drawText(img, label, x-textWidth(label, 3)/2, avatarY-glyphHeight*3/2+3, 3, white)
+		x += previewAvatarRadius + previewAvatarRadius/2
+	}
+
+	countX := previewMargin
+	if x > previewMargin+previewAvatarRadius {
+		countX = x + previewAvatarRadius/2
+	}
+	drawTextShadowed(img, pluralize(content.PostCount, "message", "messages"), countX, avatarY-7*4/2, 4, white)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// drawAvatar draws a contributor's profile picture, or their initial on a colored circle
+func (s *PreviewService) drawAvatar(img *image.RGBA, user models.User, cx, cy int) {
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	if picture := s.loadImage(user.ProfilePicture); picture != nil {
+		drawCircle(img, cx, cy, previewAvatarRadius, picture, white, white, 4)
+		return
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(user.Name))
+	fill := avatarColors[int(hash.Sum32()%uint32(len(avatarColors)))]
+	drawCircle(img, cx, cy, previewAvatarRadius, nil, fill, white, 4)
+
+	initial := strings.ToUpper(firstRune(toGlyphText(user.Name)))
+	if initial == "" {
+		initial = string(fallbackGlyph)
+	}
+	drawText(img, initial, cx-textWidth(initial, 5)/2, cy-7*5/2, 5, white)
+}
+
+// loadImage loads an image from the app's storage or an allowed avatar host. Images that can't
+// be loaded, or are too large to decode safely, are skipped.
+func (s *PreviewService) loadImage(imageURL string) image.Image {
+	if imageURL == "" {
+		return nil
+	}
+
+	reader, err := s.openImage(imageURL)
+	if err != nil {
+		log.Warn("Failed to load preview source image", zap.String("url", imageURL), zap.Error(err))
+		return nil
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, previewMaxSourceBytes))
+	if err != nil {
+		log.Warn("Failed to read preview source image", zap.String("url", imageURL), zap.Error(err))
+		return nil
+	}
+
+	// Check the dimensions before decoding, a small file can claim a huge image
+	imgConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		log.Warn("Failed to decode preview source image", zap.String("url", imageURL), zap.Error(err))
+		return nil
+	}
+	if imgConfig.Width <= 0 || imgConfig.Height <= 0 ||
+		int64(imgConfig.Width)*int64(imgConfig.Height) > previewMaxSourcePixels {
+		log.Warn("Preview source image is too large",
+			zap.String("url", imageURL),
+			zap.Int("width", imgConfig.Width),
+			zap.Int("height", imgConfig.Height))
+		return nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		log.Warn("Failed to decode preview source image", zap.String("url", imageURL), zap.Error(err))
+		return nil
+	}
+	return img
+}
+
+// openImage opens an image stored by the app or served by an allowed remote host.
+// Image URLs can be set by users, so nothing else is fetched.
+func (s *PreviewService) openImage(imageURL string) (io.ReadCloser, error) {
+	if s.isStoredImage(imageURL) {
+		return s.storage.Get(imageURL)
+	}
+
+	parsedURL, err := url.Parse(imageURL)
+	if err != nil || !isPreviewImageHostAllowed(parsedURL) {
+		return nil, errors.New("image host is not allowed")
+	}
+
+	resp, err := s.httpClient.Get(imageURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// isStoredImage reports whether a URL points to a file in the app's own storage
+func (s *PreviewService) isStoredImage(imageURL string) bool {
+	filePath, err := storage.ExtractPathFromURL(imageURL)
+	if err != nil || filePath == "" || strings.Contains(filePath, "..") || strings.Contains(filePath, "\\") {
+		return false
+	}
+	return s.storage.GetURL(filePath) == imageURL
+}
+
+// isPreviewImageHostAllowed reports whether a URL is served over HTTPS by one of previewImageHosts
+func isPreviewImageHostAllowed(imageURL *url.URL) bool {
+	if imageURL.Scheme != "https" || imageURL.User != nil || imageURL.Port() != "" {
+		return false
+	}
+
+	host := strings.ToLower(imageURL.Hostname())
+	for _, allowed := range previewImageHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// pluralize formats a count with the singular or plural form of a noun
+func pluralize(count int64, singular, plural string) string {
+	if count == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
+
+// firstRune returns the first character of a string
+func firstRune(text string) string {
+	for _, r := range text {
+		return string(r)
+	}
+	return ""
+}
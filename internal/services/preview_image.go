@@ -0,0 +1,262 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// typographicASCII maps common typographic characters to the ASCII characters they stand for
+var typographicASCII = map[rune]string{
+	'‘': "'", '’': "'", '‚': "'", '′': "'",
+	'“': "\"", '”': "\"", '„': "\"", '″': "\"",
+	'–': "-", '—': "-", '‐': "-", '−': "-",
+	'…': "...", '•': "*", '·': "*",
+	' ': " ", '\t': " ", '\n': " ", '\r': " ",
+}
+
+// toGlyphText folds text into the characters the bitmap font can draw: accents are removed,
+// typographic characters become their ASCII forms, symbols such as emoji are dropped and
+// any other character becomes fallbackGlyph.
+func toGlyphText(text string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(text) {
+		switch {
+		case unicode.IsMark(r) || unicode.Is(unicode.Cf, r):
+			continue
+		case r >= firstGlyph && r <= lastGlyph:
+			b.WriteRune(r)
+		case typographicASCII[r] != "":
+			b.WriteString(typographicASCII[r])
+		case unicode.IsSymbol(r) || unicode.IsControl(r):
+			continue
+		default:
+			b.WriteRune(fallbackGlyph)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// textWidth returns the width in pixels of text drawn at a scale, without trailing spacing
+func textWidth(text string, scale int) int {
+	if text == "" {
+		return 0
+	}
+	return (len(text)*glyphAdvance - 1) * scale
+}
+
+// drawText draws text with its top left corner at x, y. Each font pixel is drawn as a
+// scale by scale square. The text must already be folded with toGlyphText.
+func drawText(img *image.RGBA, text string, x, y, scale int, c color.Color) {
+	src := image.NewUniform(c)
+	for i := 0; i < len(text); i++ {
+		glyph := glyphs[fallbackGlyph-firstGlyph]
+		if text[i] >= firstGlyph && text[i] <= lastGlyph {
+			glyph = glyphs[text[i]-firstGlyph]
+		}
+
+		left := x + i*glyphAdvance*scale
+		for row, bits := range glyph {
+			for col := 0; col < glyphWidth; col++ {
+				if bits&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				pixel := image.Rect(left+col*scale, y+row*scale, left+(col+1)*scale, y+(row+1)*scale)
+				draw.Draw(img, pixel, src, image.Point{}, draw.Over)
+			}
+		}
+	}
+}
+
+// drawTextShadowed draws text over a soft shadow so it stays readable on photos
+func drawTextShadowed(img *image.RGBA, text string, x, y, scale int, c color.Color) {
+	offset := max(scale/3, 1)
+	drawText(img, text, x+offset, y+offset, scale, color.NRGBA{A: 140})
+	drawText(img, text, x, y, scale, c)
+}
+
+// wrapText splits text into at most maxLines lines that fit maxWidth pixels at a scale.
+// Text that doesn't fit ends with an ellipsis.
+func wrapText(text string, maxWidth, scale, maxLines int) []string {
+	maxChars := max((maxWidth/scale+1)/glyphAdvance, 4)
+
+	var lines []string
+	var current string
+	words := strings.Fields(text)
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+
+		// Break words longer than a line
+		if len(word) > maxChars {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			for len(word) > maxChars {
+				lines = append(lines, word[:maxChars])
+				word = word[maxChars:]
+			}
+		}
+
+		switch {
+		case current == "":
+			current = word
+		case len(current)+1+len(word) <= maxChars:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	if len(lines) <= maxLines {
+		return lines
+	}
+
+	lines = lines[:maxLines]
+	last := lines[maxLines-1]
+	if len(last) > maxChars-3 {
+		last = strings.TrimRight(last[:maxChars-3], " ")
+	}
+	lines[maxLines-1] = last + "..."
+	return lines
+}
+
+// fillVerticalGradient fills the image with a gradient from top to bottom
+func fillVerticalGradient(img *image.RGBA, top, bottom color.NRGBA) {
+	bounds := img.Bounds()
+	height := bounds.Dy()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		t := float64(y-bounds.Min.Y) / float64(max(height-1, 1))
+		row := color.NRGBA{
+			R: blendChannel(top.R, bottom.R, t),
+			G: blendChannel(top.G, bottom.G, t),
+			B: blendChannel(top.B, bottom.B, t),
+			A: 255,
+		}
+		draw.Draw(img, image.Rect(bounds.Min.X, y, bounds.Max.X, y+1), image.NewUniform(row), image.Point{}, draw.Src)
+	}
+}
+
+// shadeVertical darkens the image with black, from topAlpha at the top to bottomAlpha at the bottom
+func shadeVertical(img *image.RGBA, topAlpha, bottomAlpha uint8) {
+	bounds := img.Bounds()
+	black := image.NewUniform(color.Black)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		t := float64(y-bounds.Min.Y) / float64(max(bounds.Dy()-1, 1))
+		mask := image.NewUniform(color.Alpha{A: blendChannel(topAlpha, bottomAlpha, t)})
+		draw.DrawMask(img, image.Rect(bounds.Min.X, y, bounds.Max.X, y+1), black, image.Point{}, mask, image.Point{}, draw.Over)
+	}
+}
+
+// blendChannel mixes two color channels, t of the way from a to b
+func blendChannel(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+}
+
+// drawCover scales src to cover the rectangle r of img, cropping the overflow evenly.
+// Each destination pixel averages the source pixels it covers.
+func drawCover(img *image.RGBA, r image.Rectangle, src image.Image) {
+	srcBounds := src.Bounds()
+	if srcBounds.Empty() || r.Empty() {
+		return
+	}
+
+	// Pick the largest centered source area with the destination's aspect ratio
+	scale := max(float64(r.Dx())/float64(srcBounds.Dx()), float64(r.Dy())/float64(srcBounds.Dy()))
+	cropWidth := float64(r.Dx()) / scale
+	cropHeight := float64(r.Dy()) / scale
+	cropX := float64(srcBounds.Min.X) + (float64(srcBounds.Dx())-cropWidth)/2
+	cropY := float64(srcBounds.Min.Y) + (float64(srcBounds.Dy())-cropHeight)/2
+
+	for y := 0; y < r.Dy(); y++ {
+		sy0 := int(cropY + float64(y)/scale)
+		sy1 := max(int(cropY+float64(y+1)/scale), sy0+1)
+		for x := 0; x < r.Dx(); x++ {
+			sx0 := int(cropX + float64(x)/scale)
+			sx1 := max(int(cropX+float64(x+1)/scale), sx0+1)
+
+			var red, green, blue, alpha, count uint32
+			for sy := sy0; sy < sy1 && sy < srcBounds.Max.Y; sy++ {
+				for sx := sx0; sx < sx1 && sx < srcBounds.Max.X; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					red, green, blue, alpha = red+cr, green+cg, blue+cb, alpha+ca
+					count++
+				}
+			}
+			if count == 0 {
+				continue
+			}
+			img.Set(r.Min.X+x, r.Min.Y+y, color.RGBA64{
+				R: uint16(red / count),
+				G: uint16(green / count),
+				B: uint16(blue / count),
+				A: uint16(alpha / count),
+			})
+		}
+	}
+}
+
+// drawCircle draws src scaled into a circle of radius r centered on cx, cy with a ring
+// around it. Without src, the circle is filled with the fill color.
+func drawCircle(img *image.RGBA, cx, cy, r int, src image.Image, fill, ring color.NRGBA, ringWidth int) {
+	// Scale the source into a square first, then copy the pixels inside the circle
+	var square *image.RGBA
+	if src != nil {
+		square = image.NewRGBA(image.Rect(0, 0, 2*r, 2*r))
+		drawCover(square, square.Bounds(), src)
+	}
+
+	outer := r + ringWidth
+	for y := -outer; y < outer; y++ {
+		for x := -outer; x < outer; x++ {
+			// Sample the pixel center to decide what covers it
+			dx, dy := float64(x)+0.5, float64(y)+0.5
+			distance := dx*dx + dy*dy
+			switch {
+			case distance <= float64(r*r):
+				c := color.Color(fill)
+				if square != nil {
+					c = square.At(x+r, y+r)
+				}
+				img.Set(cx+x, cy+y, c)
+			case distance <= float64(outer*outer):
+				img.Set(cx+x, cy+y, ring)
+			}
+		}
+	}
+}
+
+// parseHexColor parses a color like #abc or #aabbcc
+func parseHexColor(value string) (color.NRGBA, bool) {
+	hex := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return color.NRGBA{}, false
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	return color.NRGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}, true
+}
+
+// darken scales a color towards black by a factor from 0 to 1
+func darken(c color.NRGBA, factor float64) color.NRGBA {
+	return color.NRGBA{
+		R: uint8(float64(c.R) * (1 - factor)),
+		G: uint8(float64(c.G) * (1 - factor)),
+		B: uint8(float64(c.B) * (1 - factor)),
+		A: c.A,
+	}
+}
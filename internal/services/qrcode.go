@@ -0,0 +1,528 @@
+package services
+
+import (
+	"errors"
+)
+
+// QR code error correction levels, from the least to the most redundant
+type QRErrorCorrection int
+
+const (
+	QRErrorCorrectionLow      QRErrorCorrection = iota // recovers about 7% of the code
+	QRErrorCorrectionMedium                            // recovers about 15% of the code
+	QRErrorCorrectionQuartile                          // recovers about 25% of the code
+	QRErrorCorrectionHigh                              // recovers about 30% of the code
+)
+
+// ParseQRErrorCorrection parses an error correction level written as L, M, Q or H
+func ParseQRErrorCorrection(level string) (QRErrorCorrection, bool) {
+	switch level {
+	case "L", "l":
+		return QRErrorCorrectionLow, true
+	case "M", "m":
+		return QRErrorCorrectionMedium, true
+	case "Q", "q":
+		return QRErrorCorrectionQuartile, true
+	case "H", "h":
+		return QRErrorCorrectionHigh, true
+	}
+	return 0, false
+}
+
+// formatBits returns the two bits that identify the level in the format information
+func (level QRErrorCorrection) formatBits() int {
+	return [...]int{1, 0, 3, 2}[level]
+}
+
+// Versions supported by the encoder. Version v is 17+4v modules wide.
+const (
+	qrMinVersion = 1
+	qrMaxVersion = 40
+)
+
+// qrQuietZone is the number of light modules required around a QR code
+const qrQuietZone = 4
+
+// errQRDataTooLong is returned when data doesn't fit in the largest QR code
+var errQRDataTooLong = errors.New("data too long for a QR code")
+
+// qrECCodewordsPerBlock is the number of error correction codewords in each block, by level and version
+var qrECCodewordsPerBlock = [4][qrMaxVersion + 1]int{
+	{0, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{0, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{0, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// qrBlocks is the number of error correction blocks, by level and version
+var qrBlocks = [4][qrMaxVersion + 1]int{
+	{0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{0, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{0, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// QRCode is an encoded QR code, a square of dark and light modules
+type QRCode struct {
+	Size    int
+	modules [][]bool
+	// reserved marks the modules of function patterns, which data and masks skip
+	reserved [][]bool
+}
+
+// Dark reports whether the module at column x and row y is dark. Modules outside the
+// code are light, so callers can draw the quiet zone with the same loop.
+func (q *QRCode) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= q.Size || y >= q.Size {
+		return false
+	}
+	return q.modules[y][x]
+}
+
+// EncodeQR encodes data in byte mode into the smallest QR code that holds it at the
+// error correction level, choosing the mask with the lowest penalty
+func EncodeQR(data []byte, level QRErrorCorrection) (*QRCode, error) {
+	// Find the smallest version with room for the data
+	version := qrMinVersion
+	for ; version <= qrMaxVersion; version++ {
+		if qrBitsNeeded(len(data), version) <= qrDataCodewords(version, level)*8 {
+			break
+		}
+	}
+	if version > qrMaxVersion {
+		return nil, errQRDataTooLong
+	}
+
+	codewords := qrAddErrorCorrection(qrDataBits(data, version, level), version, level)
+
+	size := 17 + 4*version
+	code := &QRCode{Size: size, modules: make([][]bool, size), reserved: make([][]bool, size)}
+	for y := range code.modules {
+		code.modules[y] = make([]bool, size)
+		code.reserved[y] = make([]bool, size)
+	}
+	code.drawFunctionPatterns(version, level)
+	code.drawCodewords(codewords)
+
+	// Keep the mask whose result is easiest to scan
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		code.applyMask(mask)
+		code.drawFormatBits(level, mask)
+		if penalty := code.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		code.applyMask(mask)
+	}
+	code.applyMask(bestMask)
+	code.drawFormatBits(level, bestMask)
+
+	return code, nil
+}
+
+// qrCountBits returns the size of the byte mode character count field
+func qrCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// qrBitsNeeded returns the bits needed for a byte mode segment of n bytes
+func qrBitsNeeded(n, version int) int {
+	if n >= 1<<qrCountBits(version) {
+		return 1 << 30
+	}
+	return 4 + qrCountBits(version) + 8*n
+}
+
+// qrRawDataModules returns the number of modules available for codewords in a version
+func qrRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		result -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// qrDataCodewords returns the number of data codewords in a version at a level
+func qrDataCodewords(version int, level QRErrorCorrection) int {
+	return qrRawDataModules(version)/8 - qrECCodewordsPerBlock[level][version]*qrBlocks[level][version]
+}
+
+// qrDataBits builds the data codewords: the byte mode segment, the terminator and the padding
+func qrDataBits(data []byte, version int, level QRErrorCorrection) []byte {
+	capacity := qrDataCodewords(version, level) * 8
+
+	var bits []bool
+	appendBits := func(value, length int) {
+		for i := length - 1; i >= 0; i-- {
+			bits = append(bits, value>>i&1 == 1)
+		}
+	}
+	appendBits(0x4, 4)
+	appendBits(len(data), qrCountBits(version))
+	for _, b := range data {
+		appendBits(int(b), 8)
+	}
+	appendBits(0, min(4, capacity-len(bits)))
+	appendBits(0, (8-len(bits)%8)%8)
+
+	codewords := make([]byte, 0, capacity/8)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << (7 - j)
+			}
+		}
+		codewords = append(codewords, b)
+	}
+	for pad := byte(0xEC); len(codewords) < capacity/8; pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+	return codewords
+}
+
+// qrAddErrorCorrection splits the data into blocks, adds the error correction codewords
+// of each block and interleaves the blocks into the final sequence
+func qrAddErrorCorrection(data []byte, version int, level QRErrorCorrection) []byte {
+	blocks := qrBlocks[level][version]
+	ecLen := qrECCodewordsPerBlock[level][version]
+	rawCodewords := qrRawDataModules(version) / 8
+	shortBlocks := blocks - rawCodewords%blocks
+	shortBlockLen := rawCodewords / blocks
+
+	divisor := qrReedSolomonDivisor(ecLen)
+	split := make([][]byte, blocks)
+	offset := 0
+	for i := range split {
+		dataLen := shortBlockLen - ecLen
+		if i >= shortBlocks {
+			dataLen++
+		}
+		block := append([]byte{}, data[offset:offset+dataLen]...)
+		offset += dataLen
+		ec := qrReedSolomonRemainder(block, divisor)
+		// Short blocks get a placeholder so all blocks line up when interleaving
+		if i < shortBlocks {
+			block = append(block, 0)
+		}
+		split[i] = append(block, ec...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i < len(split[0]); i++ {
+		for j, block := range split {
+			if i != shortBlockLen-ecLen || j >= shortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// qrReedSolomonDivisor returns the generator polynomial of a given degree, without its
+// leading coefficient, highest power first
+func qrReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = qrMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrMultiply(root, 0x02)
+	}
+	return result
+}
+
+// qrReedSolomonRemainder returns the error correction codewords of a block
+func qrReedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range divisor {
+			result[i] ^= qrMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
+
+// qrMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func qrMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+// set sets a function module and reserves it
+func (q *QRCode) set(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.reserved[y][x] = true
+}
+
+// drawFunctionPatterns draws the finder, timing and alignment patterns and reserves the
+// format and version areas
+func (q *QRCode) drawFunctionPatterns(version int, level QRErrorCorrection) {
+	for i := 0; i < q.Size; i++ {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(q.Size-4, 3)
+	q.drawFinder(3, q.Size-4)
+
+	positions := qrAlignmentPositions(version, q.Size)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Alignment patterns never overlap the finders
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			q.drawAlignment(x, y)
+		}
+	}
+
+	// Reserve the format area with a placeholder until the mask is known
+	q.drawFormatBits(level, 0)
+	q.drawVersion(version)
+}
+
+// drawFinder draws a finder pattern and its separator centered on x, y
+func (q *QRCode) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			distance := max(abs(dx), abs(dy))
+			if x+dx >= 0 && x+dx < q.Size && y+dy >= 0 && y+dy < q.Size {
+				q.set(x+dx, y+dy, distance != 2 && distance != 4)
+			}
+		}
+	}
+}
+
+// drawAlignment draws an alignment pattern centered on x, y
+func (q *QRCode) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// qrAlignmentPositions returns the row and column centers of the alignment patterns
+func qrAlignmentPositions(version, size int) []int {
+	if version == 1 {
+		return nil
+	}
+	count := version/7 + 2
+	step := (version*4 + count*2 + 1) / (count*2 - 2) * 2
+	if version == 32 {
+		step = 26
+	}
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, position := count-1, size-7; i >= 1; i, position = i-1, position-step {
+		positions[i] = position
+	}
+	return positions
+}
+
+// drawFormatBits draws both copies of the level and mask, protected by a BCH code
+func (q *QRCode) drawFormatBits(level QRErrorCorrection, mask int) {
+	data := level.formatBits()<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ (remainder>>9)*0x537
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+
+	// Around the top left finder
+	for i := 0; i <= 5; i++ {
+		q.set(8, i, bit(i))
+	}
+	q.set(8, 7, bit(6))
+	q.set(8, 8, bit(7))
+	q.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(i))
+	}
+
+	// Split between the other two finders
+	for i := 0; i < 8; i++ {
+		q.set(q.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.Size-15+i, bit(i))
+	}
+	q.set(8, q.Size-8, true)
+}
+
+// drawVersion draws both copies of the version, protected by a BCH code, from version 7 up
+func (q *QRCode) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	remainder := version
+	for i := 0; i < 12; i++ {
+		remainder = (remainder << 1) ^ (remainder>>11)*0x1F25
+	}
+	bits := version<<12 | remainder
+	for i := 0; i < 18; i++ {
+		dark := bits>>i&1 == 1
+		a, b := q.Size-11+i%3, i/3
+		q.set(a, b, dark)
+		q.set(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the zigzag of two module wide columns, from the
+// bottom right corner, skipping function modules
+func (q *QRCode) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		// The vertical timing pattern is skipped as a whole column
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < q.Size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = q.Size - 1 - vertical
+				}
+				if !q.reserved[y][x] && i < len(codewords)*8 {
+					q.modules[y][x] = codewords[i>>3]>>(7-i&7)&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by a mask pattern. Masks are their own
+// inverse, so applying one twice restores the code.
+func (q *QRCode) applyMask(mask int) {
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.reserved[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the code is to scan, following the four rules of the standard:
+// long runs, 2x2 blocks, finder-like patterns and an unbalanced share of dark modules
+func (q *QRCode) penalty() int {
+	score := 0
+	dark := 0
+	for i := 0; i < q.Size; i++ {
+		score += q.linePenalty(func(j int) bool { return q.modules[i][j] })
+		score += q.linePenalty(func(j int) bool { return q.modules[j][i] })
+	}
+
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.Size && y+1 < q.Size {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+
+	total := q.Size * q.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return score + k*10
+}
+
+// qrFinderLike is the 1:1:3:1:1 dark and light ratio of a finder pattern
+var qrFinderLike = []bool{true, false, true, true, true, false, true}
+
+// linePenalty scores the runs and finder-like patterns of a row or column
+func (q *QRCode) linePenalty(at func(int) bool) int {
+	score := 0
+	run := 1
+	for j := 1; j <= q.Size; j++ {
+		if j < q.Size && at(j) == at(j-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			score += 3 + run - 5
+		}
+		run = 1
+	}
+
+	// A finder-like pattern counts when four light modules precede or follow it, where
+	// the quiet zone counts as light
+	light := func(from, to int) bool {
+		for j := from; j < to; j++ {
+			if j >= 0 && j < q.Size && at(j) {
+				return false
+			}
+		}
+		return true
+	}
+	for j := 0; j+len(qrFinderLike) <= q.Size; j++ {
+		matches := true
+		for k, want := range qrFinderLike {
+			if at(j+k) != want {
+				matches = false
+				break
+			}
+		}
+		if matches && (light(j-4, j) || light(j+7, j+11)) {
+			score += 40
+		}
+	}
+	return score
+}
+
+// abs returns the absolute value of an int
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/db"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// The read paths below must run the same number of queries however big a board is.
+// They run against the Postgres database in TEST_DATABASE_URL and are skipped without one.
+// Every fixture is created inside a transaction that is rolled back afterwards.
+
+// readPathSizes are the numbers of posts, boards or contributors the read paths are measured at
+var readPathSizes = []int{10, 100, 300}
+
+// readPathSetup seeds a fixture of the given size and returns the request to measure
+type readPathSetup func(tb testing.TB, env *queryTestEnv, size int) func() error
+
+var readPaths = []struct {
+	name  string
+	setup readPathSetup
+}{
+	{"GetBoardBySlug", setupGetBoardBySlug},
+	{"ListUserBoards", setupListUserBoards},
+	{"ListBoardContributors", setupListBoardContributors},
+}
+
+// queryCounter counts the SQL statements gorm runs
+type queryCounter struct {
+	count atomic.Int64
+}
+
+// register adds a callback counting every statement to all of gorm's processors
+func (c *queryCounter) register(gdb *gorm.DB) error {
+	count := func(*gorm.DB) { c.count.Add(1) }
+	callbacks := gdb.Callback()
+
+	if err := callbacks.Query().After("gorm:query").Register("test:count_query", count); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register("test:count_row", count); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().After("gorm:raw").Register("test:count_raw", count); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("test:count_create", count); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("test:count_update", count); err != nil {
+		return err
+	}
+	return callbacks.Delete().After("gorm:delete").Register("test:count_delete", count)
+}
+
+var (
+	testDBOnce    sync.Once
+	testDB        *gorm.DB
+	testDBErr     error
+	testDBQueries queryCounter
+)
+
+// openTestDB connects to and migrates the test database once per test binary
+func openTestDB(tb testing.TB) *gorm.DB {
+	tb.Helper()
+
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		tb.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	testDBOnce.Do(func() {
+		testDB, testDBErr = gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+			Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+		})
+		if testDBErr != nil {
+			return
+		}
+		if testDBErr = db.MigrateSchema(testDB); testDBErr != nil {
+			return
+		}
+		testDBErr = testDBQueries.register(testDB)
+	})
+	if testDBErr != nil {
+		tb.Fatalf("failed to set up test database: %v", testDBErr)
+	}
+
+	return testDB
+}
+
+// queryTestEnv holds services that work inside a transaction rolled back when the test ends
+type queryTestEnv struct {
+	tx           *gorm.DB
+	boardService *BoardService
+	postService  *PostService
+}
+
+// newQueryTestEnv starts a transaction and creates the services under test on top of it
+func newQueryTestEnv(tb testing.TB) *queryTestEnv {
+	tb.Helper()
+
+	tx := openTestDB(tb).Begin()
+	if tx.Error != nil {
+		tb.Fatalf("failed to begin transaction: %v", tx.Error)
+	}
+	tb.Cleanup(func() { tx.Rollback() })
+
+	cfg := &config.Config{}
+	emailService := NewEmailService(cfg)
+	contentFilter := NewContentFilterService(tx, cfg)
+	boardService := NewBoardService(tx, nil, cfg, emailService, contentFilter, NewUnsplashService(cfg))
+
+	return &queryTestEnv{
+		tx:           tx,
+		boardService: boardService,
+		postService:  NewPostService(tx, nil, cfg, boardService, emailService, contentFilter),
+	}
+}
+
+// countQueries runs a request once and returns how many statements it ran
+func (env *queryTestEnv) countQueries(tb testing.TB, request func() error) int {
+	tb.Helper()
+
+	before := testDBQueries.count.Load()
+	if err := request(); err != nil {
+		tb.Fatalf("request failed: %v", err)
+	}
+	return int(testDBQueries.count.Load() - before)
+}
+
+// create inserts test records, failing the test on error
+func (env *queryTestEnv) create(tb testing.TB, value interface{}) {
+	tb.Helper()
+
+	if err := env.tx.Create(value).Error; err != nil {
+		tb.Fatalf("failed to create %T: %v", value, err)
+	}
+}
+
+// createUsers creates users with unique emails. Hooks are skipped so passwords aren't hashed,
+// which keeps seeding fast and allows a batch insert.
+func (env *queryTestEnv) createUsers(tb testing.TB, prefix string, count int) []models.User {
+	tb.Helper()
+
+	batch := uuid.NewString()
+	users := make([]models.User, count)
+	for i := range users {
+		users[i] = models.User{
+			Name:     fmt.Sprintf("%s %d", prefix, i),
+			Email:    fmt.Sprintf("%s-%d-%s@example.com", prefix, i, batch),
+			Password: "password",
+		}
+	}
+	if err := env.tx.Session(&gorm.Session{SkipHooks: true}).Create(&users).Error; err != nil {
+		tb.Fatalf("failed to create users: %v", err)
+	}
+	return users
+}
+
+// createBoard creates a board owned by the given user
+func (env *queryTestEnv) createBoard(tb testing.TB, creatorID uint) *models.Board {
+	board := &models.Board{
+		Title:        "Farewell",
+		ReceiverName: "Alex",
+		CreatorID:    creatorID,
+		FontName:     "Roboto",
+	}
+	env.create(tb, board)
+	return board
+}
+
+// seedBoard creates a board with the given number of contributors, each of whom
+// wrote a post, reacted to it and commented on it
+func (env *queryTestEnv) seedBoard(tb testing.TB, size int) (*models.Board, []models.User) {
+	creator := env.createUsers(tb, "creator", 1)[0]
+	board := env.createBoard(tb, creator.ID)
+	users := env.createUsers(tb, "contributor", size)
+
+	contributors := make([]models.BoardContributor, size)
+	posts := make([]models.Post, size)
+	for i, user := range users {
+		contributors[i] = models.BoardContributor{BoardID: board.ID, UserID: user.ID, Role: models.RoleContributor}
+		posts[i] = models.Post{
+			BoardID:    board.ID,
+			AuthorID:   &users[i].ID,
+			AuthorName: user.Name,
+			Content:    "Congratulations!",
+			Status:     models.PostStatusApproved,
+		}
+	}
+	env.create(tb, &contributors)
+	env.create(tb, &posts)
+
+	reactions := make([]models.PostReaction, size)
+	comments := make([]models.PostComment, size)
+	for i, post := range posts {
+		reactions[i] = models.PostReaction{PostID: post.ID, UserID: *post.AuthorID, Emoji: models.ReactionHeart}
+		comments[i] = models.PostComment{
+			PostID:     post.ID,
+			BoardID:    board.ID,
+			AuthorID:   post.AuthorID,
+			AuthorName: post.AuthorName,
+			Content:    "Thank you!",
+		}
+	}
+	env.create(tb, &reactions)
+	env.create(tb, &comments)
+
+	return board, users
+}
+
+// setupGetBoardBySlug measures opening a board with all its posts, as the board page does
+func setupGetBoardBySlug(tb testing.TB, env *queryTestEnv, size int) func() error {
+	board, users := env.seedBoard(tb, size)
+	viewerID := users[0].ID
+
+	return func() error {
+		_, _, posts, err := env.boardService.GetBoardBySlug(board.Slug, viewerID, true)
+		if err != nil {
+			return err
+		}
+		if len(posts) != size {
+			return fmt.Errorf("got %d posts, want %d", len(posts), size)
+		}
+		_, err = env.postService.LoadPostDetails(posts, viewerID)
+		return err
+	}
+}
+
+// setupListUserBoards measures the dashboard of a user contributing to boards of different creators
+func setupListUserBoards(tb testing.TB, env *queryTestEnv, size int) func() error {
+	viewer := env.createUsers(tb, "viewer", 1)[0]
+	creators := env.createUsers(tb, "creator", size)
+
+	contributors := make([]models.BoardContributor, size)
+	posts := make([]models.Post, size)
+	for i, creator := range creators {
+		board := env.createBoard(tb, creator.ID)
+		contributors[i] = models.BoardContributor{BoardID: board.ID, UserID: viewer.ID, Role: models.RoleContributor}
+		posts[i] = models.Post{
+			BoardID:    board.ID,
+			AuthorID:   &viewer.ID,
+			AuthorName: viewer.Name,
+			Content:    "Congratulations!",
+			Status:     models.PostStatusApproved,
+		}
+	}
+	env.create(tb, &contributors)
+	env.create(tb, &posts)
+
+	query := requests.BoardQuery{Page: 1, PerPage: 100}
+	return func() error {
+		_, total, err := env.boardService.ListUserBoards(viewer.ID, query)
+		if err != nil {
+			return err
+		}
+		if total != int64(size) {
+			return fmt.Errorf("got %d boards, want %d", total, size)
+		}
+		return nil
+	}
+}
+
+// setupListBoardContributors measures listing the contributors of a board
+func setupListBoardContributors(tb testing.TB, env *queryTestEnv, size int) func() error {
+	board, _ := env.seedBoard(tb, size)
+
+	return func() error {
+		_, users, err := env.boardService.ListBoardContributors(board.ID, board.CreatorID)
+		if err != nil {
+			return err
+		}
+		if len(users) != size {
+			return fmt.Errorf("got %d contributors, want %d", len(users), size)
+		}
+		return nil
+	}
+}
+
+func TestReadPathQueryCounts(t *testing.T) {
+	for _, path := range readPaths {
+		t.Run(path.name, func(t *testing.T) {
+			counts := make([]int, len(readPathSizes))
+			for i, size := range readPathSizes {
+				t.Run(fmt.Sprintf("size=%d", size), func(t *testing.T) {
+					env := newQueryTestEnv(t)
+					counts[i] = env.countQueries(t, path.setup(t, env, size))
+				})
+			}
+			if t.Failed() {
+				return
+			}
+
+			for i, size := range readPathSizes {
+				if counts[i] != counts[0] {
+					t.Errorf("%s ran %d queries at size %d but %d at size %d",
+						path.name, counts[i], size, counts[0], readPathSizes[0])
+				}
+			}
+		})
+	}
+}
+
+// benchmarkReadPath runs a read path at every size, reporting its queries per request
+// and failing when the count changes with the size
+func benchmarkReadPath(b *testing.B, setup readPathSetup) {
+	baseline := -1
+	for _, size := range readPathSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			env := newQueryTestEnv(b)
+			request := setup(b, env, size)
+
+			queries := env.countQueries(b, request)
+			if baseline < 0 {
+				baseline = queries
+			} else if queries != baseline {
+				b.Fatalf("ran %d queries at size %d but %d at size %d", queries, size, baseline, readPathSizes[0])
+			}
+
+			for b.Loop() {
+				if err := request(); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(queries), "queries/op")
+		})
+	}
+}
+
+func BenchmarkGetBoardBySlug(b *testing.B) {
+	benchmarkReadPath(b, setupGetBoardBySlug)
+}
+
+func BenchmarkListUserBoards(b *testing.B) {
+	benchmarkReadPath(b, setupListUserBoards)
+}
+
+func BenchmarkListBoardContributors(b *testing.B) {
+	benchmarkReadPath(b, setupListBoardContributors)
+}
@@ -0,0 +1,489 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"time"
+)
+
+// reportHideReason is recorded on posts hidden automatically after abuse reports
+const reportHideReason = "Hidden while abuse reports are reviewed"
+
+// ReportedContent is an abuse report together with the reported post or board
+// and the number of open reports against it
+type ReportedContent struct {
+	models.Report
+	Post            *models.Post
+	Board           *models.Board
+	OpenReportCount int64
+}
+
+// ReportService handles abuse reports and their review by site admins
+type ReportService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	boardService *BoardService
+}
+
+// NewReportService creates a new ReportService
+func NewReportService(db *gorm.DB, cfg *config.Config, boardService *BoardService) *ReportService {
+	return &ReportService{
+		db:           db,
+		cfg:          cfg,
+		boardService: boardService,
+	}
+}
+
+// ReportPost records an abuse report about a post. Reporters without an account are
+// identified by their IP address, which is only stored hashed.
+func (s *ReportService) ReportPost(postID, reporterID uint, clientIP string, input requests.CreateReportRequest) error {
+	var post models.Post
+	if result := s.db.First(&post, postID); result.Error != nil {
+		return utils.NewNotFoundError("Post not found").
+			WithField("post_id", postID)
+	}
+
+	return s.createReport(models.ReportTargetPost, post.ID, post.BoardID, reporterID, clientIP, input)
+}
+
+// ReportBoard records an abuse report about a board
+func (s *ReportService) ReportBoard(boardID, reporterID uint, clientIP string, input requests.CreateReportRequest) error {
+	board, err := s.boardService.GetBoardByID(boardID)
+	if err != nil {
+		return err
+	}
+
+	return s.createReport(models.ReportTargetBoard, board.ID, board.ID, reporterID, clientIP, input)
+}
+
+// createReport records a report and hides the target once enough distinct reporters have flagged it.
+// Reporting the same target again updates the reporter's earlier report instead of adding another.
+func (s *ReportService) createReport(targetType models.ReportTargetType, targetID, boardID, reporterID uint, clientIP string, input requests.CreateReportRequest) error {
+	reporterKey := s.reporterKey(reporterID, clientIP)
+
+	return utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		var report models.Report
+		result := tx.Where("target_type = ? AND target_id = ? AND reporter_key = ?", targetType, targetID, reporterKey).
+			First(&report)
+
+		switch {
+		case result.Error == nil:
+			// Reporting again reopens a report that was already reviewed
+			if err := tx.Model(&report).Updates(map[string]interface{}{
+				"reason":          input.Reason,
+				"details":         input.Details,
+				"status":          models.ReportStatusOpen,
+				"action":          "",
+				"resolution_note": "",
+				"resolved_by_id":  nil,
+				"resolved_at":     nil,
+			}).Error; err != nil {
+				return utils.NewInternalError("Failed to update report", err).
+					WithField("report_id", report.ID)
+			}
+		case errors.Is(result.Error, gorm.ErrRecordNotFound):
+			report = models.Report{
+				TargetType:  targetType,
+				TargetID:    targetID,
+				ReporterKey: reporterKey,
+				BoardID:     boardID,
+				Reason:      input.Reason,
+				Details:     input.Details,
+				Status:      models.ReportStatusOpen,
+			}
+			if reporterID != 0 {
+				report.ReporterID = &reporterID
+			}
+			if err := tx.Create(&report).Error; err != nil {
+				return utils.NewInternalError("Failed to create report", err).
+					WithField("target_type", targetType).
+					WithField("target_id", targetID)
+			}
+		default:
+			return utils.NewInternalError("Failed to query reports", result.Error).
+				WithField("target_type", targetType).
+				WithField("target_id", targetID)
+		}
+
+		// Hide the content pending review once enough people have reported it
+		if s.cfg.ReportAutoHideThreshold <= 0 {
+			return nil
+		}
+
+		var openReports int64
+		if err := tx.Model(&models.Report{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, models.ReportStatusOpen).
+			Count(&openReports).Error; err != nil {
+			return utils.NewInternalError("Failed to count reports", err).
+				WithField("target_type", targetType).
+				WithField("target_id", targetID)
+		}
+
+		if openReports < int64(s.cfg.ReportAutoHideThreshold) {
+			return nil
+		}
+
+		log.Info("Hiding reported content pending review",
+			zap.String("target_type", string(targetType)),
+			zap.Uint("target_id", targetID),
+			zap.Int64("open_reports", openReports))
+
+		return hideReportedContent(tx, targetType, targetID, nil, reportHideReason)
+	})
+}
+
+// reporterKey identifies a reporter by account, or by a salted hash of their IP address
+func (s *ReportService) reporterKey(reporterID uint, clientIP string) string {
+	if reporterID != 0 {
+		return fmt.Sprintf("user:%d", reporterID)
+	}
+	sum := sha256.Sum256([]byte(s.cfg.JWTSecret + "|" + clientIP))
+	return "ip:" + hex.EncodeToString(sum[:])
+}
+
+// ListReports lists abuse reports for review, oldest first
+func (s *ReportService) ListReports(query requests.ReportQuery) ([]ReportedContent, int64, error) {
+	status := query.Status
+	if status == "" {
+		status = string(models.ReportStatusOpen)
+	}
+
+	dbQuery := s.db.Model(&models.Report{}).Where("status = ?", status)
+	if query.TargetType != "" {
+		dbQuery = dbQuery.Where("target_type = ?", query.TargetType)
+	}
+	if query.Reason != "" {
+		dbQuery = dbQuery.Where("reason = ?", query.Reason)
+	}
+	if query.BoardID != 0 {
+		dbQuery = dbQuery.Where("board_id = ?", query.BoardID)
+	}
+
+	var total int64
+	if err := dbQuery.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to count reports", err)
+	}
+
+	var reports []models.Report
+	if err := dbQuery.Order("created_at asc, id asc").
+		Offset((query.Page - 1) * query.PerPage).
+		Limit(query.PerPage).
+		Find(&reports).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to fetch reports", err)
+	}
+
+	result, err := s.loadReportedContent(reports)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result, total, nil
+}
+
+// loadReportedContent loads the posts, boards and open report counts for a page of reports
+func (s *ReportService) loadReportedContent(reports []models.Report) ([]ReportedContent, error) {
+	var postIDs, boardIDs []uint
+	for _, report := range reports {
+		if report.TargetType == models.ReportTargetPost {
+			postIDs = append(postIDs, report.TargetID)
+		}
+		boardIDs = append(boardIDs, report.BoardID)
+	}
+
+	// Reported content may since have been moved to the trash
+	postsByID := make(map[uint]*models.Post)
+	if len(postIDs) > 0 {
+		var posts []models.Post
+		if err := s.db.Unscoped().Where("id IN ?", postIDs).Find(&posts).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to fetch reported posts", err)
+		}
+		for i := range posts {
+			postsByID[posts[i].ID] = &posts[i]
+		}
+	}
+
+	boardsByID := make(map[uint]*models.Board)
+	if len(boardIDs) > 0 {
+		var boards []models.Board
+		if err := s.db.Unscoped().Where("id IN ?", boardIDs).Find(&boards).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to fetch reported boards", err)
+		}
+		for i := range boards {
+			boardsByID[boards[i].ID] = &boards[i]
+		}
+	}
+
+	// Count open reports per target
+	type targetCount struct {
+		TargetType models.ReportTargetType
+		TargetID   uint
+		Count      int64
+	}
+	var counts []targetCount
+	if len(reports) > 0 {
+		targetIDs := make([]uint, len(reports))
+		for i, report := range reports {
+			targetIDs[i] = report.TargetID
+		}
+		if err := s.db.Model(&models.Report{}).
+			Select("target_type, target_id, COUNT(*) AS count").
+			Where("status = ? AND target_id IN ?", models.ReportStatusOpen, targetIDs).
+			Group("target_type, target_id").
+			Scan(&counts).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to count reports", err)
+		}
+	}
+	countsByTarget := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		countsByTarget[fmt.Sprintf("%s:%d", count.TargetType, count.TargetID)] = count.Count
+	}
+
+	result := make([]ReportedContent, len(reports))
+	for i, report := range reports {
+		result[i].Report = report
+		result[i].Board = boardsByID[report.BoardID]
+		if report.TargetType == models.ReportTargetPost {
+			result[i].Post = postsByID[report.TargetID]
+		}
+		result[i].OpenReportCount = countsByTarget[fmt.Sprintf("%s:%d", report.TargetType, report.TargetID)]
+	}
+
+	return result, nil
+}
+
+// ResolveReport applies a site admin's decision to a report. The decision closes every
+// open report about the same post or board.
+func (s *ReportService) ResolveReport(reportID, adminID uint, input requests.ResolveReportRequest) (*ReportedContent, error) {
+	var report models.Report
+	if result := s.db.First(&report, reportID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Report not found").
+				WithField("report_id", reportID)
+		}
+		return nil, utils.NewInternalError("Failed to query report", result.Error).
+			WithField("report_id", reportID)
+	}
+
+	if report.Status != models.ReportStatusOpen {
+		return nil, utils.NewBadRequestError("This report has already been reviewed").
+			WithField("report_id", reportID)
+	}
+
+	if input.Action == models.ReportActionHidePost && report.TargetType != models.ReportTargetPost {
+		return nil, utils.NewBadRequestError("Only reported posts can be hidden").
+			WithField("report_id", reportID)
+	}
+
+	// Locking goes through the regular board lock so it is handled like any other lock
+	if input.Action == models.ReportActionLockBoard {
+		if _, err := s.boardService.ToggleBoardLock(report.BoardID, adminID, true); err != nil {
+			return nil, err
+		}
+	}
+
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		reason := input.Note
+		if reason == "" {
+			reason = "Removed after abuse reports"
+		}
+
+		switch input.Action {
+		case models.ReportActionDismiss:
+			if err := unhideReportedContent(tx, report.TargetType, report.TargetID); err != nil {
+				return err
+			}
+		case models.ReportActionHidePost:
+			if err := hideReportedContent(tx, report.TargetType, report.TargetID, &adminID, reason); err != nil {
+				return err
+			}
+		case models.ReportActionLockBoard:
+			// A locked board can be shown again, reported posts stay as they are
+			if report.TargetType == models.ReportTargetBoard {
+				if err := unhideReportedContent(tx, report.TargetType, report.TargetID); err != nil {
+					return err
+				}
+			}
+		case models.ReportActionSuspendUser:
+			if err := s.suspendReportedUser(tx, &report, input.Note); err != nil {
+				return err
+			}
+			if err := hideReportedContent(tx, report.TargetType, report.TargetID, &adminID, reason); err != nil {
+				return err
+			}
+		}
+
+		// Close every open report about the same content
+		status := models.ReportStatusResolved
+		if input.Action == models.ReportActionDismiss {
+			status = models.ReportStatusDismissed
+		}
+		now := time.Now()
+		if err := tx.Model(&models.Report{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", report.TargetType, report.TargetID, models.ReportStatusOpen).
+			Updates(map[string]interface{}{
+				"status":          status,
+				"action":          input.Action,
+				"resolution_note": input.Note,
+				"resolved_by_id":  adminID,
+				"resolved_at":     now,
+			}).Error; err != nil {
+			return utils.NewInternalError("Failed to resolve reports", err).
+				WithField("report_id", reportID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Abuse report resolved",
+		zap.Uint("report_id", reportID),
+		zap.Uint("admin_id", adminID),
+		zap.String("action", string(input.Action)))
+
+	// Reload the report with its content
+	if err := s.db.First(&report, reportID).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to reload report", err).
+			WithField("report_id", reportID)
+	}
+	reported, err := s.loadReportedContent([]models.Report{report})
+	if err != nil {
+		return nil, err
+	}
+
+	return &reported[0], nil
+}
+
+// suspendReportedUser suspends the author of a reported post or the creator of a reported board
+func (s *ReportService) suspendReportedUser(tx *gorm.DB, report *models.Report, note string) error {
+	var userID uint
+	if report.TargetType == models.ReportTargetPost {
+		var post models.Post
+		if err := tx.Unscoped().First(&post, report.TargetID).Error; err != nil {
+			return utils.NewNotFoundError("Reported post not found").
+				WithField("post_id", report.TargetID)
+		}
+		if post.AuthorID == nil {
+			return utils.NewBadRequestError("Anonymous posts aren't linked to an account, hide the post instead").
+				WithField("post_id", post.ID)
+		}
+		userID = *post.AuthorID
+	} else {
+		var board models.Board
+		if err := tx.Unscoped().First(&board, report.TargetID).Error; err != nil {
+			return utils.NewNotFoundError("Reported board not found").
+				WithField("board_id", report.TargetID)
+		}
+		userID = board.CreatorID
+	}
+
+	var user models.User
+	if err := tx.First(&user, userID).Error; err != nil {
+		return utils.NewNotFoundError("User not found").
+			WithField("user_id", userID)
+	}
+	if user.IsAdmin {
+		return utils.NewForbiddenError("Site admins can't be suspended").
+			WithField("user_id", userID)
+	}
+
+	if err := tx.Model(&user).Updates(map[string]interface{}{
+		"suspended_at":   time.Now(),
+		"suspend_reason": note,
+	}).Error; err != nil {
+		return utils.NewInternalError("Failed to suspend user", err).
+			WithField("user_id", userID)
+	}
+
+	return nil
+}
+
+// LiftSuspension lets a suspended user sign in again
+func (s *ReportService) LiftSuspension(userID uint) error {
+	var user models.User
+	if result := s.db.First(&user, userID); result.Error != nil {
+		return utils.NewNotFoundError("User not found").
+			WithField("user_id", userID)
+	}
+
+	if user.SuspendedAt == nil {
+		return utils.NewBadRequestError("This user isn't suspended").
+			WithField("user_id", userID)
+	}
+
+	if err := s.db.Model(&user).Updates(map[string]interface{}{
+		"suspended_at":   nil,
+		"suspend_reason": "",
+	}).Error; err != nil {
+		return utils.NewInternalError("Failed to lift suspension", err).
+			WithField("user_id", userID)
+	}
+
+	return nil
+}
+
+// hideReportedContent hides a reported post or board from everyone except the people managing it
+func hideReportedContent(tx *gorm.DB, targetType models.ReportTargetType, targetID uint, adminID *uint, reason string) error {
+	now := time.Now()
+
+	if targetType == models.ReportTargetBoard {
+		if err := tx.Unscoped().Model(&models.Board{}).
+			Where("id = ? AND hidden_at IS NULL", targetID).
+			Update("hidden_at", now).Error; err != nil {
+			return utils.NewInternalError("Failed to hide board", err).
+				WithField("board_id", targetID)
+		}
+		return nil
+	}
+
+	updates := map[string]interface{}{
+		"status":            models.PostStatusHidden,
+		"moderation_reason": reason,
+		"moderated_by_id":   adminID,
+		"moderated_at":      now,
+	}
+	query := tx.Unscoped().Model(&models.Post{}).Where("id = ?", targetID)
+	if adminID == nil {
+		// Automatic hiding only applies to published posts
+		query = query.Where("status = ?", models.PostStatusApproved)
+	}
+	if err := query.Updates(updates).Error; err != nil {
+		return utils.NewInternalError("Failed to hide post", err).
+			WithField("post_id", targetID)
+	}
+	return nil
+}
+
+// unhideReportedContent shows a hidden post or board again
+func unhideReportedContent(tx *gorm.DB, targetType models.ReportTargetType, targetID uint) error {
+	if targetType == models.ReportTargetBoard {
+		if err := tx.Unscoped().Model(&models.Board{}).
+			Where("id = ?", targetID).
+			Update("hidden_at", nil).Error; err != nil {
+			return utils.NewInternalError("Failed to show board", err).
+				WithField("board_id", targetID)
+		}
+		return nil
+	}
+
+	if err := tx.Unscoped().Model(&models.Post{}).
+		Where("id = ? AND status = ?", targetID, models.PostStatusHidden).
+		Updates(map[string]interface{}{
+			"status":            models.PostStatusApproved,
+			"moderation_reason": "",
+		}).Error; err != nil {
+		return utils.NewInternalError("Failed to show post", err).
+			WithField("post_id", targetID)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"gorm.io/gorm"
+	"html"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxSearchTerms limits how many words of a search query are used
+const maxSearchTerms = 10
+
+// Highlighted matches are marked with control characters by Postgres so the rest of the
+// text can be HTML-escaped before the marks are turned into <mark> tags
+const (
+	highlightStart = "\x01"
+	highlightStop  = "\x02"
+)
+
+// snippetOptions shows up to two fragments of a post around the matching words
+const snippetOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop +
+	", MaxWords=35, MinWords=15, ShortWord=2, MaxFragments=2, FragmentDelimiter=\" … \""
+
+// highlightOptions marks every matching word of a short text such as a title
+const highlightOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", HighlightAll=true"
+
+// Board titles and receiver names are matched as they are, post content is also
+// matched by word stem so "congratulate" finds "congratulations"
+const (
+	boardSearchQuery = "to_tsquery('simple', ?)"
+	postSearchQuery  = "(to_tsquery('english', ?) || to_tsquery('simple', ?))"
+)
+
+// BoardSearchResult is a board matching a search with its matches highlighted
+type BoardSearchResult struct {
+	models.Board
+	Rank                  float64
+	TitleHighlight        string
+	ReceiverNameHighlight string
+}
+
+// PostSearchResult is a post matching a search with a highlighted snippet
+type PostSearchResult struct {
+	models.Post
+	Author        *models.User           `gorm:"-"`
+	LikesCount    int64                  `gorm:"-"`
+	LikedByMe     bool                   `gorm:"-"`
+	Reactions     []models.ReactionCount `gorm:"-"`
+	MyReactions   []string               `gorm:"-"`
+	CommentsCount int64                  `gorm:"-"`
+	BoardTitle    string
+	BoardSlug     string
+	Rank          float64
+	Snippet       string
+}
+
+// SearchService handles full-text search across boards and posts
+type SearchService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	boardService *BoardService
+}
+
+// NewSearchService creates a new SearchService
+func NewSearchService(db *gorm.DB, cfg *config.Config, boardService *BoardService) *SearchService {
+	return &SearchService{
+		db:           db,
+		cfg:          cfg,
+		boardService: boardService,
+	}
+}
+
+// SearchBoards searches the titles and receiver names of the boards a user created or contributes to
+func (s *SearchService) SearchBoards(userID uint, params requests.SearchQuery) ([]BoardSearchResult, int64, error) {
+	tsQuery, err := parseSearchQuery(params.Query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := s.db.Model(&models.Board{}).
+		Joins("LEFT JOIN board_contributors ON board_contributors.board_id = boards.id AND board_contributors.user_id = ?", userID).
+		Where("boards.creator_id = ? OR board_contributors.user_id IS NOT NULL", userID).
+		Where("boards.hidden_at IS NULL OR boards.creator_id = ? OR board_contributors.role = ?", userID, models.RoleAdmin).
+		Where("boards.search_vector @@ "+boardSearchQuery, tsQuery)
+
+	// Count matching boards
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to search boards", err).
+			WithField("user_id", userID)
+	}
+
+	var results []BoardSearchResult
+	if err := query.
+		Select("boards.*, "+
+			"ts_rank(boards.search_vector, "+boardSearchQuery+") AS rank, "+
+			"ts_headline('simple', boards.title, "+boardSearchQuery+", ?) AS title_highlight, "+
+			"ts_headline('simple', boards.receiver_name, "+boardSearchQuery+", ?) AS receiver_name_highlight",
+			tsQuery, tsQuery, highlightOptions, tsQuery, highlightOptions).
+		Order("rank desc, boards.created_at desc").
+		Offset((params.Page - 1) * params.PerPage).
+		Limit(params.PerPage).
+		Scan(&results).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to search boards", err).
+			WithField("user_id", userID)
+	}
+
+	for i := range results {
+		results[i].TitleHighlight = renderHighlights(results[i].TitleHighlight)
+		results[i].ReceiverNameHighlight = renderHighlights(results[i].ReceiverNameHighlight)
+	}
+
+	return results, total, nil
+}
+
+// SearchPosts searches the content and author names of posts on the boards a user
+// created or contributes to. Pending posts are only found by their author and by board moderators.
+func (s *SearchService) SearchPosts(userID uint, params requests.PostSearchQuery) ([]PostSearchResult, int64, error) {
+	tsQuery, err := parseSearchQuery(params.Query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := s.db.Model(&models.Post{}).
+		Joins("JOIN boards ON boards.id = posts.board_id AND boards.deleted_at IS NULL").
+		Joins("LEFT JOIN board_contributors ON board_contributors.board_id = boards.id AND board_contributors.user_id = ?", userID).
+		Where("boards.creator_id = ? OR board_contributors.user_id IS NOT NULL", userID).
+		Where("boards.hidden_at IS NULL OR boards.creator_id = ? OR board_contributors.role = ?", userID, models.RoleAdmin).
+		Where("posts.status = ? OR posts.author_id = ? OR boards.creator_id = ? OR board_contributors.role IN ?",
+			models.PostStatusApproved, userID, userID, []models.Role{models.RoleAdmin, models.RoleModerator})
+
+	if params.BoardID != 0 {
+		query = query.Where("posts.board_id = ?", params.BoardID)
+	}
+
+	return s.searchPosts(query, tsQuery, userID, params)
+}
+
+// SearchBoardPosts searches the posts of one board. Anyone who can open the board can search it.
+func (s *SearchService) SearchBoardPosts(boardID, viewerID uint, params requests.PostSearchQuery) ([]PostSearchResult, int64, error) {
+	board, err := s.boardService.GetViewableBoard(boardID, viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tsQuery, err := parseSearchQuery(params.Query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := s.db.Model(&models.Post{}).
+		Joins("JOIN boards ON boards.id = posts.board_id").
+		Where("posts.board_id = ?", boardID)
+	query = s.boardService.scopeVisiblePosts(query, board, viewerID)
+
+	return s.searchPosts(query, tsQuery, viewerID, params)
+}
+
+// searchPosts applies the search and its filters to a query of visible posts,
+// then loads a page of results ranked by relevance
+func (s *SearchService) searchPosts(query *gorm.DB, tsQuery string, viewerID uint, params requests.PostSearchQuery) ([]PostSearchResult, int64, error) {
+	query = query.Where("posts.search_vector @@ "+postSearchQuery, tsQuery, tsQuery)
+
+	// Apply filters
+	query = filterPosts(query, params.AuthorID, params.MediaType)
+	if params.From != "" {
+		from, _ := time.Parse("2006-01-02", params.From)
+		query = query.Where("posts.created_at >= ?", from)
+	}
+	if params.To != "" {
+		to, _ := time.Parse("2006-01-02", params.To)
+		query = query.Where("posts.created_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	// Count matching posts
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to search posts", err)
+	}
+
+	var results []PostSearchResult
+	if err := query.
+		Select("posts.*, boards.title AS board_title, boards.slug AS board_slug, "+
+			"ts_rank(posts.search_vector, "+postSearchQuery+") AS rank, "+
+			"ts_headline('simple', posts.content, "+postSearchQuery+", ?) AS snippet",
+			tsQuery, tsQuery, tsQuery, tsQuery, snippetOptions).
+		Order("rank desc, posts.created_at desc").
+		Offset((params.Page - 1) * params.PerPage).
+		Limit(params.PerPage).
+		Scan(&results).Error; err != nil {
+		return nil, 0, utils.NewInternalError("Failed to search posts", err)
+	}
+
+	// Load the authors, reactions and comment counts of the results
+	postIDs := make([]uint, len(results))
+	var authorIDs []uint
+	for i, result := range results {
+		postIDs[i] = result.ID
+		if result.AuthorID != nil {
+			authorIDs = append(authorIDs, *result.AuthorID)
+		}
+	}
+	authors, err := loadUsersByID(s.db, authorIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	reactions, err := loadPostReactions(s.db, postIDs, viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	commentCounts, err := loadCommentCounts(s.db, postIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range results {
+		if results[i].AuthorID != nil {
+			results[i].Author = authors[*results[i].AuthorID]
+		}
+		postReactions := reactions[results[i].ID]
+		results[i].LikesCount = postReactions.Total
+		results[i].Reactions = postReactions.Counts
+		results[i].MyReactions = postReactions.MyReactions
+		results[i].LikedByMe = slices.Contains(postReactions.MyReactions, models.ReactionHeart)
+		results[i].CommentsCount = commentCounts[results[i].ID]
+		results[i].Snippet = renderHighlights(results[i].Snippet)
+	}
+
+	return results, total, nil
+}
+
+// parseSearchQuery turns what a user typed into a Postgres text search query that
+// requires every word and matches words by prefix, so "happy birth" finds "Happy Birthday".
+// Everything but letters and digits is dropped, so the input can't inject query operators.
+func parseSearchQuery(input string) (string, error) {
+	terms := searchTerms(input)
+	if len(terms) == 0 {
+		return "", utils.NewBadRequestError("The search must contain at least one letter or digit").
+			WithField("q", input)
+	}
+	return strings.Join(terms, " & "), nil
+}
+
+// searchTerms splits search input into lowercase prefix terms
+func searchTerms(input string) []string {
+	words := strings.FieldsFunc(input, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var terms []string
+	for _, word := range words {
+		if len(terms) == maxSearchTerms {
+			break
+		}
+		terms = append(terms, strings.ToLower(word)+":*")
+	}
+	return terms
+}
+
+// renderHighlights HTML-escapes highlighted text and wraps the matches in <mark> tags
+func renderHighlights(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, highlightStart, "<mark>")
+	return strings.ReplaceAll(text, highlightStop, "</mark>")
+}
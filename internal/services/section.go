@@ -0,0 +1,440 @@
+package services
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+)
+
+// defaultSectionTitle is the title of the section holding posts that aren't in any other section
+const defaultSectionTitle = "General"
+
+// SectionWithCount is a board section together with the number of approved posts in it
+type SectionWithCount struct {
+	models.BoardSection
+	PostCount int64
+}
+
+// SectionPosts is a board section with its posts, in board order
+type SectionPosts struct {
+	SectionWithCount
+	Posts []BoardPost
+}
+
+// SectionService handles the sections posts are grouped in on a board
+type SectionService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	boardService *BoardService
+}
+
+// NewSectionService creates a new SectionService
+func NewSectionService(db *gorm.DB, cfg *config.Config, boardService *BoardService) *SectionService {
+	return &SectionService{
+		db:           db,
+		cfg:          cfg,
+		boardService: boardService,
+	}
+}
+
+// ListSections lists the sections of a board the viewer can open, with their post counts
+func (s *SectionService) ListSections(boardID, viewerID uint) ([]SectionWithCount, error) {
+	if _, err := s.boardService.GetViewableBoard(boardID, viewerID); err != nil {
+		return nil, err
+	}
+	return s.ListBoardSections(boardID)
+}
+
+// ListBoardSections lists the sections of a board in order, with their approved post counts.
+// Boards without sections return an empty list.
+func (s *SectionService) ListBoardSections(boardID uint) ([]SectionWithCount, error) {
+	var sections []models.BoardSection
+	if err := s.db.Where("board_id = ?", boardID).Order("position asc, id asc").Find(&sections).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch sections", err).
+			WithField("board_id", boardID)
+	}
+	if len(sections) == 0 {
+		return []SectionWithCount{}, nil
+	}
+
+	// Posts without a section are counted for the default section
+	type sectionCount struct {
+		SectionID *uint
+		PostCount int64
+	}
+	var counts []sectionCount
+	if err := s.db.Model(&models.Post{}).
+		Select("section_id, COUNT(*) AS post_count").
+		Where("board_id = ? AND status = ?", boardID, models.PostStatusApproved).
+		Group("section_id").
+		Scan(&counts).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to count section posts", err).
+			WithField("board_id", boardID)
+	}
+
+	countsBySection := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		if count.SectionID != nil {
+			countsBySection[*count.SectionID] += count.PostCount
+		} else {
+			countsBySection[0] += count.PostCount
+		}
+	}
+
+	result := make([]SectionWithCount, len(sections))
+	for i, section := range sections {
+		result[i].BoardSection = section
+		if section.IsDefault {
+			result[i].PostCount = countsBySection[0]
+		} else {
+			result[i].PostCount = countsBySection[section.ID]
+		}
+	}
+
+	return result, nil
+}
+
+// CountSectionPosts counts the approved posts in a section
+func (s *SectionService) CountSectionPosts(section *models.BoardSection) int64 {
+	query := s.db.Model(&models.Post{}).Where("board_id = ? AND status = ?", section.BoardID, models.PostStatusApproved)
+	if section.IsDefault {
+		query = query.Where("section_id IS NULL")
+	} else {
+		query = query.Where("section_id = ?", section.ID)
+	}
+
+	var count int64
+	query.Count(&count)
+	return count
+}
+
+// GroupPostsBySection splits a board's posts into its sections, keeping their order.
+// Posts without a section, or in a section that no longer exists, go to the default section.
+func GroupPostsBySection(sections []SectionWithCount, posts []BoardPost) []SectionPosts {
+	groups := make([]SectionPosts, len(sections))
+	indexByID := make(map[uint]int, len(sections))
+	defaultIndex := -1
+	for i, section := range sections {
+		groups[i] = SectionPosts{SectionWithCount: section, Posts: []BoardPost{}}
+		indexByID[section.ID] = i
+		if section.IsDefault {
+			defaultIndex = i
+		}
+	}
+
+	for _, post := range posts {
+		index := defaultIndex
+		if post.SectionID != nil {
+			if i, exists := indexByID[*post.SectionID]; exists {
+				index = i
+			}
+		}
+		if index >= 0 {
+			groups[index].Posts = append(groups[index].Posts, post)
+		}
+	}
+
+	return groups
+}
+
+// CreateSection adds a section at the end of a board. The board's default section is
+// created along with its first section.
+func (s *SectionService) CreateSection(boardID, userID uint, input requests.CreateSectionRequest) (*models.BoardSection, error) {
+	if _, err := s.getManagedBoard(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	// Check the title against the content filter
+	if err := s.boardService.filterBoardText(boardID, userID, &input.Title, nil); err != nil {
+		return nil, err
+	}
+
+	section := models.BoardSection{
+		BoardID: boardID,
+		Title:   input.Title,
+		Color:   input.Color,
+	}
+
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if _, err := ensureDefaultSection(tx, boardID); err != nil {
+			return err
+		}
+
+		var lastPosition int
+		if err := tx.Model(&models.BoardSection{}).
+			Where("board_id = ?", boardID).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&lastPosition).Error; err != nil {
+			return utils.NewInternalError("Failed to create section", err).
+				WithField("board_id", boardID)
+		}
+		section.Position = lastPosition + 1
+
+		if err := tx.Create(&section).Error; err != nil {
+			return utils.NewInternalError("Failed to create section", err).
+				WithField("board_id", boardID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &section, nil
+}
+
+// UpdateSection renames or recolors a section
+func (s *SectionService) UpdateSection(boardID, sectionID, userID uint, input requests.UpdateSectionRequest) (*models.BoardSection, error) {
+	if _, err := s.getManagedBoard(boardID, userID); err != nil {
+		return nil, err
+	}
+
+	section, err := s.getSection(boardID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the new title against the content filter
+	if err := s.boardService.filterBoardText(boardID, userID, input.Title, nil); err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		section.Title = *input.Title
+	}
+	if input.Color != nil {
+		section.Color = *input.Color
+	}
+
+	if result := s.db.Save(section); result.Error != nil {
+		return nil, utils.NewInternalError("Failed to update section", result.Error).
+			WithField("section_id", sectionID)
+	}
+
+	return section, nil
+}
+
+// DeleteSection deletes a section, moving its posts to the end of the default section.
+// Posts in the trash are moved too, so they are restored into a section that exists.
+func (s *SectionService) DeleteSection(boardID, sectionID, userID uint) error {
+	if _, err := s.getManagedBoard(boardID, userID); err != nil {
+		return err
+	}
+
+	section, err := s.getSection(boardID, sectionID)
+	if err != nil {
+		return err
+	}
+	if section.IsDefault {
+		return utils.NewBadRequestError("The default section can't be deleted").
+			WithField("section_id", sectionID)
+	}
+
+	return utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		// Bring positions in line with the current order first, as moves only change ranks
+		if err := renumberBoardPosts(tx, boardID, "rank asc, id asc"); err != nil {
+			return err
+		}
+
+		var lastPosition int
+		if err := tx.Unscoped().Model(&models.Post{}).
+			Where("board_id = ?", boardID).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&lastPosition).Error; err != nil {
+			return utils.NewInternalError("Failed to move section posts", err).
+				WithField("section_id", sectionID)
+		}
+
+		if err := tx.Unscoped().Model(&models.Post{}).
+			Where("board_id = ? AND section_id = ?", boardID, sectionID).
+			Updates(map[string]interface{}{
+				"section_id": nil,
+				"position":   gorm.Expr("position + ?", lastPosition),
+			}).Error; err != nil {
+			return utils.NewInternalError("Failed to move section posts", err).
+				WithField("section_id", sectionID)
+		}
+
+		// Rank the moved posts after the others
+		if err := renumberBoardPosts(tx, boardID, "position asc, id asc"); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(section).Error; err != nil {
+			return utils.NewInternalError("Failed to delete section", err).
+				WithField("section_id", sectionID)
+		}
+		return nil
+	})
+}
+
+// ReorderSections updates the order of the sections of a board
+func (s *SectionService) ReorderSections(boardID, userID uint, positions []requests.SectionPosition) error {
+	if _, err := s.getManagedBoard(boardID, userID); err != nil {
+		return err
+	}
+
+	return utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		for _, order := range positions {
+			result := tx.Model(&models.BoardSection{}).
+				Where("id = ? AND board_id = ?", order.ID, boardID).
+				Update("position", order.Position)
+			if result.Error != nil {
+				return utils.NewInternalError("Failed to reorder sections", result.Error).
+					WithField("board_id", boardID)
+			}
+			if result.RowsAffected == 0 {
+				return utils.NewBadRequestError("Section does not belong to this board").
+					WithField("board_id", boardID).
+					WithField("section_id", order.ID)
+			}
+		}
+		return nil
+	})
+}
+
+// MovePost moves a post to another section of its board, at a position or after the
+// board's last post
+func (s *SectionService) MovePost(postID, userID uint, input requests.MovePostToSectionRequest) (*models.Post, error) {
+	var post models.Post
+	if result := s.db.First(&post, postID); result.Error != nil {
+		return nil, utils.NewNotFoundError("Post not found").
+			WithField("post_id", postID)
+	}
+
+	if _, err := s.getManagedBoard(post.BoardID, userID); err != nil {
+		return nil, err
+	}
+
+	sectionID, err := resolvePostSection(s.db, post.BoardID, input.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		// Without a position, the post goes after the board's last post
+		if input.Position == nil {
+			rank, err := nextPostRank(tx, post.BoardID)
+			if err != nil {
+				return utils.NewInternalError("Failed to move post", err).
+					WithField("post_id", postID)
+			}
+
+			if err := tx.Model(&post).Updates(map[string]interface{}{
+				"section_id": sectionID,
+				"rank":       rank,
+				"position":   gorm.Expr("(SELECT COALESCE(MAX(position), 0) + 1 FROM posts WHERE board_id = ?)", post.BoardID),
+			}).Error; err != nil {
+				return utils.NewInternalError("Failed to move post", err).
+					WithField("post_id", postID)
+			}
+			return nil
+		}
+
+		// Place the post among positions brought in line with the current order, then rank
+		// the board by position
+		if err := renumberBoardPosts(tx, post.BoardID, "rank asc, id asc"); err != nil {
+			return err
+		}
+		if err := tx.Model(&post).Updates(map[string]interface{}{
+			"section_id": sectionID,
+			"position":   *input.Position,
+		}).Error; err != nil {
+			return utils.NewInternalError("Failed to move post", err).
+				WithField("post_id", postID)
+		}
+		return renumberBoardPosts(tx, post.BoardID, "position asc, id asc")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Reload the post to get its new rank and position
+	if err := s.db.First(&post, post.ID).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to reload post", err)
+	}
+
+	return &post, nil
+}
+
+// getManagedBoard gets a board the user can organize, which must not be locked
+func (s *SectionService) getManagedBoard(boardID, userID uint) (*models.Board, error) {
+	board, err := s.boardService.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.boardService.IsBoardAdmin(board, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to organize this board").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	if board.IsLocked {
+		return nil, utils.NewForbiddenError("This board is locked and doesn't allow modifications").
+			WithField("board_id", boardID)
+	}
+
+	return board, nil
+}
+
+// getSection gets a section of a board
+func (s *SectionService) getSection(boardID, sectionID uint) (*models.BoardSection, error) {
+	var section models.BoardSection
+	if result := s.db.Where("id = ? AND board_id = ?", sectionID, boardID).First(&section); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Section not found").
+				WithField("section_id", sectionID)
+		}
+		return nil, utils.NewInternalError("Failed to query section", result.Error).
+			WithField("section_id", sectionID)
+	}
+	return &section, nil
+}
+
+// ensureDefaultSection gets the default section of a board, creating it first if needed
+func ensureDefaultSection(tx *gorm.DB, boardID uint) (*models.BoardSection, error) {
+	var section models.BoardSection
+	result := tx.Where("board_id = ? AND is_default", boardID).First(&section)
+	if result.Error == nil {
+		return &section, nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, utils.NewInternalError("Failed to query default section", result.Error).
+			WithField("board_id", boardID)
+	}
+
+	section = models.BoardSection{
+		BoardID:   boardID,
+		Title:     defaultSectionTitle,
+		IsDefault: true,
+	}
+	if err := tx.Create(&section).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to create default section", err).
+			WithField("board_id", boardID)
+	}
+	return &section, nil
+}
+
+// resolvePostSection checks that a section belongs to a board and returns the section ID
+// to store on its posts, which is nil for the default section
+func resolvePostSection(db *gorm.DB, boardID, sectionID uint) (*uint, error) {
+	var section models.BoardSection
+	if result := db.Where("id = ? AND board_id = ?", sectionID, boardID).First(&section); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, utils.NewBadRequestError("Section does not belong to this board").
+				WithField("board_id", boardID).
+				WithField("section_id", sectionID)
+		}
+		return nil, utils.NewInternalError("Failed to query section", result.Error).
+			WithField("section_id", sectionID)
+	}
+
+	if section.IsDefault {
+		return nil, nil
+	}
+	return &section.ID, nil
+}
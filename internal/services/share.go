@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"gorm.io/gorm"
+	"image"
+	"image/color"
+	"image/png"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/utils"
+	"net/url"
+	"strings"
+)
+
+// Defaults of the board QR code
+const (
+	defaultQRSize            = 512
+	defaultQRErrorCorrection = QRErrorCorrectionMedium
+	// Printed cards get scuffed and folded, so they use more redundancy
+	defaultShareCardErrorCorrection = QRErrorCorrectionQuartile
+)
+
+// ShareService handles QR codes and printable cards for sharing boards
+type ShareService struct {
+	db           *gorm.DB
+	cfg          *config.Config
+	boardService *BoardService
+}
+
+// NewShareService creates a new ShareService
+func NewShareService(db *gorm.DB, cfg *config.Config, boardService *BoardService) *ShareService {
+	return &ShareService{
+		db:           db,
+		cfg:          cfg,
+		boardService: boardService,
+	}
+}
+
+// ShareFile is a generated file for sharing a board
+type ShareFile struct {
+	Data        []byte
+	ContentType string
+	Filename    string
+}
+
+// GetBoardQR generates the QR code of a board's URL in the client app as a PNG or SVG image
+func (s *ShareService) GetBoardQR(boardID, viewerID uint, query requests.BoardQRQuery) (*ShareFile, error) {
+	board, err := s.boardService.GetViewableBoard(boardID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	level := defaultQRErrorCorrection
+	if query.ErrorCorrection != "" {
+		level, _ = ParseQRErrorCorrection(query.ErrorCorrection)
+	}
+	size := query.Size
+	if size == 0 {
+		size = defaultQRSize
+	}
+
+	code, err := s.encodeBoardURL(board, level)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Format == "svg" {
+		return &ShareFile{
+			Data:        qrSVG(code, size),
+			ContentType: "image/svg+xml",
+			Filename:    board.Slug + "-qr.svg",
+		}, nil
+	}
+
+	data, err := qrPNG(code, size)
+	if err != nil {
+		return nil, err
+	}
+	return &ShareFile{
+		Data:        data,
+		ContentType: "image/png",
+		Filename:    board.Slug + "-qr.png",
+	}, nil
+}
+
+// GetShareCard generates a printable PDF card with the board's title and QR code
+func (s *ShareService) GetShareCard(boardID, viewerID uint, query requests.ShareCardQuery) (*ShareFile, error) {
+	board, err := s.boardService.GetViewableBoard(boardID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	level := defaultShareCardErrorCorrection
+	if query.ErrorCorrection != "" {
+		level, _ = ParseQRErrorCorrection(query.ErrorCorrection)
+	}
+	paper := pdfPaperA4
+	if query.Paper == "letter" {
+		paper = pdfPaperLetter
+	}
+
+	code, err := s.encodeBoardURL(board, level)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShareFile{
+		Data:        renderShareCard(board, s.boardURL(board), code, paper),
+		ContentType: "application/pdf",
+		Filename:    board.Slug + "-share-card.pdf",
+	}, nil
+}
+
+// boardURL returns the URL of a board in the client app
+func (s *ShareService) boardURL(board *models.Board) string {
+	return strings.TrimRight(s.cfg.ClientURL, "/") + "/boards/" + url.PathEscape(board.Slug)
+}
+
+// encodeBoardURL encodes the URL of a board as a QR code
+func (s *ShareService) encodeBoardURL(board *models.Board, level QRErrorCorrection) (*QRCode, error) {
+	code, err := EncodeQR([]byte(s.boardURL(board)), level)
+	if err != nil {
+		return nil, utils.NewInternalError("Unable to generate QR code", err).
+			WithField("board_id", board.ID)
+	}
+	return code, nil
+}
+
+// qrPNG draws a QR code with its quiet zone as a size by size PNG. Modules are drawn as
+// whole pixels so scanners see sharp edges; leftover pixels widen the quiet zone.
+func qrPNG(code *QRCode, size int) ([]byte, error) {
+	modules := code.Size + 2*qrQuietZone
+	scale := size / modules
+	if scale < 1 {
+		return nil, utils.NewValidationError(fmt.Sprintf("size must be at least %d for this board's QR code", modules)).
+			WithFieldErrors(utils.FieldError{Field: "size", Message: fmt.Sprintf("must be at least %d", modules)})
+	}
+	offset := (size - scale*code.Size) / 2
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if !code.Dark(x, y) {
+				continue
+			}
+			for py := offset + y*scale; py < offset+(y+1)*scale; py++ {
+				row := img.Pix[py*img.Stride:]
+				for px := offset + x*scale; px < offset+(x+1)*scale; px++ {
+					row[px] = 1
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, utils.NewInternalError("Unable to encode QR code", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// qrSVG draws a QR code with its quiet zone as an SVG image displayed at size by size.
+// Runs of dark modules in a row are merged into one rectangle to keep the path short.
+func qrSVG(code *QRCode, size int) []byte {
+	modules := code.Size + 2*qrQuietZone
+
+	var path strings.Builder
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; {
+			if !code.Dark(x, y) {
+				x++
+				continue
+			}
+			run := 1
+			for code.Dark(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", x+qrQuietZone, y+qrQuietZone, run, run)
+			x += run
+		}
+	}
+
+	return []byte(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+			`<rect width="%d" height="%d" fill="#ffffff"/><path fill="#000000" d="%s"/></svg>`,
+		size, size, modules, modules, modules, modules, path.String(),
+	))
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"kudoboard-api/internal/models"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// pdfPaper is a page size in points
+type pdfPaper struct {
+	Width  float64
+	Height float64
+}
+
+// Paper sizes of the share card
+var (
+	pdfPaperA4     = pdfPaper{Width: 595.28, Height: 841.89}
+	pdfPaperLetter = pdfPaper{Width: 612, Height: 792}
+)
+
+// pdfFont is one of the standard PDF fonts every viewer has, so nothing is embedded
+type pdfFont struct {
+	Resource string
+	BaseFont string
+	// Widths holds the advance of the characters from ' ' to '~' in thousandths of the font size
+	Widths [95]int
+}
+
+// pdfDefaultWidth is the advance used for characters outside the widths tables
+const pdfDefaultWidth = 556
+
+var (
+	pdfHelvetica = pdfFont{Resource: "F1", BaseFont: "Helvetica", Widths: [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}}
+	pdfHelveticaBold = pdfFont{Resource: "F2", BaseFont: "Helvetica-Bold", Widths: [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}}
+)
+
+// encode converts text to the WinAnsi encoding of the standard fonts. Characters the
+// encoding lacks become question marks.
+func (f pdfFont) encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok || b < ' ' {
+			b = '?'
+		}
+		encoded = append(encoded, b)
+	}
+	return encoded
+}
+
+// width returns the width of text in points at a font size
+func (f pdfFont) width(text string, size float64) float64 {
+	total := 0
+	for _, b := range f.encode(text) {
+		if b >= ' ' && b <= '~' {
+			total += f.Widths[b-' ']
+		} else {
+			total += pdfDefaultWidth
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// wrap splits text into at most maxLines lines that fit maxWidth points. Text that doesn't
+// fit ends with an ellipsis.
+func (f pdfFont) wrap(text string, size, maxWidth float64, maxLines int) []string {
+	var lines []string
+	var current string
+	for _, word := range strings.Fields(text) {
+		// Break words longer than a line
+		for f.width(word, size) > maxWidth {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			runes := []rune(word)
+			cut := len(runes) - 1
+			for cut > 1 && f.width(string(runes[:cut]), size) > maxWidth {
+				cut--
+			}
+			lines = append(lines, string(runes[:cut]))
+			word = string(runes[cut:])
+		}
+
+		switch {
+		case current == "":
+			current = word
+		case f.width(current+" "+word, size) <= maxWidth:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	if len(lines) <= maxLines {
+		return lines
+	}
+
+	lines = lines[:maxLines]
+	last := []rune(lines[maxLines-1])
+	for len(last) > 0 && f.width(string(last)+"...", size) > maxWidth {
+		last = last[:len(last)-1]
+	}
+	lines[maxLines-1] = strings.TrimRight(string(last), " ") + "..."
+	return lines
+}
+
+// pdfPage collects the drawing operators of a page
+type pdfPage struct {
+	content bytes.Buffer
+}
+
+// fillColor sets the color of the following fills and text
+func (p *pdfPage) fillColor(c [3]uint8) {
+	fmt.Fprintf(&p.content, "%s %s %s rg\n", pdfNumber(float64(c[0])/255), pdfNumber(float64(c[1])/255), pdfNumber(float64(c[2])/255))
+}
+
+// rect adds a rectangle to the current path, with its bottom left corner at x, y
+func (p *pdfPage) rect(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re\n", pdfNumber(x), pdfNumber(y), pdfNumber(width), pdfNumber(height))
+}
+
+// fill fills the current path
+func (p *pdfPage) fill() {
+	p.content.WriteString("f\n")
+}
+
+// centeredText draws a line of text centered on x with its baseline at y
+func (p *pdfPage) centeredText(font pdfFont, size, x, y float64, text string) {
+	left := x - font.width(text, size)/2
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font.Resource, pdfNumber(size), pdfNumber(left), pdfNumber(y), pdfEscape(font.encode(text)))
+}
+
+// renderShareCard lays out the printable card of a board: the title and receiver at the
+// top, the QR code in the middle and the URL to type in below it
+func renderShareCard(board *models.Board, boardURL string, code *QRCode, paper pdfPaper) []byte {
+	const margin = 56.0
+	page := &pdfPage{}
+	center := paper.Width / 2
+	contentWidth := paper.Width - 2*margin
+	textColor := [3]uint8{0x22, 0x22, 0x22}
+	mutedColor := [3]uint8{0x6b, 0x6b, 0x6b}
+
+	// A band in the board's header color
+	if board.ShowHeaderColor {
+		if header, ok := parseHexColor(board.HeaderColor); ok && (header.R < 0xf0 || header.G < 0xf0 || header.B < 0xf0) {
+			page.fillColor([3]uint8{header.R, header.G, header.B})
+			page.rect(0, paper.Height-28, paper.Width, 28)
+			page.fill()
+		}
+	}
+
+	// Title and receiver
+	y := paper.Height - margin - 40
+	page.fillColor(textColor)
+	for _, line := range pdfHelveticaBold.wrap(board.Title, 30, contentWidth, 3) {
+		page.centeredText(pdfHelveticaBold, 30, center, y, line)
+		y -= 36
+	}
+	if receiver := strings.TrimSpace(board.ReceiverName); receiver != "" {
+		page.fillColor(mutedColor)
+		for _, line := range pdfHelvetica.wrap("For "+receiver, 18, contentWidth, 2) {
+			page.centeredText(pdfHelvetica, 18, center, y, line)
+			y -= 24
+		}
+	}
+
+	// QR code, sized to the space left between the heading and the instructions
+	side := min(contentWidth, 360, y-margin-120)
+	module := side / float64(code.Size)
+	left := center - side/2
+	top := y - 24
+	page.fillColor([3]uint8{0, 0, 0})
+	for row := 0; row < code.Size; row++ {
+		for col := 0; col < code.Size; {
+			if !code.Dark(col, row) {
+				col++
+				continue
+			}
+			run := 1
+			for code.Dark(col+run, row) {
+				run++
+			}
+			page.rect(left+float64(col)*module, top-float64(row+1)*module, float64(run)*module, module)
+			col += run
+		}
+	}
+	page.fill()
+
+	// Instructions and the URL for people without a camera
+	y = top - side - 40
+	page.fillColor(textColor)
+	page.centeredText(pdfHelveticaBold, 18, center, y, "Scan to add your message")
+	urlSize := 12.0
+	for urlSize > 7 && pdfHelvetica.width(boardURL, urlSize) > contentWidth {
+		urlSize--
+	}
+	page.fillColor(mutedColor)
+	page.centeredText(pdfHelvetica, urlSize, center, y-24, boardURL)
+	page.centeredText(pdfHelvetica, 9, center, margin/2, "Made with Kudoboard")
+
+	return writePDF(paper, page, board.Title)
+}
+
+// writePDF writes a single page PDF using the standard fonts
+func writePDF(paper pdfPaper, page *pdfPage, title string) []byte {
+	var content bytes.Buffer
+	zw := zlib.NewWriter(&content)
+	_, _ = zw.Write(page.content.Bytes())
+	_ = zw.Close()
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /%s 4 0 R /%s 5 0 R >> >> /Contents 6 0 R >>",
+			pdfNumber(paper.Width), pdfNumber(paper.Height), pdfHelvetica.Resource, pdfHelveticaBold.Resource),
+		fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", pdfHelvetica.BaseFont),
+		fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", pdfHelveticaBold.BaseFont),
+		fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		fmt.Sprintf("<< /Title %s /Producer (Kudoboard) >>", pdfTextString(title)),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, len(objects), xref)
+	return out.Bytes()
+}
+
+// pdfNumber formats a number with at most two decimals
+func pdfNumber(value float64) string {
+	formatted := strconv.FormatFloat(value, 'f', 2, 64)
+	formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	if formatted == "-0" || formatted == "" {
+		return "0"
+	}
+	return formatted
+}
+
+// pdfEscape escapes encoded text for a literal string
+func pdfEscape(text []byte) string {
+	var b strings.Builder
+	for _, c := range text {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// pdfTextString encodes text as a UTF-16 hex string, which metadata fields accept in any language
+func pdfTextString(text string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(text)) {
+		fmt.Fprintf(&b, "%04X", unit)
+	}
+	b.WriteString(">")
+	return b.String()
+}
@@ -3,11 +3,16 @@ package storage
 import (
 	"fmt"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"io"
 	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/log"
+	"mime"
 	"mime/multipart"
 	"net/url"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -81,6 +86,48 @@ func ExtractPathFromURL(fileURL string) (string, error) {
 	return fileURL, nil
 }
 
+// uniqueSuffixPattern matches the suffix added by generateUniqueFilename
+var uniqueSuffixPattern = regexp.MustCompile(`-\d{14}-[0-9a-f]{8}$`)
+
+// CopyFile duplicates a stored file into the same directory and returns the new file's info.
+// Copies are independent, so deleting the original doesn't affect the copy.
+func CopyFile(s StorageService, fileURL string) (*FileInfo, error) {
+	relativePath, err := ExtractPathFromURL(fileURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file URL: %w", err)
+	}
+
+	reader, err := s.Get(fileURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source file: %w", err)
+	}
+	defer reader.Close()
+
+	// Drop the previous unique suffix so names don't grow with every copy
+	filename := path.Base(relativePath)
+	ext := path.Ext(filename)
+	filename = uniqueSuffixPattern.ReplaceAllString(strings.TrimSuffix(filename, ext), "") + ext
+
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return s.SaveFromReader(reader, filename, contentType, path.Dir(relativePath))
+}
+
+// DeleteFiles removes stored files, logging failures instead of returning them.
+// It's meant for best-effort cleanup where a leftover file is picked up by the orphan cleanup job.
+func DeleteFiles(s StorageService, fileURLs []string) {
+	for _, fileURL := range fileURLs {
+		if err := s.Delete(fileURL); err != nil {
+			log.Warn("Failed to delete media",
+				zap.String("file_path", fileURL),
+				zap.Error(err))
+		}
+	}
+}
+
 // Helper function to generate a unique filename
 func generateUniqueFilename(originalFilename string) string {
 	ext := filepath.Ext(originalFilename)
@@ -46,6 +46,9 @@ func (s *StorageCleanupService) CleanOrphanedFiles() error {
 		"theme/",
 		"icon/",
 		"general/",
+		"preview/",
+		"background/",
+		"emoji/",
 	}
 
 	var totalProcessed, totalDeleted, totalErrors int
@@ -147,6 +150,13 @@ func (s *StorageCleanupService) cleanPrefix(prefix string, minAge time.Time) (in
 	return totalProcessed, totalDeleted, totalErrors, nil
 }
 
+// styleBackgroundURL selects the URL of the uploaded background image of a style column
+const styleBackgroundURL = "style->'background'->'image'->>'url'"
+
+// changedBackgroundURL selects the same URL from the old value of a style setting change.
+// Other settings aren't JSON objects, so only style changes are parsed.
+const changedBackgroundURL = "CASE WHEN field = 'style' AND old_value LIKE '{%' THEN (old_value::jsonb)->'background'->'image'->>'url' END"
+
 // findOrphanedFiles efficiently identifies files not referenced in the database
 func (s *StorageCleanupService) findOrphanedFiles(files []FileInfo) ([]FileInfo, error) {
 	// Extract all file paths
@@ -158,9 +168,9 @@ func (s *StorageCleanupService) findOrphanedFiles(files []FileInfo) ([]FileInfo,
 	// Create a map for efficient lookups
 	existingPathsMap := make(map[string]bool)
 
-	// Check posts table
+	// Check posts table, including posts in the trash
 	var existingPostPaths []string
-	if err := s.db.Model(&models.Post{}).
+	if err := s.db.Unscoped().Model(&models.Post{}).
 		Where("media_path IN ? AND media_source = 'internal'", filePaths).
 		Pluck("media_path", &existingPostPaths).Error; err != nil {
 		return nil, err
@@ -169,6 +179,28 @@ func (s *StorageCleanupService) findOrphanedFiles(files []FileInfo) ([]FileInfo,
 		existingPathsMap[path] = true
 	}
 
+	// Check post revisions table, as earlier versions can be restored
+	var revisionPaths []string
+	if err := s.db.Model(&models.PostRevision{}).
+		Where("media_path IN ? AND media_source = 'internal'", filePaths).
+		Pluck("media_path", &revisionPaths).Error; err != nil {
+		return nil, err
+	}
+	for _, path := range revisionPaths {
+		existingPathsMap[path] = true
+	}
+
+	// Check board template posts table
+	var templatePostPaths []string
+	if err := s.db.Model(&models.BoardTemplatePost{}).
+		Where("media_path IN ? AND media_source = 'internal'", filePaths).
+		Pluck("media_path", &templatePostPaths).Error; err != nil {
+		return nil, err
+	}
+	for _, path := range templatePostPaths {
+		existingPathsMap[path] = true
+	}
+
 	// Check themes table - icon_url
 	var iconPaths []string
 	if err := s.db.Model(&models.Theme{}).
@@ -202,6 +234,56 @@ func (s *StorageCleanupService) findOrphanedFiles(files []FileInfo) ([]FileInfo,
 		existingPathsMap[path] = true
 	}
 
+	// Check board previews table - image_url
+	var previewPaths []string
+	if err := s.db.Model(&models.BoardPreview{}).
+		Where("image_url IN ?", filePaths).
+		Pluck("image_url", &previewPaths).Error; err != nil {
+		return nil, err
+	}
+	for _, path := range previewPaths {
+		existingPathsMap[path] = true
+	}
+
+	// Check custom emoji table - image_url
+	var emojiPaths []string
+	if err := s.db.Model(&models.CustomEmoji{}).
+		Where("image_url IN ?", filePaths).
+		Pluck("image_url", &emojiPaths).Error; err != nil {
+		return nil, err
+	}
+	for _, path := range emojiPaths {
+		existingPathsMap[path] = true
+	}
+
+	// Check boards and templates - uploaded style background, including boards in the trash
+	var boardBackgroundPaths []string
+	if err := s.db.Unscoped().Model(&models.Board{}).
+		Where(styleBackgroundURL+" IN ?", filePaths).
+		Pluck(styleBackgroundURL, &boardBackgroundPaths).Error; err != nil {
+		return nil, err
+	}
+	var templateBackgroundPaths []string
+	if err := s.db.Model(&models.BoardTemplate{}).
+		Where(styleBackgroundURL+" IN ?", filePaths).
+		Pluck(styleBackgroundURL, &templateBackgroundPaths).Error; err != nil {
+		return nil, err
+	}
+	for _, path := range append(boardBackgroundPaths, templateBackgroundPaths...) {
+		existingPathsMap[path] = true
+	}
+
+	// Check board setting history - backgrounds a board can be reverted to
+	var historyBackgroundPaths []string
+	if err := s.db.Model(&models.BoardSettingChange{}).
+		Where(changedBackgroundURL+" IN ?", filePaths).
+		Pluck(changedBackgroundURL, &historyBackgroundPaths).Error; err != nil {
+		return nil, err
+	}
+	for _, path := range historyBackgroundPaths {
+		existingPathsMap[path] = true
+	}
+
 	// Find orphaned files
 	var orphanedFiles []FileInfo
 	for _, file := range files {
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"gorm.io/gorm"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/dto/requests"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/services/storage"
+	"kudoboard-api/internal/utils"
+	"strings"
+)
+
+// receiverNamePlaceholder is replaced by the receiver's name when a board is created from a template
+const receiverNamePlaceholder = "{receiver_name}"
+
+// TemplateService handles board templates
+type TemplateService struct {
+	db           *gorm.DB
+	storage      storage.StorageService
+	cfg          *config.Config
+	boardService *BoardService
+}
+
+// NewTemplateService creates a new TemplateService
+func NewTemplateService(db *gorm.DB, storage storage.StorageService, cfg *config.Config, boardService *BoardService) *TemplateService {
+	return &TemplateService{
+		db:           db,
+		storage:      storage,
+		cfg:          cfg,
+		boardService: boardService,
+	}
+}
+
+// CreateTemplate saves an existing board's settings, and optionally its posts, as a template
+func (s *TemplateService) CreateTemplate(userID uint, input requests.CreateTemplateRequest) (*models.BoardTemplate, error) {
+	// Find source board
+	board, err := s.boardService.GetBoardByID(input.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user can manage the board
+	if !s.boardService.IsBoardAdmin(board, userID) {
+		return nil, utils.NewForbiddenError("You don't have permission to create a template from this board").
+			WithField("board_id", board.ID).
+			WithField("user_id", userID)
+	}
+
+	scope := input.Scope
+	if scope == "" {
+		scope = models.TemplateScopePersonal
+	}
+
+	// Only site admins can publish templates for everyone
+	if scope == models.TemplateScopeOrg {
+		var user models.User
+		if result := s.db.First(&user, userID); result.Error != nil || !user.IsAdmin {
+			return nil, utils.NewForbiddenError("Only administrators can create organization-wide templates").
+				WithField("user_id", userID)
+		}
+	}
+
+	// Turn the receiver's name in the title into a placeholder
+	titleFormat := board.Title
+	if board.ReceiverName != "" {
+		titleFormat = strings.ReplaceAll(titleFormat, board.ReceiverName, receiverNamePlaceholder)
+	}
+
+	template := models.BoardTemplate{
+		Name:                 input.Name,
+		Description:          input.Description,
+		Scope:                scope,
+		CreatorID:            userID,
+		TitleFormat:          titleFormat,
+		FontName:             board.FontName,
+		FontSize:             board.FontSize,
+		HeaderColor:          board.HeaderColor,
+		ShowHeaderColor:      board.ShowHeaderColor,
+		ThemeID:              board.ThemeID,
+		Effect:               board.Effect,
+		Style:                board.Style,
+		EnableIntroAnimation: board.EnableIntroAnimation,
+		IsPrivate:            board.IsPrivate,
+		AllowAnonymous:       board.AllowAnonymous,
+		EnableReminders:      board.EnableReminders,
+		RequireApproval:      board.RequireApproval,
+	}
+
+	var copiedMedia []string
+	if input.IncludePosts {
+		var posts []models.Post
+		if err := s.db.Where("board_id = ? AND status = ?", board.ID, models.PostStatusApproved).
+			Order("position asc").Find(&posts).Error; err != nil {
+			return nil, utils.NewInternalError("Failed to fetch board posts", err).
+				WithField("board_id", board.ID)
+		}
+
+		for _, post := range posts {
+			templatePost := models.BoardTemplatePost{
+				AuthorName:      post.AuthorName,
+				Content:         post.Content,
+				ContentFormat:   post.ContentFormat,
+				MediaPath:       post.MediaPath,
+				MediaType:       post.MediaType,
+				MediaSource:     post.MediaSource,
+				BackgroundColor: post.BackgroundColor,
+				TextColor:       post.TextColor,
+				Position:        post.Position,
+			}
+
+			// Copy internal media so the template outlives the board
+			if post.MediaPath != "" && post.MediaSource == "internal" {
+				fileInfo, err := storage.CopyFile(s.storage, post.MediaPath)
+				if err != nil {
+					storage.DeleteFiles(s.storage, copiedMedia)
+					return nil, utils.NewInternalError("Failed to copy post media", err).
+						WithField("post_id", post.ID)
+				}
+				templatePost.MediaPath = fileInfo.URL
+				copiedMedia = append(copiedMedia, fileInfo.URL)
+			}
+
+			template.Posts = append(template.Posts, templatePost)
+		}
+	}
+
+	// Save template together with its posts
+	if result := s.db.Create(&template); result.Error != nil {
+		storage.DeleteFiles(s.storage, copiedMedia)
+		return nil, utils.NewInternalError("Failed to create template", result.Error).
+			WithField("board_id", board.ID)
+	}
+
+	return &template, nil
+}
+
+// ListTemplates lists the user's personal templates and all organization-wide templates
+func (s *TemplateService) ListTemplates(userID uint) ([]models.BoardTemplate, error) {
+	var templates []models.BoardTemplate
+	if err := s.db.Preload("Posts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	}).
+		Where("creator_id = ? OR scope = ?", userID, models.TemplateScopeOrg).
+		Order("scope asc, name asc").
+		Find(&templates).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to fetch templates", err).
+			WithField("user_id", userID)
+	}
+
+	return templates, nil
+}
+
+// GetTemplate gets a template the user is allowed to use
+func (s *TemplateService) GetTemplate(templateID, userID uint) (*models.BoardTemplate, error) {
+	var template models.BoardTemplate
+	if result := s.db.Preload("Posts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	}).First(&template, templateID); result.Error != nil {
+		return nil, utils.NewNotFoundError("Template not found").
+			WithField("template_id", templateID)
+	}
+
+	if template.Scope != models.TemplateScopeOrg && template.CreatorID != userID {
+		return nil, utils.NewForbiddenError("You don't have access to this template").
+			WithField("template_id", templateID).
+			WithField("user_id", userID)
+	}
+
+	return &template, nil
+}
+
+// DeleteTemplate deletes a template and its seeded media
+func (s *TemplateService) DeleteTemplate(templateID, userID uint) error {
+	var template models.BoardTemplate
+	if result := s.db.Preload("Posts").First(&template, templateID); result.Error != nil {
+		return utils.NewNotFoundError("Template not found").
+			WithField("template_id", templateID)
+	}
+
+	// Only the creator or a site admin can delete a template
+	if template.CreatorID != userID {
+		var user models.User
+		if result := s.db.First(&user, userID); result.Error != nil || !user.IsAdmin {
+			return utils.NewForbiddenError("You don't have permission to delete this template").
+				WithField("template_id", templateID).
+				WithField("user_id", userID)
+		}
+	}
+
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", templateID).Delete(&models.BoardTemplatePost{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete template posts", err).
+				WithField("template_id", templateID)
+		}
+
+		if err := tx.Delete(&template).Error; err != nil {
+			return utils.NewInternalError("Failed to delete template", err).
+				WithField("template_id", templateID)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	var media []string
+	for _, post := range template.Posts {
+		if post.MediaPath != "" && post.MediaSource == "internal" {
+			media = append(media, post.MediaPath)
+		}
+	}
+	storage.DeleteFiles(s.storage, media)
+
+	return nil
+}
+
+// CreateBoardFromTemplate creates a new board using a template's settings and seeded posts
+func (s *TemplateService) CreateBoardFromTemplate(templateID, userID uint, input requests.CreateBoardFromTemplateRequest) (*models.Board, error) {
+	template, err := s.GetTemplate(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	board := models.Board{
+		Title:                strings.ReplaceAll(template.TitleFormat, receiverNamePlaceholder, input.ReceiverName),
+		ReceiverName:         input.ReceiverName,
+		CreatorID:            userID,
+		FontName:             template.FontName,
+		FontSize:             template.FontSize,
+		HeaderColor:          template.HeaderColor,
+		ShowHeaderColor:      template.ShowHeaderColor,
+		ThemeID:              template.ThemeID,
+		Effect:               template.Effect,
+		Style:                template.Style,
+		EnableIntroAnimation: template.EnableIntroAnimation,
+		IsPrivate:            template.IsPrivate,
+		AllowAnonymous:       template.AllowAnonymous,
+		EnableReminders:      template.EnableReminders,
+		RequireApproval:      template.RequireApproval,
+		AllowEmbed:           true,
+		LayoutMode:           models.BoardLayoutGrid,
+		DeliveryAt:           input.DeliveryAt,
+	}
+	if input.Title != nil {
+		board.Title = *input.Title
+	}
+
+	// Check the board's names against the content filter
+	if err := s.boardService.filterBoardText(0, userID, &board.Title, &board.ReceiverName); err != nil {
+		return nil, err
+	}
+
+	// Copy seeded media so the board doesn't depend on the template's files
+	posts := make([]models.Post, 0, len(template.Posts))
+	ranks := utils.RankKeys(len(template.Posts))
+	var copiedMedia []string
+	for i, templatePost := range template.Posts {
+		post := models.Post{
+			AuthorID:        &userID,
+			AuthorName:      templatePost.AuthorName,
+			Content:         templatePost.Content,
+			ContentFormat:   templatePost.ContentFormat,
+			ContentHTML:     renderPostContent(templatePost.Content, templatePost.ContentFormat),
+			MediaPath:       templatePost.MediaPath,
+			MediaType:       templatePost.MediaType,
+			MediaSource:     templatePost.MediaSource,
+			BackgroundColor: templatePost.BackgroundColor,
+			TextColor:       templatePost.TextColor,
+			Position:        templatePost.Position,
+			Rank:            ranks[i],
+		}
+
+		if templatePost.MediaPath != "" && templatePost.MediaSource == "internal" {
+			fileInfo, err := storage.CopyFile(s.storage, templatePost.MediaPath)
+			if err != nil {
+				storage.DeleteFiles(s.storage, copiedMedia)
+				return nil, utils.NewInternalError("Failed to copy template media", err).
+					WithField("template_id", templateID)
+			}
+			post.MediaPath = fileInfo.URL
+			copiedMedia = append(copiedMedia, fileInfo.URL)
+		}
+
+		posts = append(posts, post)
+	}
+
+	err = utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := createBoardWithSettings(tx, &board); err != nil {
+			return utils.NewInternalError("Failed to create board", err)
+		}
+
+		// Add creator as admin contributor
+		contributor := models.BoardContributor{
+			BoardID: board.ID,
+			UserID:  userID,
+			Role:    models.RoleAdmin,
+		}
+		if err := tx.Create(&contributor).Error; err != nil {
+			return utils.NewInternalError("Failed to add creator as admin", err)
+		}
+
+		for i := range posts {
+			posts[i].BoardID = board.ID
+		}
+		if len(posts) > 0 {
+			if err := tx.Create(&posts).Error; err != nil {
+				return utils.NewInternalError("Failed to create template posts", err).
+					WithField("template_id", templateID)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		storage.DeleteFiles(s.storage, copiedMedia)
+		return nil, err
+	}
+
+	return &board, nil
+}
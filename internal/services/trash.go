@@ -0,0 +1,516 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"kudoboard-api/internal/config"
+	"kudoboard-api/internal/log"
+	"kudoboard-api/internal/models"
+	"kudoboard-api/internal/services/storage"
+	"kudoboard-api/internal/utils"
+	"time"
+)
+
+// TrashedBoard is a deleted board together with the number of posts deleted with it
+type TrashedBoard struct {
+	models.Board
+	PostCount int64
+}
+
+// TrashedPost is a deleted post together with the title of its board
+type TrashedPost struct {
+	models.Post
+	BoardTitle string
+}
+
+// TrashService lists, restores and purges deleted boards and posts
+type TrashService struct {
+	db           *gorm.DB
+	storage      storage.StorageService
+	cfg          *config.Config
+	boardService *BoardService
+}
+
+// NewTrashService creates a new TrashService
+func NewTrashService(db *gorm.DB, storage storage.StorageService, cfg *config.Config, boardService *BoardService) *TrashService {
+	return &TrashService{
+		db:           db,
+		storage:      storage,
+		cfg:          cfg,
+		boardService: boardService,
+	}
+}
+
+// PurgeAt returns when an item deleted at the given time will be permanently removed
+func (s *TrashService) PurgeAt(deletedAt time.Time) time.Time {
+	return deletedAt.Add(s.cfg.TrashRetention)
+}
+
+// ListTrash lists the deleted boards and posts the user can still restore
+func (s *TrashService) ListTrash(userID uint) ([]TrashedBoard, []TrashedPost, error) {
+	cutoff := time.Now().Add(-s.cfg.TrashRetention)
+
+	// Boards can only be deleted, and therefore restored, by their creator
+	var boards []models.Board
+	if err := s.db.Unscoped().
+		Where("creator_id = ? AND deleted_at IS NOT NULL AND deleted_at > ?", userID, cutoff).
+		Order("deleted_at desc").
+		Find(&boards).Error; err != nil {
+		return nil, nil, utils.NewInternalError("Failed to fetch deleted boards", err).
+			WithField("user_id", userID)
+	}
+
+	// Count the posts deleted together with each board
+	boardIDs := make([]uint, len(boards))
+	for i, board := range boards {
+		boardIDs[i] = board.ID
+	}
+
+	type boardPostCount struct {
+		BoardID   uint
+		PostCount int64
+	}
+	var counts []boardPostCount
+	if len(boardIDs) > 0 {
+		if err := s.db.Unscoped().Model(&models.Post{}).
+			Select("posts.board_id, COUNT(*) AS post_count").
+			Joins("JOIN boards ON boards.id = posts.board_id AND boards.deleted_at = posts.deleted_at").
+			Where("posts.board_id IN ?", boardIDs).
+			Group("posts.board_id").
+			Scan(&counts).Error; err != nil {
+			return nil, nil, utils.NewInternalError("Failed to count deleted posts", err).
+				WithField("user_id", userID)
+		}
+	}
+
+	countsByBoard := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		countsByBoard[count.BoardID] = count.PostCount
+	}
+
+	trashedBoards := make([]TrashedBoard, len(boards))
+	for i, board := range boards {
+		trashedBoards[i].Board = board
+		trashedBoards[i].PostCount = countsByBoard[board.ID]
+	}
+
+	// Posts deleted on their own from boards that are still active, which the user wrote or manages
+	var posts []TrashedPost
+	if err := s.db.Unscoped().Model(&models.Post{}).
+		Select("posts.*, boards.title AS board_title").
+		Joins("JOIN boards ON boards.id = posts.board_id AND boards.deleted_at IS NULL").
+		Where("posts.deleted_at IS NOT NULL AND posts.deleted_at > ?", cutoff).
+		Where("posts.author_id = ? OR boards.creator_id = ? OR EXISTS (SELECT 1 FROM board_contributors WHERE board_contributors.board_id = posts.board_id AND board_contributors.user_id = ? AND board_contributors.role = ?)",
+			userID, userID, userID, models.RoleAdmin).
+		Order("posts.deleted_at desc").
+		Scan(&posts).Error; err != nil {
+		return nil, nil, utils.NewInternalError("Failed to fetch deleted posts", err).
+			WithField("user_id", userID)
+	}
+
+	return trashedBoards, posts, nil
+}
+
+// RestoreBoard restores a deleted board together with the posts deleted with it
+func (s *TrashService) RestoreBoard(boardID, userID uint) (*models.Board, error) {
+	board, err := s.getTrashedBoard(boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Give the board a new slug if another active board has taken it in the meantime
+	var conflicts int64
+	s.db.Model(&models.Board{}).Where("slug = ?", board.Slug).Count(&conflicts)
+	if conflicts > 0 {
+		board.Slug = uuid.New().String()
+	}
+
+	err = utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Post{}).
+			Where("board_id = ? AND deleted_at = ?", boardID, board.DeletedAt.Time).
+			Update("deleted_at", nil).Error; err != nil {
+			return utils.NewInternalError("Failed to restore board posts", err).
+				WithField("board_id", boardID)
+		}
+
+		if err := tx.Unscoped().Model(board).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"slug":       board.Slug,
+		}).Error; err != nil {
+			return utils.NewInternalError("Failed to restore board", err).
+				WithField("board_id", boardID)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	board.DeletedAt = gorm.DeletedAt{}
+	return board, nil
+}
+
+// RestorePost restores a post deleted on its own
+func (s *TrashService) RestorePost(postID, userID uint) (*models.Post, error) {
+	post, board, err := s.getTrashedPost(postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if board is locked
+	if board.IsLocked {
+		return nil, utils.NewForbiddenError("This board is locked and doesn't allow modifications").
+			WithField("board_id", board.ID)
+	}
+
+	if err := s.db.Unscoped().Model(post).Update("deleted_at", nil).Error; err != nil {
+		return nil, utils.NewInternalError("Failed to restore post", err).
+			WithField("post_id", postID)
+	}
+
+	post.DeletedAt = gorm.DeletedAt{}
+	return post, nil
+}
+
+// DeleteBoardPermanently purges a board from the trash before its retention window ends
+func (s *TrashService) DeleteBoardPermanently(boardID, userID uint) error {
+	board, err := s.getTrashedBoard(boardID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.purgeBoard(board)
+}
+
+// DeletePostPermanently purges a post from the trash before its retention window ends
+func (s *TrashService) DeletePostPermanently(postID, userID uint) error {
+	post, _, err := s.getTrashedPost(postID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.purgePosts([]models.Post{*post})
+}
+
+// PurgeExpired permanently removes boards and posts whose retention window has ended
+func (s *TrashService) PurgeExpired() error {
+	cutoff := time.Now().Add(-s.cfg.TrashRetention)
+
+	var boards []models.Board
+	if err := s.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at <= ?", cutoff).
+		Find(&boards).Error; err != nil {
+		return fmt.Errorf("failed to fetch expired boards: %w", err)
+	}
+
+	var purgedBoards int
+	for i := range boards {
+		if err := s.purgeBoard(&boards[i]); err != nil {
+			log.Error("Failed to purge board",
+				zap.Uint("board_id", boards[i].ID),
+				zap.Error(err))
+			continue
+		}
+		purgedBoards++
+	}
+
+	// Posts of boards still in the trash are purged with their board
+	var posts []models.Post
+	if err := s.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at <= ?", cutoff).
+		Where("board_id NOT IN (SELECT id FROM boards WHERE deleted_at IS NOT NULL)").
+		Find(&posts).Error; err != nil {
+		return fmt.Errorf("failed to fetch expired posts: %w", err)
+	}
+
+	if err := s.purgePosts(posts); err != nil {
+		return fmt.Errorf("failed to purge posts: %w", err)
+	}
+
+	log.Info("Trash purge job completed",
+		zap.Int("boards_purged", purgedBoards),
+		zap.Int("posts_purged", len(posts)))
+
+	return nil
+}
+
+// purgeBoard permanently removes a board with everything attached to it
+func (s *TrashService) purgeBoard(board *models.Board) error {
+	var posts []models.Post
+	if err := s.db.Unscoped().Where("board_id = ?", board.ID).Find(&posts).Error; err != nil {
+		return utils.NewInternalError("Failed to fetch board posts for media cleanup", err).
+			WithField("board_id", board.ID)
+	}
+
+	var revisions []models.PostRevision
+	if err := s.db.Where("post_id IN (SELECT id FROM posts WHERE board_id = ?)", board.ID).
+		Find(&revisions).Error; err != nil {
+		return utils.NewInternalError("Failed to fetch post revisions for media cleanup", err).
+			WithField("board_id", board.ID)
+	}
+
+	var previews []models.BoardPreview
+	if err := s.db.Where("board_id = ?", board.ID).Find(&previews).Error; err != nil {
+		return utils.NewInternalError("Failed to fetch board preview for cleanup", err).
+			WithField("board_id", board.ID)
+	}
+
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		// Delete all associated post reactions
+		if err := tx.Exec("DELETE FROM post_reactions WHERE post_id IN (SELECT id FROM posts WHERE board_id = ?)", board.ID).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board post reactions", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete the earlier versions of the board's posts
+		if err := tx.Exec("DELETE FROM post_revisions WHERE post_id IN (SELECT id FROM posts WHERE board_id = ?)", board.ID).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board post revisions", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete all comments on the board's posts
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.PostComment{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board post comments", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete all associated posts
+		if err := tx.Unscoped().Where("board_id = ?", board.ID).Delete(&models.Post{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board posts", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete the sections the posts were grouped in
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.BoardSection{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board sections", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete all associated contributors
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.BoardContributor{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board contributors", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete all pending invitations
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.BoardInvitation{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board invitations", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete expected contributor tracking
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.ExpectedContributor{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete expected contributors", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete settings history
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.BoardSettingChange{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board history", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete abuse reports about the board and its posts
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.Report{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete reports", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete the board's content filters and their events
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.ContentFilterEvent{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete content filter events", err).
+				WithField("board_id", board.ID)
+		}
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.ContentFilterList{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete content filters", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete view analytics
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.BoardView{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board views", err).
+				WithField("board_id", board.ID)
+		}
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.BoardDailyStat{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board analytics", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete the cached preview image record
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.BoardPreview{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board preview", err).
+				WithField("board_id", board.ID)
+		}
+
+		// Delete the board
+		if err := tx.Unscoped().Delete(board).Error; err != nil {
+			return utils.NewInternalError("Failed to delete board", err).
+				WithField("board_id", board.ID)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	s.deletePostMedia(posts, revisions)
+	for _, preview := range previews {
+		if err := s.storage.Delete(preview.ImageURL); err != nil {
+			log.Warn("Failed to delete board preview image",
+				zap.Uint("board_id", board.ID),
+				zap.String("file_path", preview.ImageURL),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// purgePosts permanently removes posts with their reactions, comments and revisions
+func (s *TrashService) purgePosts(posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]uint, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	var revisions []models.PostRevision
+	if err := s.db.Where("post_id IN ?", postIDs).Find(&revisions).Error; err != nil {
+		return utils.NewInternalError("Failed to fetch post revisions for media cleanup", err)
+	}
+
+	err := utils.WithTransaction(s.db, func(tx *gorm.DB) error {
+		// Delete reactions
+		if err := tx.Where("post_id IN ?", postIDs).Delete(&models.PostReaction{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete post reactions", err)
+		}
+
+		// Delete comments
+		if err := tx.Where("post_id IN ?", postIDs).Delete(&models.PostComment{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete post comments", err)
+		}
+
+		// Delete earlier versions
+		if err := tx.Where("post_id IN ?", postIDs).Delete(&models.PostRevision{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete post revisions", err)
+		}
+
+		// Delete abuse reports about the posts
+		if err := tx.Where("target_type = ? AND target_id IN ?", models.ReportTargetPost, postIDs).
+			Delete(&models.Report{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete post reports", err)
+		}
+
+		// Delete posts
+		if err := tx.Unscoped().Where("id IN ?", postIDs).Delete(&models.Post{}).Error; err != nil {
+			return utils.NewInternalError("Failed to delete posts", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	s.deletePostMedia(posts, revisions)
+	return nil
+}
+
+// deletePostMedia removes the internal media files of purged posts and their earlier
+// versions. Files shared by several versions are deleted once.
+func (s *TrashService) deletePostMedia(posts []models.Post, revisions []models.PostRevision) {
+	mediaPaths := make(map[string]uint)
+	for _, post := range posts {
+		if post.MediaPath != "" && post.MediaSource == "internal" {
+			mediaPaths[post.MediaPath] = post.ID
+		}
+	}
+	for _, revision := range revisions {
+		if revision.MediaPath != "" && revision.MediaSource == "internal" {
+			mediaPaths[revision.MediaPath] = revision.PostID
+		}
+	}
+
+	for mediaPath, postID := range mediaPaths {
+		if err := s.storage.Delete(mediaPath); err != nil {
+			log.Warn("Failed to delete media",
+				zap.Uint("post_id", postID),
+				zap.String("file_path", mediaPath),
+				zap.Error(err))
+		}
+	}
+}
+
+// getTrashedBoard loads a deleted board that the user can still restore
+func (s *TrashService) getTrashedBoard(boardID, userID uint) (*models.Board, error) {
+	var board models.Board
+	if result := s.db.Unscoped().Where("deleted_at IS NOT NULL").First(&board, boardID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Board not found in trash").
+				WithField("board_id", boardID)
+		}
+		return nil, utils.NewInternalError("Failed to query board", result.Error).
+			WithField("board_id", boardID)
+	}
+
+	// Only the creator can delete a board, so only the creator can manage it in the trash
+	if board.CreatorID != userID {
+		return nil, utils.NewForbiddenError("You don't have permission to manage this board").
+			WithField("board_id", boardID).
+			WithField("user_id", userID)
+	}
+
+	if !board.DeletedAt.Time.After(time.Now().Add(-s.cfg.TrashRetention)) {
+		return nil, utils.NewNotFoundError("Board not found in trash").
+			WithField("board_id", boardID)
+	}
+
+	return &board, nil
+}
+
+// getTrashedPost loads a post deleted on its own that the user can still restore, with its board
+func (s *TrashService) getTrashedPost(postID, userID uint) (*models.Post, *models.Board, error) {
+	var post models.Post
+	if result := s.db.Unscoped().Where("deleted_at IS NOT NULL").First(&post, postID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil, utils.NewNotFoundError("Post not found in trash").
+				WithField("post_id", postID)
+		}
+		return nil, nil, utils.NewInternalError("Failed to query post", result.Error).
+			WithField("post_id", postID)
+	}
+
+	if !post.DeletedAt.Time.After(time.Now().Add(-s.cfg.TrashRetention)) {
+		return nil, nil, utils.NewNotFoundError("Post not found in trash").
+			WithField("post_id", postID)
+	}
+
+	// Posts deleted with their board come back with the board
+	var board models.Board
+	if result := s.db.First(&board, post.BoardID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil, utils.NewBadRequestError("The board of this post is in the trash, restore the board instead").
+				WithField("post_id", postID).
+				WithField("board_id", post.BoardID)
+		}
+		return nil, nil, utils.NewInternalError("Failed to query board", result.Error).
+			WithField("board_id", post.BoardID)
+	}
+
+	// The author or a board admin can manage the post
+	if (post.AuthorID == nil || *post.AuthorID != userID) && !s.boardService.IsBoardAdmin(&board, userID) {
+		return nil, nil, utils.NewForbiddenError("You don't have permission to manage this post").
+			WithField("post_id", postID).
+			WithField("user_id", userID)
+	}
+
+	return &post, &board, nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -215,3 +216,73 @@ func (s *UnsplashService) GetById(photoID string) (map[string]interface{}, error
 
 	return result, nil
 }
+
+// UnsplashPhoto is the part of an Unsplash photo needed to show it with its attribution
+type UnsplashPhoto struct {
+	ID               string
+	URL              string
+	Color            string
+	AuthorName       string
+	AuthorURL        string
+	PhotoURL         string
+	DownloadLocation string
+}
+
+// GetPhoto gets a photo with the URL and attribution needed to use it
+func (s *UnsplashService) GetPhoto(photoID string) (*UnsplashPhoto, error) {
+	result, err := s.GetById(photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	text := func(object map[string]interface{}, key string) string {
+		value, _ := object[key].(string)
+		return value
+	}
+	urls, _ := result["urls"].(map[string]interface{})
+	links, _ := result["links"].(map[string]interface{})
+	user, _ := result["user"].(map[string]interface{})
+	userLinks, _ := user["links"].(map[string]interface{})
+
+	photo := &UnsplashPhoto{
+		ID:               text(result, "id"),
+		URL:              text(urls, "full"),
+		Color:            text(result, "color"),
+		AuthorName:       text(user, "name"),
+		AuthorURL:        text(userLinks, "html"),
+		PhotoURL:         text(links, "html"),
+		DownloadLocation: text(links, "download_location"),
+	}
+	if photo.URL == "" {
+		return nil, utils.NewInternalError("Unexpected response format from Unsplash", nil).
+			WithField("photo_id", photoID)
+	}
+
+	return photo, nil
+}
+
+// TrackDownload tells Unsplash a photo was used, as their API guidelines require
+func (s *UnsplashService) TrackDownload(photo *UnsplashPhoto) error {
+	// Only call Unsplash itself with the access key
+	if !strings.HasPrefix(photo.DownloadLocation, unsplashBaseURL+"/") {
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, photo.DownloadLocation, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Client-ID %s", s.cfg.UnsplashAccessKey))
+	req.Header.Add("Accept-Version", "v1")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status code: %d", resp.StatusCode)
+	}
+	return nil
+}
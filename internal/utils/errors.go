@@ -17,6 +17,12 @@ var (
 	ErrValidation    = errors.New("validation error")
 )
 
+// FieldError describes why the value of one request field is invalid
+type FieldError struct {
+	Field   string
+	Message string
+}
+
 // AppError represents an application error with additional context
 type AppError struct {
 	Code        string                 // Error code for client
@@ -25,6 +31,7 @@ type AppError struct {
 	stack       string                 // Stack trace
 	OperationID string                 // Optional operation ID for tracking
 	Fields      map[string]interface{} // Additional context fields
+	FieldErrors []FieldError           // Invalid request fields, returned to the client
 }
 
 // Error implements the error interface
@@ -60,6 +67,12 @@ func (e *AppError) WithFields(fields map[string]interface{}) *AppError {
 	return e
 }
 
+// WithFieldErrors adds the request fields that are invalid
+func (e *AppError) WithFieldErrors(fieldErrors ...FieldError) *AppError {
+	e.FieldErrors = append(e.FieldErrors, fieldErrors...)
+	return e
+}
+
 // WithOperationID adds an operation ID for tracking
 func (e *AppError) WithOperationID(id string) *AppError {
 	e.OperationID = id
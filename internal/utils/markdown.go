@@ -0,0 +1,364 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Post content can be written in a small subset of Markdown: paragraphs, line breaks,
+// bullet and numbered lists, bold, italics, links and emoji shortcodes. Anything else,
+// raw HTML included, is shown as it was written.
+
+var (
+	bulletItemPattern     = regexp.MustCompile(`^ {0,3}[-*+][ \t]+(.*)$`)
+	orderedItemPattern    = regexp.MustCompile(`^ {0,3}(\d{1,9})[.)][ \t]+(.*)$`)
+	emojiShortcodePattern = regexp.MustCompile(`^:([a-z0-9_+-]+):`)
+	paragraphBreakPattern = regexp.MustCompile(`\n[ \t]*\n`)
+)
+
+// emojiShortcodes maps the supported shortcodes to their emoji
+var emojiShortcodes = map[string]string{
+	"+1":                    "👍",
+	"100":                   "💯",
+	"balloon":               "🎈",
+	"birthday":              "🎂",
+	"blue_heart":            "💙",
+	"blush":                 "😊",
+	"bouquet":               "💐",
+	"cake":                  "🍰",
+	"champagne":             "🍾",
+	"clap":                  "👏",
+	"coffee":                "☕",
+	"confetti_ball":         "🎊",
+	"crown":                 "👑",
+	"cry":                   "😢",
+	"fire":                  "🔥",
+	"gift":                  "🎁",
+	"green_heart":           "💚",
+	"grin":                  "😁",
+	"heart":                 "❤️",
+	"heart_eyes":            "😍",
+	"hugs":                  "🤗",
+	"joy":                   "😂",
+	"kissing_heart":         "😘",
+	"laughing":              "😆",
+	"medal":                 "🏅",
+	"muscle":                "💪",
+	"ok_hand":               "👌",
+	"partying_face":         "🥳",
+	"pray":                  "🙏",
+	"purple_heart":          "💜",
+	"rainbow":               "🌈",
+	"raised_hands":          "🙌",
+	"rocket":                "🚀",
+	"rose":                  "🌹",
+	"slightly_smiling_face": "🙂",
+	"smile":                 "😄",
+	"smiley":                "😃",
+	"sob":                   "😭",
+	"sparkles":              "✨",
+	"star":                  "⭐",
+	"star_struck":           "🤩",
+	"sunflower":             "🌻",
+	"sunglasses":            "😎",
+	"tada":                  "🎉",
+	"thinking":              "🤔",
+	"thumbsup":              "👍",
+	"trophy":                "🏆",
+	"wave":                  "👋",
+	"wink":                  "😉",
+	"yellow_heart":          "💛",
+}
+
+// EmojiForShortcode returns the emoji of a supported shortcode, without the colons
+func EmojiForShortcode(shortcode string) (string, bool) {
+	emoji, exists := emojiShortcodes[shortcode]
+	return emoji, exists
+}
+
+// RenderMarkdown renders content written in the Markdown subset as sanitized HTML.
+// Blank lines separate paragraphs and single line breaks are kept.
+func RenderMarkdown(source string) string {
+	var out strings.Builder
+	list := "" // "ul" or "ol" while a list is open
+	inParagraph, inItem := false, false
+
+	closeBlock := func() {
+		if inParagraph {
+			out.WriteString("</p>")
+		}
+		if inItem {
+			out.WriteString("</li>")
+		}
+		if list != "" {
+			out.WriteString("</" + list + ">")
+		}
+		list, inParagraph, inItem = "", false, false
+	}
+
+	openItem := func(kind, start, text string) {
+		if list != kind {
+			closeBlock()
+			out.WriteString("<" + kind)
+			if number, _ := strconv.Atoi(start); kind == "ol" && number > 1 {
+				out.WriteString(` start="` + strconv.Itoa(number) + `"`)
+			}
+			out.WriteString(">")
+			list = kind
+		} else if inItem {
+			out.WriteString("</li>")
+		}
+		out.WriteString("<li>" + renderInline(strings.TrimSpace(text), true))
+		inItem = true
+	}
+
+	for _, line := range strings.Split(normalizeNewlines(source), "\n") {
+		if strings.TrimSpace(line) == "" {
+			closeBlock()
+			continue
+		}
+		if match := bulletItemPattern.FindStringSubmatch(line); match != nil {
+			openItem("ul", "", match[1])
+			continue
+		}
+		if match := orderedItemPattern.FindStringSubmatch(line); match != nil {
+			openItem("ol", match[1], match[2])
+			continue
+		}
+
+		// Other lines continue the open paragraph or list item
+		text := renderInline(strings.TrimSpace(line), true)
+		switch {
+		case inItem || inParagraph:
+			out.WriteString("<br>" + text)
+		default:
+			out.WriteString("<p>" + text)
+			inParagraph = true
+		}
+	}
+	closeBlock()
+
+	return SanitizeHTML(out.String())
+}
+
+// RenderPlainText renders plain text as sanitized HTML, showing it exactly as written.
+// Blank lines separate paragraphs and single line breaks are kept.
+func RenderPlainText(source string) string {
+	var out strings.Builder
+	for _, paragraph := range paragraphBreakPattern.Split(normalizeNewlines(source), -1) {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		lines := strings.Split(paragraph, "\n")
+		for i, line := range lines {
+			lines[i] = html.EscapeString(line)
+		}
+		out.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>")
+	}
+	return SanitizeHTML(out.String())
+}
+
+// normalizeNewlines turns Windows and old Mac line endings into \n
+func normalizeNewlines(source string) string {
+	return strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(source)
+}
+
+// renderInline renders the emphasis, links and emoji of a line of text. Link labels are
+// rendered without links, since links can't be nested.
+func renderInline(text string, allowLinks bool) string {
+	var out strings.Builder
+	plainStart := 0
+	flush := func(end int) {
+		out.WriteString(html.EscapeString(text[plainStart:end]))
+	}
+
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\' && i+1 < len(text) && isASCIIPunctuation(text[i+1]):
+			// A backslash shows the next character as written
+			flush(i)
+			out.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			plainStart = i
+			continue
+
+		case c == '[' && allowLinks:
+			if label, href, end, ok := parseLink(text, i); ok {
+				flush(i)
+				writeLink(&out, href, renderInline(label, false))
+				i, plainStart = end, end
+				continue
+			}
+
+		case (c == 'h' || c == 'H') && allowLinks && (i == 0 || !isWordChar(text[i-1])):
+			if href, end, ok := parseAutolink(text, i); ok {
+				flush(i)
+				writeLink(&out, href, html.EscapeString(href))
+				i, plainStart = end, end
+				continue
+			}
+
+		case c == '*' || c == '_':
+			if tag, inner, end, ok := parseEmphasis(text, i); ok {
+				flush(i)
+				out.WriteString("<" + tag + ">" + renderInline(inner, allowLinks) + "</" + tag + ">")
+				i, plainStart = end, end
+				continue
+			}
+
+		case c == ':':
+			if match := emojiShortcodePattern.FindStringSubmatch(text[i:]); match != nil {
+				if emoji, exists := emojiShortcodes[match[1]]; exists {
+					flush(i)
+					out.WriteString(emoji)
+					i += len(match[0])
+					plainStart = i
+					continue
+				}
+			}
+		}
+		i++
+	}
+	flush(len(text))
+
+	return out.String()
+}
+
+// parseLink reads a [label](url) link starting at text[start]
+func parseLink(text string, start int) (label, href string, end int, ok bool) {
+	// Find the closing bracket, allowing brackets inside the label
+	depth := 0
+	closing := -1
+	for i := start; i < len(text) && closing < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closing = i
+			}
+		}
+	}
+	if closing <= start+1 || closing+1 >= len(text) || text[closing+1] != '(' {
+		return "", "", 0, false
+	}
+
+	// The URL ends at the parenthesis that closes it, so URLs may contain balanced ones
+	urlStart := closing + 2
+	depth = 0
+	for end = urlStart; end < len(text); end++ {
+		if text[end] == '(' {
+			depth++
+		} else if text[end] == ')' {
+			if depth == 0 {
+				break
+			}
+			depth--
+		}
+	}
+	if end >= len(text) {
+		return "", "", 0, false
+	}
+	href = text[urlStart:end]
+	if href == "" || strings.ContainsAny(href, " \t") {
+		return "", "", 0, false
+	}
+
+	return text[start+1 : closing], href, end + 1, true
+}
+
+// parseAutolink reads a bare http or https URL starting at text[start]. Punctuation at the
+// end is left out, as it usually belongs to the sentence.
+func parseAutolink(text string, start int) (href string, end int, ok bool) {
+	rest := strings.ToLower(text[start:])
+	if !strings.HasPrefix(rest, "http://") && !strings.HasPrefix(rest, "https://") {
+		return "", 0, false
+	}
+
+	end = start
+	for end < len(text) && text[end] != ' ' && text[end] != '\t' && text[end] != '<' {
+		end++
+	}
+	for end > start {
+		last := text[end-1]
+		if strings.IndexByte(`.,:;!?'"*_`, last) >= 0 ||
+			(last == ')' && strings.Count(text[start:end], "(") < strings.Count(text[start:end], ")")) {
+			end--
+			continue
+		}
+		break
+	}
+
+	href = text[start:end]
+	if !IsSafeLinkURL(href) {
+		return "", 0, false
+	}
+	return href, end, true
+}
+
+// parseEmphasis reads bold (**text** or __text__) or italic (*text* or _text_) text
+// starting at text[start]. Underscores inside words, as in snake_case, aren't emphasis.
+func parseEmphasis(text string, start int) (tag, inner string, end int, ok bool) {
+	delimiter := text[start : start+1]
+	tag = "em"
+	if start+1 < len(text) && text[start+1] == text[start] {
+		delimiter = text[start : start+2]
+		tag = "strong"
+	}
+	underscore := delimiter[0] == '_'
+
+	open := start + len(delimiter)
+	if open >= len(text) || isSpace(text[open]) || (underscore && start > 0 && isWordChar(text[start-1])) {
+		return "", "", 0, false
+	}
+
+	for i := open + 1; i+len(delimiter) <= len(text); i++ {
+		if !strings.HasPrefix(text[i:], delimiter) {
+			continue
+		}
+		// A single delimiter doesn't close on half of a double one
+		if len(delimiter) == 1 && i+1 < len(text) && text[i+1] == delimiter[0] {
+			i++
+			continue
+		}
+		end = i + len(delimiter)
+		if isSpace(text[i-1]) || (underscore && end < len(text) && isWordChar(text[end])) {
+			continue
+		}
+		return tag, text[open:i], end, true
+	}
+
+	return "", "", 0, false
+}
+
+// writeLink writes a link that opens in a new tab and isn't followed by search engines.
+// Links to unsafe URLs only show their label.
+func writeLink(out *strings.Builder, href, label string) {
+	if !IsSafeLinkURL(href) {
+		out.WriteString(label)
+		return
+	}
+	out.WriteString(`<a href="` + html.EscapeString(href) + `" rel="` + linkRel + `" target="_blank">` + label + "</a>")
+}
+
+// isASCIIPunctuation reports whether a character can be escaped with a backslash
+func isASCIIPunctuation(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+// isWordChar reports whether a byte is part of a word. Bytes of multi-byte characters
+// count as letters.
+func isWordChar(c byte) bool {
+	return c >= 0x80 || c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// isSpace reports whether a byte is a space or tab
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
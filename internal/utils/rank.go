@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// Rank keys order items by plain byte comparison, so an item can be moved between two
+// others by giving it a key that sorts between theirs without touching any other item.
+// Keys are made of digits and lowercase letters and never end in '0', which guarantees
+// there is always room for another key between two different keys.
+const (
+	rankAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	rankBase     = len(rankAlphabet)
+
+	// rankWidth is the number of leading digits of a key that are counted up when appending
+	rankWidth = 6
+	// rankStep is the gap between appended or rebalanced keys, which leaves room for later moves
+	rankStep = rankBase * rankBase
+)
+
+// rankCapacity is the number of values the leading digits of a key can hold
+var rankCapacity = pow(rankBase, rankWidth)
+
+// RankKeys returns evenly spaced keys for count items in order. The keys are rankStep
+// apart, or closer together if that many don't fit in the leading digits.
+func RankKeys(count int) []string {
+	step := min(rankStep, rankCapacity/(count+1))
+	keys := make([]string, count)
+	for i := range keys {
+		keys[i], _ = encodeRank((i + 1) * step)
+	}
+	return keys
+}
+
+// RankAfter returns a key that sorts after last, or the first key when last is empty.
+// Appending counts up the leading digits so keys stay short.
+func RankAfter(last string) string {
+	if last == "" {
+		key, _ := encodeRank(rankStep)
+		return key
+	}
+
+	head := last
+	if len(head) > rankWidth {
+		head = head[:rankWidth]
+	}
+	value := 0
+	for i := 0; i < rankWidth; i++ {
+		digit := 0
+		if i < len(head) {
+			digit = strings.IndexByte(rankAlphabet, head[i])
+		}
+		value = value*rankBase + digit
+	}
+
+	if key, ok := encodeRank((value/rankStep + 1) * rankStep); ok {
+		return key
+	}
+
+	// The leading digits are used up; the key grows until the items are rebalanced
+	key, _ := RankBetween(last, "")
+	return key
+}
+
+// RankBetween returns a key that sorts after a and before b. An empty a means before the
+// first key and an empty b means after the last one.
+func RankBetween(a, b string) (string, error) {
+	if b != "" && a >= b {
+		return "", errors.New("rank keys are not in order")
+	}
+
+	// Walk down both keys, staying equal to each while the new key is still a prefix of it
+	var key []byte
+	followsA, followsB := true, b != ""
+	for i := 0; ; i++ {
+		low := -1
+		if followsA && i < len(a) {
+			low = strings.IndexByte(rankAlphabet, a[i])
+		}
+		high := rankBase
+		if followsB {
+			high = strings.IndexByte(rankAlphabet, b[i])
+		}
+
+		// Finish with a digit strictly between the two, never '0' so the key can't end in one
+		if first := max(low+1, 1); first < high {
+			key = append(key, rankAlphabet[(first+high-1)/2])
+			return string(key), nil
+		}
+
+		// Otherwise copy the lower digit and look for room in the next one
+		digit := max(low, 0)
+		key = append(key, rankAlphabet[digit])
+		if digit > low {
+			followsA = false
+		}
+		if digit < high {
+			followsB = false
+		}
+	}
+}
+
+// encodeRank writes a value as a key of rankWidth digits, without trailing zeros
+func encodeRank(value int) (string, bool) {
+	if value <= 0 || value >= rankCapacity {
+		return "", false
+	}
+
+	digits := make([]byte, rankWidth)
+	for i := rankWidth - 1; i >= 0; i-- {
+		digits[i] = rankAlphabet[value%rankBase]
+		value /= rankBase
+	}
+	return strings.TrimRight(string(digits), "0"), true
+}
+
+// pow raises base to a small non-negative exponent
+func pow(base, exponent int) int {
+	result := 1
+	for i := 0; i < exponent; i++ {
+		result *= base
+	}
+	return result
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"golang.org/x/net/html"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// linkRel is the rel attribute of every link in user content
+const linkRel = "nofollow noopener noreferrer"
+
+// allowedTags lists the tags user content may contain. Every other tag is removed but its
+// text is kept.
+var allowedTags = map[string]bool{
+	"p":      true,
+	"br":     true,
+	"strong": true,
+	"em":     true,
+	"ul":     true,
+	"ol":     true,
+	"li":     true,
+	"a":      true,
+}
+
+// droppedTags lists the tags whose content is removed together with them
+var droppedTags = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"iframe":   true,
+	"object":   true,
+	"embed":    true,
+	"noscript": true,
+	"template": true,
+	"textarea": true,
+	"title":    true,
+	"svg":      true,
+	"math":     true,
+}
+
+// safeLinkSchemes lists the URL schemes links in user content may use
+var safeLinkSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// SanitizeHTML keeps only the allowed tags of an HTML fragment and closes any left open.
+// Links keep an href with a safe scheme and always get linkRel; no other attribute is
+// kept apart from the start of numbered lists.
+func SanitizeHTML(fragment string) string {
+	var out strings.Builder
+	var open []string
+	dropping := 0
+
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.TextToken:
+			if dropping == 0 {
+				out.WriteString(html.EscapeString(token.Data))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedTags[token.Data] {
+				if tokenType == html.StartTagToken {
+					dropping++
+				}
+				continue
+			}
+			if dropping > 0 || !allowedTags[token.Data] {
+				continue
+			}
+			if token.Data == "br" {
+				out.WriteString("<br>")
+				continue
+			}
+			if tag, ok := sanitizeStartTag(token); ok {
+				out.WriteString(tag)
+				open = append(open, token.Data)
+			}
+
+		case html.EndTagToken:
+			if droppedTags[token.Data] {
+				if dropping > 0 {
+					dropping--
+				}
+				continue
+			}
+			if dropping > 0 {
+				continue
+			}
+			// Close the matching open tag and any opened inside it
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.Data {
+					for j := len(open) - 1; j >= i; j-- {
+						out.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+
+	return out.String()
+}
+
+// sanitizeStartTag writes an allowed start tag with only its safe attributes. Links
+// without a safe href are left out.
+func sanitizeStartTag(token html.Token) (string, bool) {
+	switch token.Data {
+	case "a":
+		for _, attr := range token.Attr {
+			if attr.Namespace == "" && attr.Key == "href" && IsSafeLinkURL(attr.Val) {
+				return `<a href="` + html.EscapeString(attr.Val) + `" rel="` + linkRel + `" target="_blank">`, true
+			}
+		}
+		return "", false
+
+	case "ol":
+		for _, attr := range token.Attr {
+			if attr.Namespace == "" && attr.Key == "start" {
+				if start, err := strconv.Atoi(attr.Val); err == nil && start > 1 {
+					return `<ol start="` + strconv.Itoa(start) + `">`, true
+				}
+			}
+		}
+		return "<ol>", true
+
+	default:
+		return "<" + token.Data + ">", true
+	}
+}
+
+// IsSafeLinkURL reports whether a URL can be linked to from user content: an absolute
+// http or https URL with a host, or a mailto address
+func IsSafeLinkURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil || !safeLinkSchemes[strings.ToLower(parsed.Scheme)] {
+		return false
+	}
+	if strings.EqualFold(parsed.Scheme, "mailto") {
+		return parsed.Opaque != ""
+	}
+	return parsed.Host != ""
+}
@@ -0,0 +1,298 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusables maps look-alike letters from other scripts to the Latin letter they imitate
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'А': 'a', 'в': 'b', 'В': 'b', 'е': 'e', 'Е': 'e', 'ё': 'e', 'к': 'k', 'К': 'k',
+	'м': 'm', 'М': 'm', 'н': 'h', 'Н': 'h', 'о': 'o', 'О': 'o', 'р': 'p', 'Р': 'p', 'с': 'c',
+	'С': 'c', 'т': 't', 'Т': 't', 'у': 'y', 'У': 'y', 'х': 'x', 'Х': 'x', 'ѕ': 's', 'Ѕ': 's',
+	'і': 'i', 'І': 'i', 'ї': 'i', 'ј': 'j', 'Ј': 'j', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w', 'һ': 'h',
+	'ӏ': 'i', 'Ӏ': 'i', 'ѵ': 'v', 'п': 'n', 'г': 'r', 'ь': 'b',
+	// Greek
+	'α': 'a', 'Α': 'a', 'β': 'b', 'Β': 'b', 'ε': 'e', 'Ε': 'e', 'Ζ': 'z', 'η': 'n', 'Η': 'h',
+	'ι': 'i', 'Ι': 'i', 'κ': 'k', 'Κ': 'k', 'Μ': 'm', 'ν': 'v', 'Ν': 'n', 'ο': 'o', 'Ο': 'o',
+	'ρ': 'p', 'Ρ': 'p', 'τ': 't', 'Τ': 't', 'υ': 'u', 'Υ': 'y', 'χ': 'x', 'Χ': 'x', 'ω': 'w',
+	'ς': 's',
+	// Latin extensions
+	'ɑ': 'a', 'ɡ': 'g', 'ɩ': 'i', 'ı': 'i', 'ȷ': 'j', 'ʀ': 'r', 'ʏ': 'y', 'ᴄ': 'c', 'ᴏ': 'o',
+	'ᴜ': 'u', 'ᴠ': 'v', 'ᴡ': 'w', 'ᴢ': 'z', 'ß': 's', 'ø': 'o', 'Ø': 'o', 'đ': 'd', 'ł': 'i',
+}
+
+// leetspeak maps digits and symbols commonly used in place of letters.
+// 'l' is folded into 'i' because '1', '!' and '|' stand in for both.
+var leetspeak = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '6': 'g', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '!': 'i', '|': 'i', '+': 't', '€': 'e', '£': 'l', 'l': 'i',
+}
+
+// isFilterTokenRune reports whether a rune can be part of a word for filtering purposes
+func isFilterTokenRune(r rune) bool {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || unicode.Is(unicode.Cf, r) {
+		return true
+	}
+	_, isLeet := leetspeak[r]
+	return isLeet
+}
+
+// NormalizeFilterText folds a piece of text into the form word lists are matched against:
+// compatibility forms and accents are removed, look-alike letters and leetspeak are mapped
+// to Latin letters, and everything except letters is dropped.
+func NormalizeFilterText(text string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(text) {
+		if unicode.IsMark(r) || unicode.Is(unicode.Cf, r) {
+			continue
+		}
+		if mapped, ok := confusables[r]; ok {
+			r = mapped
+		}
+		r = unicode.ToLower(r)
+		if mapped, ok := leetspeak[r]; ok {
+			r = mapped
+		}
+		if unicode.IsLetter(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// collapseRepeats squeezes runs of the same letter into one and reports whether
+// any run was at least three letters long
+func collapseRepeats(word string) (string, bool) {
+	var b strings.Builder
+	var last rune
+	run, longRun := 0, false
+	for _, r := range word {
+		if r == last {
+			run++
+			if run >= 3 {
+				longRun = true
+			}
+			continue
+		}
+		last, run = r, 1
+		b.WriteRune(r)
+	}
+	return b.String(), longRun
+}
+
+// WordMatch is a blocked term found in a piece of text. Start and End are byte offsets
+// into the original text.
+type WordMatch struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// WordMatcher finds blocked terms in text, seeing through leetspeak, look-alike letters,
+// stretched letters ("baaad") and spaced-out letters ("b a d")
+type WordMatcher struct {
+	exact     map[string]string
+	collapsed map[string]string
+	maxWords  int
+}
+
+// NewWordMatcher creates a matcher for a list of terms. Terms may contain several words.
+func NewWordMatcher(terms []string) *WordMatcher {
+	m := &WordMatcher{
+		exact:     make(map[string]string),
+		collapsed: make(map[string]string),
+		maxWords:  1,
+	}
+
+	for _, term := range terms {
+		words := strings.Fields(term)
+		normalized := make([]string, 0, len(words))
+		for _, word := range words {
+			if n := NormalizeFilterText(word); n != "" {
+				normalized = append(normalized, n)
+			}
+		}
+		if len(normalized) == 0 {
+			continue
+		}
+
+		key := strings.Join(normalized, " ")
+		m.exact[key] = term
+		collapsed, _ := collapseRepeats(key)
+		m.collapsed[collapsed] = term
+		if len(normalized) > m.maxWords {
+			m.maxWords = len(normalized)
+		}
+	}
+
+	return m
+}
+
+// filterToken is a word of the original text, with its bounds once edge symbols are trimmed
+type filterToken struct {
+	start, end               int
+	trimmedStart, trimmedEnd int
+	trimmed                  string
+}
+
+// tokenize splits text into words, joining runs of single letters separated by spaces or dots
+func tokenize(text string) []filterToken {
+	var tokens []filterToken
+	start := -1
+	for i, r := range text + " " {
+		if i < len(text) && isFilterTokenRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			word := text[start:i]
+			isEdge := func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			}
+			trimmedStart := start + len(word) - len(strings.TrimLeftFunc(word, isEdge))
+			trimmedEnd := start + len(strings.TrimRightFunc(word, isEdge))
+			if trimmedEnd < trimmedStart {
+				trimmedEnd = trimmedStart
+			}
+			tokens = append(tokens, filterToken{
+				start:        start,
+				end:          i,
+				trimmedStart: trimmedStart,
+				trimmedEnd:   trimmedEnd,
+				trimmed:      NormalizeFilterText(text[trimmedStart:trimmedEnd]),
+			})
+			start = -1
+		}
+	}
+
+	// Join spaced-out letters such as "b a d" or "b.a.d" into a single word
+	var joined []filterToken
+	for i := 0; i < len(tokens); i++ {
+		j := i
+		for j+1 < len(tokens) && utf8.RuneCountInString(tokens[j].trimmed) == 1 &&
+			utf8.RuneCountInString(tokens[j+1].trimmed) == 1 &&
+			!strings.ContainsAny(text[tokens[j].end:tokens[j+1].start], "\n\r") &&
+			tokens[j+1].start-tokens[j].end <= 2 {
+			j++
+		}
+		if j == i {
+			joined = append(joined, tokens[i])
+			continue
+		}
+
+		var word strings.Builder
+		for k := i; k <= j; k++ {
+			word.WriteString(tokens[k].trimmed)
+		}
+		joined = append(joined, filterToken{
+			start:        tokens[i].start,
+			end:          tokens[j].end,
+			trimmedStart: tokens[i].trimmedStart,
+			trimmedEnd:   tokens[j].trimmedEnd,
+			trimmed:      word.String(),
+		})
+		i = j
+	}
+
+	return joined
+}
+
+// lookup finds the term matching a normalized word or phrase
+func (m *WordMatcher) lookup(candidate string) (string, bool) {
+	if term, ok := m.exact[candidate]; ok {
+		return term, true
+	}
+	if collapsed, stretched := collapseRepeats(candidate); stretched {
+		if term, ok := m.collapsed[collapsed]; ok {
+			return term, true
+		}
+	}
+	return "", false
+}
+
+// Find returns the blocked terms found in text, in order of appearance
+func (m *WordMatcher) Find(text string) []WordMatch {
+	if len(m.exact) == 0 {
+		return nil
+	}
+
+	tokens := tokenize(text)
+	var matches []WordMatch
+	for i := 0; i < len(tokens); i++ {
+		// Prefer the longest phrase starting at this word
+		matched := false
+		for n := min(m.maxWords, len(tokens)-i); n >= 1 && !matched; n-- {
+			first, last := tokens[i], tokens[i+n-1]
+
+			// Symbols at the edges of a word may stand in for letters ("$hit") or be
+			// punctuation ("shit!"), so try the word with and without them
+			if n == 1 {
+				for _, bounds := range [][2]int{
+					{first.start, first.end},
+					{first.start, first.trimmedEnd},
+					{first.trimmedStart, first.end},
+				} {
+					candidate := NormalizeFilterText(text[bounds[0]:bounds[1]])
+					if candidate == "" {
+						continue
+					}
+					if term, ok := m.lookup(candidate); ok {
+						matches = append(matches, WordMatch{Term: term, Start: bounds[0], End: bounds[1]})
+						matched = true
+						break
+					}
+				}
+				if matched {
+					break
+				}
+			}
+
+			parts := make([]string, n)
+			for k := 0; k < n; k++ {
+				parts[k] = tokens[i+k].trimmed
+			}
+			if candidate := strings.Join(parts, " "); strings.TrimSpace(candidate) != "" {
+				if term, ok := m.lookup(candidate); ok {
+					matches = append(matches, WordMatch{Term: term, Start: first.trimmedStart, End: last.trimmedEnd})
+					i += n - 1
+					matched = true
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+// MaskMatches replaces every letter of the matched words with asterisks
+func MaskMatches(text string, matches []WordMatch) string {
+	if len(matches) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match.Start < last {
+			continue
+		}
+		b.WriteString(text[last:match.Start])
+		for _, r := range text[match.Start:match.End] {
+			if unicode.IsSpace(r) {
+				b.WriteRune(r)
+			} else {
+				b.WriteRune('*')
+			}
+		}
+		last = match.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}